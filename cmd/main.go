@@ -3,10 +3,25 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/Panorama-Block/xrpl-data-extraction/config"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/accounts"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/activations"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/alerts"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/balances"
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/deposits"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/ingest"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/invoices"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/issuers"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/labels"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/network"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/pending"
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/server"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/tracker"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/validators"
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
 	"github.com/gofiber/fiber/v2"
 )
@@ -33,8 +48,102 @@ func main() {
 		log.Fatalf("Erro ao criar índices: %v", err)
 	}
 
+	// Carregar fees e reserves atuais da rede
+	if err := network.SyncFeeSettings(manager.GetHTTPClient()); err != nil {
+		log.Printf("⚠️ Não foi possível carregar o FeeSettings: %v", err)
+	}
+
+	// Acompanhar fees/reserves e votações de amendments a cada ledger validado
+	if err := network.Start(manager.GetWSClient(), manager.GetHTTPClient()); err != nil {
+		log.Printf("⚠️ Não foi possível acompanhar as configurações da rede: %v", err)
+	}
+
+	// Extrair as mudanças de estado de cada ledger validado, se configurado (busca cada ledger completo via HTTP)
+	if cfg.StateChangesEnabled {
+		if err := transactions.StartStateChanges(manager.GetWSClient(), manager.GetHTTPClient()); err != nil {
+			log.Printf("⚠️ Não foi possível acompanhar as mudanças de estado: %v", err)
+		}
+	}
+
+	// Restaurar manifests de validadores conhecidos
+	if err := validators.LoadManifests(); err != nil {
+		log.Printf("⚠️ Não foi possível carregar os manifests: %v", err)
+	}
+
+	// Registrar os hashes validados usados nos relatórios de concordância dos validadores
+	if err := validators.Start(manager.GetWSClient()); err != nil {
+		log.Printf("⚠️ Não foi possível acompanhar os ledgers validados dos validadores: %v", err)
+	}
+
+	// Registrar o histórico de saldos antes de qualquer ingestão de transações
+	balances.Start()
+	accounts.StartSettingsHistory()
+	activations.Start()
+
+	// Carregar rótulos de contas e rotular automaticamente as AMMs vistas nas transações
+	if err := labels.Load(); err != nil {
+		log.Printf("⚠️ Não foi possível carregar os rótulos: %v", err)
+	}
+	labels.Start(manager.GetHTTPClient())
+
+	// Carregar regras de alerta de transferências grandes e avaliá-las no feed de transações
+	if err := alerts.LoadRules(); err != nil {
+		log.Printf("⚠️ Não foi possível carregar as regras de alerta: %v", err)
+	}
+	alerts.Start(manager.GetHTTPClient())
+
+	// Monitorar obrigações dos emissores configurados: variações por transação e snapshots periódicos
+	if err := issuers.Start(manager.GetWSClient()); err != nil {
+		log.Printf("⚠️ Não foi possível carregar os emissores monitorados: %v", err)
+	}
+	go issuers.Run(manager.GetHTTPClient(), 30*time.Second)
+
+	// Retomar sincronizações de histórico de contas
+	if err := accounts.ResumeAccountSyncs(manager.GetHTTPClient(), manager.GetWSClient()); err != nil {
+		log.Printf("⚠️ Não foi possível retomar as sincronizações de contas: %v", err)
+	}
+
+	// Restaurar as watchlists de contas ativas
+	if err := accounts.RestoreWatchlists(manager.GetWSClient()); err != nil {
+		log.Printf("⚠️ Não foi possível restaurar as watchlists: %v", err)
+	}
+
+	// Iniciar a ingestão global de transações, se configurada
+	if cfg.TransactionsIngestConfig != "" {
+		ingestConfig, err := ingest.LoadConfigFile(cfg.TransactionsIngestConfig)
+		if err != nil {
+			log.Fatalf("Erro ao carregar a configuração de ingestão: %v", err)
+		}
+		if err := ingest.Start(manager.GetWSClient(), ingestConfig); err != nil {
+			log.Fatalf("Erro ao iniciar a ingestão de transações: %v", err)
+		}
+	}
+
+	// Retomar o monitoramento de depósitos das contas registradas
+	if err := deposits.Start(manager.GetWSClient()); err != nil {
+		log.Printf("⚠️ Não foi possível iniciar o monitoramento de depósitos: %v", err)
+	}
+
+	// Conciliar cobranças em aberto e expirar as vencidas
+	if err := invoices.Start(manager.GetWSClient()); err != nil {
+		log.Printf("⚠️ Não foi possível iniciar a conciliação de cobranças: %v", err)
+	}
+	go invoices.RunExpiry(time.Minute)
+
+	// Expirar transações pendentes a cada ledger validado
+	if err := pending.Start(manager.GetWSClient()); err != nil {
+		log.Printf("⚠️ Não foi possível acompanhar os ledgers validados do pool pendente: %v", err)
+	}
+
+	// Restaurar e acompanhar transações registradas por hash
+	if err := tracker.DefaultTracker.Restore(); err != nil {
+		log.Printf("⚠️ Não foi possível restaurar as transações acompanhadas: %v", err)
+	}
+	go tracker.DefaultTracker.Run(manager.GetHTTPClient(), 5*time.Second)
+
 	// Apply logging middleware globally
 	app.Use(server.LoggingMiddleware)
+	app.Use(server.LabelsMiddleware)
 
 	// Setup routes with manager's clients
 	server.SetupRoutes(app, manager.GetHTTPClient(), manager.GetWSClient())
@@ -9,6 +9,12 @@ type Config struct {
 	WebSocketURL string
 	APIBaseURL   string
 	MongoURI     string
+
+	// TransactionsIngestConfig is the optional path of the global transactions ingester config (JSON)
+	TransactionsIngestConfig string
+
+	// StateChangesEnabled extracts the state changes of every validated ledger, fetching each one over HTTP
+	StateChangesEnabled bool
 }
 
 func LoadConfig() *Config {
@@ -25,6 +31,9 @@ func LoadConfig() *Config {
         WebSocketURL: wsURL,
         APIBaseURL:   apiURL,
 				MongoURI:     mongoURI,
+
+        TransactionsIngestConfig: os.Getenv("TRANSACTIONS_INGEST_CONFIG"),
+        StateChangesEnabled:      os.Getenv("STATE_CHANGES_ENABLED") == "true",
     }
 }
 
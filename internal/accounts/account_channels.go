@@ -31,7 +31,7 @@ func FetchHistoricalAccountChannels(client *xrpl.HTTPClient, account string, des
 }
 
 // StreamRecentAccountChannels subscribes to real-time data using WebSocket
-func StreamRecentAccountChannels(client *xrpl.WebSocketClient, account string, destinationAccount string, callback func(*AccountChannelsWSResponse)) error {
+func StreamRecentAccountChannels(client *xrpl.WebSocketClient, account string, destinationAccount string, callback func(*AccountChannelsWSResponse)) (func(), error) {
 	// Build the params
 	params := AccountChannelsParam{
 		Account: account,
@@ -49,14 +49,8 @@ func StreamRecentAccountChannels(client *xrpl.WebSocketClient, account string, d
 		LedgerIndex:       "validated",
 	}
 
-	// Subscribe to WebSocket
-	err := client.Subscribe(payload)
-	if err != nil {
-		return err
-	}
-
-	// Read messages from WebSocket
-	client.ReadMessages(func(message []byte) {
+	// Send the command and read its response
+	return client.Request(payload, func(message []byte) {
 		var response AccountChannelsWSResponse
 		err := json.Unmarshal(message, &response)
 		if err != nil {
@@ -64,5 +58,4 @@ func StreamRecentAccountChannels(client *xrpl.WebSocketClient, account string, d
 		}
 		callback(&response)
 	})
-	return nil
 }
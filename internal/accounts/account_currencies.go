@@ -22,7 +22,7 @@ func FetchAccountCurrencies(client *xrpl.HTTPClient, account string, ledgerIndex
 	return client.Post("", payload)
 }
 
-func StreamAccountCurrencies(client *xrpl.WebSocketClient, account string, ledgerIndex string, callback func(*AccountCurrenciesWSResponse)) error {
+func StreamAccountCurrencies(client *xrpl.WebSocketClient, account string, ledgerIndex string, callback func(*AccountCurrenciesWSResponse)) (func(), error) {
 	params := AccountCurrenciesParam{
 		Account: account,
 	}
@@ -37,12 +37,8 @@ func StreamAccountCurrencies(client *xrpl.WebSocketClient, account string, ledge
 		LedgerIndex: ledgerIndex,
 	}
 
-	err := client.Subscribe(payload)
-	if err != nil {
-		return err
-	}
-
-	client.ReadMessages(func(message []byte) {
+	// Send the command and read its response
+	return client.Request(payload, func(message []byte) {
 		var response AccountCurrenciesWSResponse
 		err := json.Unmarshal(message, &response)
 		if err != nil {
@@ -51,5 +47,4 @@ func StreamAccountCurrencies(client *xrpl.WebSocketClient, account string, ledge
 		callback(&response)
 	})
 
-	return nil
 }
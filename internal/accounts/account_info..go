@@ -3,6 +3,7 @@ package accounts
 import (
 	"encoding/json"
 	"log"
+	"strconv"
 
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
 )
@@ -32,7 +33,7 @@ func FetchAccountInfo(client *xrpl.HTTPClient, account string, ledgerIndex strin
 }
 
 // StreamAccountInfo subscribes to real-time account information using WebSocket
-func StreamAccountInfo(client *xrpl.WebSocketClient, account string, ledgerIndex string, queue bool, callback func(*AccountInfoWSResponse)) error {
+func StreamAccountInfo(client *xrpl.WebSocketClient, account string, ledgerIndex string, queue bool, callback func(*AccountInfoWSResponse)) (func(), error) {
 	// Build the parameters
 	params := AccountInfoParam{
 		Account: account,
@@ -51,20 +52,80 @@ func StreamAccountInfo(client *xrpl.WebSocketClient, account string, ledgerIndex
 		Queue:       queue,
 	}
 
-	// Subscribe to WebSocket
-	err := client.Subscribe(payload)
-	if err != nil {
-		return err
-	}
-
-	// Read messages from WebSocket
-	client.ReadMessages(func(message []byte) {
+	// Send the command and read its response
+	return client.Request(payload, func(message []byte) {
 		var response AccountInfoWSResponse
 		err := json.Unmarshal(message, &response)
 		if err != nil {
 			return
 		}
+
+		var root struct {
+			Result struct {
+				AccountData *AccountRootFields `json:"account_data"`
+			} `json:"result"`
+		}
+		if json.Unmarshal(message, &root) == nil && root.Result.AccountData != nil {
+			settings := DecodeSettings(*root.Result.AccountData)
+			response.Result.AccountSettings = &settings
+		}
 		callback(&response)
 	})
-	return nil
+}
+
+// AddAccountSettings decodes the account_data of a raw account_info response and adds it as result.account_settings
+func AddAccountSettings(response map[string]interface{}) {
+	result, ok := response["result"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	accountData, ok := result["account_data"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if settings, err := DecodeSettingsMap(accountData); err == nil {
+		result["account_settings"] = settings
+	}
+}
+
+// AddAccountReserves computes the reserve breakdown for the account_data of a raw account_info
+// response, at the ledger it was read from, and adds it as result.account_reserves
+func AddAccountReserves(client *xrpl.HTTPClient, response map[string]interface{}) {
+	result, ok := response["result"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	var data struct {
+		AccountData struct {
+			Account    string `json:"Account"`
+			Balance    string `json:"Balance"`
+			OwnerCount int    `json:"OwnerCount"`
+		} `json:"account_data"`
+		LedgerIndex        int `json:"ledger_index"`
+		LedgerCurrentIndex int `json:"ledger_current_index"`
+	}
+	encoded, _ := json.Marshal(result)
+	if err := json.Unmarshal(encoded, &data); err != nil || data.AccountData.Account == "" {
+		return
+	}
+
+	ledgerIndex := data.LedgerIndex
+	if ledgerIndex == 0 {
+		ledgerIndex = data.LedgerCurrentIndex
+	}
+
+	objects, err := fetchPortfolioObjects(client, data.AccountData.Account, strconv.Itoa(ledgerIndex))
+	if err != nil {
+		log.Printf("⚠️ Não foi possível calcular as reservas de %s: %v", data.AccountData.Account, err)
+		return
+	}
+	values, err := FetchReserveValues(client)
+	if err != nil {
+		log.Printf("⚠️ Não foi possível obter reserve_base/reserve_inc: %v", err)
+		return
+	}
+
+	result["account_reserves"] = CalculateReserves(data.AccountData.Account, ledgerIndex, data.AccountData.Balance, data.AccountData.OwnerCount, objects, values)
 }
@@ -12,10 +12,14 @@ func FetchAccountLines(client *xrpl.HTTPClient, account string, ledgerIndex stri
 	// Build the parameters
 	params := AccountLinesParam{
 		Account: account,
+		Limit:   limit,
 	}
 	if ledgerIndex != "" {
 		params.LedgerIndex = ledgerIndex
 	}
+	if marker != "" {
+		params.Marker = marker
+	}
 
 	// Build the JSON-RPC payload
 	payload := AccountLinesRequest{
@@ -31,7 +35,7 @@ func FetchAccountLines(client *xrpl.HTTPClient, account string, ledgerIndex stri
 }
 
 // StreamAccountLines subscribes to real-time trust lines using WebSocket
-func StreamAccountLines(client *xrpl.WebSocketClient, account string, ledgerIndex string, limit int, callback func(*AccountLinesWSResponse)) error {
+func StreamAccountLines(client *xrpl.WebSocketClient, account string, ledgerIndex string, limit int, callback func(*AccountLinesWSResponse)) (func(), error) {
 	// Build the parameters
 	params := AccountLinesParam{
 		Account: account,
@@ -48,14 +52,8 @@ func StreamAccountLines(client *xrpl.WebSocketClient, account string, ledgerInde
 		LedgerIndex: ledgerIndex,
 	}
 
-	// Subscribe to WebSocket
-	err := client.Subscribe(payload)
-	if err != nil {
-		return err
-	}
-
-	// Read messages from WebSocket
-	client.ReadMessages(func(message []byte) {
+	// Send the command and read its response
+	return client.Request(payload, func(message []byte) {
 		var response AccountLinesWSResponse
 		err := json.Unmarshal(message, &response)
 		if err != nil {
@@ -63,5 +61,4 @@ func StreamAccountLines(client *xrpl.WebSocketClient, account string, ledgerInde
 		}
 		callback(&response)
 	})
-	return nil
 }
@@ -0,0 +1,174 @@
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// ObjectTypes maps the type filters of account_objects to the ledger entry types they select
+var ObjectTypes = map[string]string{
+	"check":           "Check",
+	"credential":      "Credential",
+	"deposit_preauth": "DepositPreauth",
+	"did":             "DID",
+	"escrow":          "Escrow",
+	"mpt_issuance":    "MPTokenIssuance",
+	"mptoken":         "MPToken",
+	"nft_offer":       "NFTokenOffer",
+	"nft_page":        "NFTokenPage",
+	"offer":           "Offer",
+	"oracle":          "Oracle",
+	"payment_channel": "PayChannel",
+	"signer_list":     "SignerList",
+	"state":           "RippleState",
+	"ticket":          "Ticket",
+}
+
+// FetchAccountObjects fetches a page of the ledger objects owned by an account via HTTP.
+// objectType filters by type (e.g. "escrow"); marker continues a previous page
+func FetchAccountObjects(client *xrpl.HTTPClient, account string, ledgerIndex string, objectType string, limit int, marker interface{}) ([]byte, error) {
+	params := AccountObjectsParam{
+		Account:     account,
+		LedgerIndex: ledgerIndex,
+		Type:        objectType,
+		Limit:       limit,
+		Marker:      marker,
+	}
+
+	payload := AccountObjectsRequest{
+		Method: "account_objects",
+		Params: []AccountObjectsParam{params},
+	}
+
+	return client.Post("", payload)
+}
+
+// FetchAccountObjectsPage fetches a page of account_objects and decodes each object into its typed struct
+func FetchAccountObjectsPage(client *xrpl.HTTPClient, account string, ledgerIndex string, objectType string, limit int, marker interface{}) (*AccountObjectsPage, error) {
+	if objectType != "" {
+		if _, ok := ObjectTypes[objectType]; !ok {
+			return nil, fmt.Errorf("unknown object type %q", objectType)
+		}
+	}
+
+	response, err := FetchAccountObjects(client, account, ledgerIndex, objectType, limit, marker)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded AccountObjectsResponse
+	if err := json.Unmarshal(response, &decoded); err != nil {
+		return nil, err
+	}
+	if decoded.Result.Error != "" {
+		return nil, &transactions.RPCError{Code: decoded.Result.Error, Message: decoded.Result.ErrorMessage}
+	}
+
+	page := &AccountObjectsPage{
+		Account:     decoded.Result.Account,
+		LedgerIndex: decoded.Result.LedgerIndex,
+		Validated:   decoded.Result.Validated,
+		Objects:     make([]interface{}, 0, len(decoded.Result.AccountObjects)),
+		Marker:      decoded.Result.Marker,
+	}
+	for _, raw := range decoded.Result.AccountObjects {
+		object, err := DecodeLedgerObject(raw)
+		if err != nil {
+			return nil, err
+		}
+		page.Objects = append(page.Objects, object)
+	}
+	return page, nil
+}
+
+// DecodeLedgerObject decodes a ledger entry into its typed struct based on LedgerEntryType.
+// Entry types without a struct are returned as a map
+func DecodeLedgerObject(raw json.RawMessage) (interface{}, error) {
+	var header LedgerObjectHeader
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, err
+	}
+
+	var object interface{}
+	switch header.LedgerEntryType {
+	case "Check":
+		object = &CheckObject{}
+	case "Credential":
+		object = &CredentialObject{}
+	case "DepositPreauth":
+		object = &DepositPreauthObject{}
+	case "DID":
+		object = &DIDObject{}
+	case "Escrow":
+		object = &EscrowObject{}
+	case "MPToken":
+		object = &MPTokenObject{}
+	case "MPTokenIssuance":
+		object = &MPTokenIssuanceObject{}
+	case "NFTokenOffer":
+		object = &NFTokenOfferObject{}
+	case "NFTokenPage":
+		object = &NFTokenPageObject{}
+	case "Offer":
+		object = &OfferObject{}
+	case "Oracle":
+		object = &OracleObject{}
+	case "PayChannel":
+		object = &PayChannelObject{}
+	case "RippleState":
+		object = &RippleStateObject{}
+	case "SignerList":
+		object = &SignerListObject{}
+	case "Ticket":
+		object = &TicketObject{}
+	default:
+		object = &map[string]interface{}{}
+	}
+
+	if err := json.Unmarshal(raw, object); err != nil {
+		return nil, fmt.Errorf("decoding %s %s: %w", header.LedgerEntryType, header.Index, err)
+	}
+	return object, nil
+}
+
+// StreamAccountObjects requests account_objects over WebSocket and decodes each response page
+func StreamAccountObjects(client *xrpl.WebSocketClient, account string, ledgerIndex string, objectType string, limit int, callback func(*AccountObjectsPage)) (func(), error) {
+	payload := AccountObjectsWSRequest{
+		ID:          1,
+		Command:     "account_objects",
+		Account:     account,
+		LedgerIndex: ledgerIndex,
+		Type:        objectType,
+		Limit:       limit,
+	}
+
+	// Send the command and read its response
+	return client.Request(payload, func(message []byte) {
+		var response AccountObjectsResponse
+		if err := json.Unmarshal(message, &response); err != nil || response.Result.Account != account {
+			return
+		}
+
+		page := &AccountObjectsPage{
+			Account:     response.Result.Account,
+			LedgerIndex: response.Result.LedgerIndex,
+			Validated:   response.Result.Validated,
+			Objects:     []interface{}{},
+			Marker:      response.Result.Marker,
+		}
+		for _, raw := range response.Result.AccountObjects {
+			if object, err := DecodeLedgerObject(raw); err == nil {
+				page.Objects = append(page.Objects, object)
+			}
+		}
+		callback(page)
+	})
+}
+
+// Header returns the common fields of a typed ledger object
+func (h *LedgerObjectHeader) Header() *LedgerObjectHeader {
+	return h
+}
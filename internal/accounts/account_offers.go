@@ -0,0 +1,79 @@
+package accounts
+
+import (
+	"encoding/json"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// FetchAccountOffers fetches a page of the open DEX offers of an account via HTTP
+func FetchAccountOffers(client *xrpl.HTTPClient, account string, ledgerIndex string, limit int, marker interface{}) ([]byte, error) {
+	params := AccountOffersParam{
+		Account:     account,
+		LedgerIndex: ledgerIndex,
+		Limit:       limit,
+		Marker:      marker,
+	}
+
+	payload := AccountOffersRequest{
+		Method: "account_offers",
+		Params: []AccountOffersParam{params},
+	}
+
+	return client.Post("", payload)
+}
+
+// FetchAccountOffersPage fetches and decodes a page of account_offers
+func FetchAccountOffersPage(client *xrpl.HTTPClient, account string, ledgerIndex string, limit int, marker interface{}) (*AccountOffersPage, error) {
+	response, err := FetchAccountOffers(client, account, ledgerIndex, limit, marker)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded AccountOffersResponse
+	if err := json.Unmarshal(response, &decoded); err != nil {
+		return nil, err
+	}
+	if decoded.Result.Error != "" {
+		return nil, &transactions.RPCError{Code: decoded.Result.Error, Message: decoded.Result.ErrorMessage}
+	}
+
+	offers := decoded.Result.Offers
+	if offers == nil {
+		offers = []AccountOffer{}
+	}
+	return &AccountOffersPage{
+		Account:     decoded.Result.Account,
+		LedgerIndex: decoded.Result.LedgerIndex,
+		Validated:   decoded.Result.Validated,
+		Offers:      offers,
+		Marker:      decoded.Result.Marker,
+	}, nil
+}
+
+// StreamAccountOffers requests account_offers over WebSocket and decodes each response page
+func StreamAccountOffers(client *xrpl.WebSocketClient, account string, ledgerIndex string, limit int, callback func(*AccountOffersPage)) (func(), error) {
+	payload := AccountOffersWSRequest{
+		ID:          1,
+		Command:     "account_offers",
+		Account:     account,
+		LedgerIndex: ledgerIndex,
+		Limit:       limit,
+	}
+
+	// Send the command and read its response
+	return client.Request(payload, func(message []byte) {
+		var response AccountOffersResponse
+		if err := json.Unmarshal(message, &response); err != nil || response.Result.Account != account || response.Result.Offers == nil {
+			return
+		}
+		callback(&AccountOffersPage{
+			Account:     response.Result.Account,
+			LedgerIndex: response.Result.LedgerIndex,
+			Validated:   response.Result.Validated,
+			Offers:      response.Result.Offers,
+			Marker:      response.Result.Marker,
+		})
+	})
+}
@@ -0,0 +1,52 @@
+package accounts
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// FetchAccountTx fetches a page of an account's transaction history via HTTP.
+// ledgerIndexMin/ledgerIndexMax of -1 mean the earliest/latest validated ledger available
+func FetchAccountTx(client *xrpl.HTTPClient, account string, ledgerIndexMin int, ledgerIndexMax int, forward bool, limit int, marker *AccountTxMarker) ([]byte, error) {
+	// Build the parameters
+	params := AccountTxParam{
+		Account:        account,
+		LedgerIndexMin: ledgerIndexMin,
+		LedgerIndexMax: ledgerIndexMax,
+		Forward:        forward,
+		Limit:          limit,
+		Marker:         marker,
+	}
+
+	// Build the JSON-RPC payload
+	payload := AccountTxRequest{
+		Method: "account_tx",
+		Params: []AccountTxParam{params},
+	}
+
+	payloadJSON, _ := json.Marshal(payload) // Convert payload to JSON
+	log.Printf("Sending payload: %s\n", payloadJSON)
+
+	// Send the request
+	return client.Post("", payload)
+}
+
+// FetchAccountTxPage fetches and decodes a page of account_tx
+func FetchAccountTxPage(client *xrpl.HTTPClient, account string, ledgerIndexMin int, ledgerIndexMax int, forward bool, limit int, marker *AccountTxMarker) (*AccountTxResponse, error) {
+	response, err := FetchAccountTx(client, account, ledgerIndexMin, ledgerIndexMax, forward, limit, marker)
+	if err != nil {
+		return nil, err
+	}
+
+	var page AccountTxResponse
+	if err := json.Unmarshal(response, &page); err != nil {
+		return nil, err
+	}
+	if page.Result.Error != "" {
+		return nil, &transactions.RPCError{Code: page.Result.Error, Message: page.Result.ErrorMessage}
+	}
+	return &page, nil
+}
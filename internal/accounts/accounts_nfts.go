@@ -28,11 +28,11 @@ func FetchHistoricalAccountNFTs(client *xrpl.HTTPClient, account string, ledgerI
 	payloadJSON, _ := json.Marshal(payload) // Convert payload to JSON
 	log.Printf("Sending payload: %s\n", payloadJSON)
 
-	return client.Post("", payloadJSON)
+	return client.Post("", payload)
 }
 
 // Stream real-time account NFTs using WebSocket
-func StreamAccountNFTs(client *xrpl.WebSocketClient, account string, ledgerIndex string, limit int, callback func(*AccountNFTsResponse)) error {
+func StreamAccountNFTs(client *xrpl.WebSocketClient, account string, ledgerIndex string, limit int, callback func(*AccountNFTsResponse)) (func(), error) {
 	params := AccountNFTsWSRequest{
 		Command: "account_nfts",
 		Account: account,
@@ -40,12 +40,8 @@ func StreamAccountNFTs(client *xrpl.WebSocketClient, account string, ledgerIndex
 		Limit: limit,
 	}
 
-	err := client.Subscribe(params)
-	if err != nil {
-		return err
-	}
-
-	client.ReadMessages(func(message []byte) {
+	// Send the command and read its response
+	return client.Request(params, func(message []byte) {
 		var response AccountNFTsResponse
 		err := json.Unmarshal(message, &response)
 		if err != nil {
@@ -55,5 +51,4 @@ func StreamAccountNFTs(client *xrpl.WebSocketClient, account string, ledgerIndex
 		callback(&response)
 	})
 
-	return nil
 }
@@ -0,0 +1,131 @@
+package accounts
+
+import (
+	"log"
+	"sync"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// permanentFollower owns the accounts followed by syncs, deposits and invoices, which are never unfollowed
+const permanentFollower = ""
+
+var (
+	followMu sync.Mutex
+	// followers maps each account on the shared accounts stream to the owners that need it
+	followers = map[string]map[string]bool{}
+	liveStop  chan struct{}
+)
+
+// FollowAccount adds an account to the accounts stream, starting the stream on first use
+func FollowAccount(wsClient *xrpl.WebSocketClient, account string) error {
+	return FollowAccounts(wsClient, permanentFollower, []string{account})
+}
+
+// FollowAccounts adds accounts to the accounts stream on behalf of an owner. Only accounts
+// that no other owner follows are subscribed upstream
+func FollowAccounts(wsClient *xrpl.WebSocketClient, owner string, accounts []string) error {
+	followMu.Lock()
+	defer followMu.Unlock()
+
+	added := []string{}
+	for _, account := range accounts {
+		if len(followers[account]) == 0 && !contains(added, account) {
+			added = append(added, account)
+		}
+	}
+
+	if len(added) > 0 {
+		if liveStop == nil {
+			stopChan := make(chan struct{})
+			if err := SubscribeAccounts(wsClient, added, stopChan); err != nil {
+				return err
+			}
+			liveStop = stopChan
+		} else {
+			request := SubscribeAccountsRequest{
+				ID:       "subscribe_accounts",
+				Command:  "subscribe",
+				Accounts: added,
+			}
+			if err := wsClient.Subscribe(request); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, account := range accounts {
+		if followers[account] == nil {
+			followers[account] = map[string]bool{}
+		}
+		followers[account][owner] = true
+	}
+	return nil
+}
+
+// UnfollowAccounts releases accounts held by an owner. Accounts left without owners are
+// unsubscribed upstream; the stream itself keeps running
+func UnfollowAccounts(wsClient *xrpl.WebSocketClient, owner string, accounts []string) error {
+	followMu.Lock()
+	defer followMu.Unlock()
+
+	removed := []string{}
+	for _, account := range accounts {
+		if !followers[account][owner] {
+			continue
+		}
+		delete(followers[account], owner)
+		if len(followers[account]) == 0 {
+			delete(followers, account)
+			removed = append(removed, account)
+		}
+	}
+
+	if len(removed) == 0 || liveStop == nil {
+		return nil
+	}
+
+	request := SubscribeAccountsRequest{
+		ID:       "unsubscribe_accounts",
+		Command:  "unsubscribe",
+		Accounts: removed,
+	}
+	if err := wsClient.Subscribe(request); err != nil {
+		log.Printf("❌ Erro ao cancelar a inscrição das contas %v: %v", removed, err)
+		return err
+	}
+	return nil
+}
+
+// FollowedAccounts returns the number of accounts on the shared accounts stream
+func FollowedAccounts() int {
+	followMu.Lock()
+	defer followMu.Unlock()
+	return len(followers)
+}
+
+// follows reports whether a transaction touches a followed account, either in its envelope or
+// through its metadata (an offer consumed, a trust line rippled, a payment between two holders of
+// a followed issuer)
+func follows(tx *transactions.Transaction) bool {
+	followMu.Lock()
+	defer followMu.Unlock()
+	for _, accounts := range [][]string{tx.Accounts, transactions.AffectedAccounts(tx.Meta)} {
+		for _, account := range accounts {
+			if len(followers[account]) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package accounts
+
+import (
+	"testing"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions/txtest"
+)
+
+func TestFollows(t *testing.T) {
+	followMu.Lock()
+	followers = map[string]map[string]bool{"rIssuer": {"issuers": true}}
+	followMu.Unlock()
+	defer func() {
+		followMu.Lock()
+		followers = map[string]map[string]bool{}
+		followMu.Unlock()
+	}()
+
+	tests := []struct {
+		name     string
+		accounts []string
+		nodes    []string
+		want     bool
+	}{
+		{
+			name:     "followed account in the envelope",
+			accounts: []string{"rIssuer", "rHolder"},
+			nodes:    []string{txtest.RippleStateNode("rHolder", "rIssuer", "USD", "0", "10")},
+			want:     true,
+		},
+		{
+			name:     "payment between two holders of a followed issuer",
+			accounts: []string{"rAlice", "rBob"},
+			nodes: []string{
+				txtest.RippleStateNode("rAlice", "rIssuer", "USD", "50", "40"),
+				txtest.RippleStateNode("rBob", "rIssuer", "USD", "0", "10"),
+			},
+			want: true,
+		},
+		{
+			name:     "offer of a followed account consumed by another",
+			accounts: []string{"rTaker"},
+			nodes:    []string{txtest.OfferNode("rIssuer", txtest.IOU("USD", "rIssuer", "30"), txtest.IOU("USD", "rIssuer", "20"), `"15000000"`, `"10000000"`)},
+			want:     true,
+		},
+		{
+			name:     "unrelated XRP payment",
+			accounts: []string{"rAlice", "rBob"},
+			nodes:    []string{txtest.AccountRootNode("rAlice", "50000000", "39999988"), txtest.AccountRootNode("rBob", "1", "10000001")},
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &transactions.Transaction{Accounts: tt.accounts, Meta: txtest.Meta(t, tt.nodes...)}
+			if got := follows(tx); got != tt.want {
+				t.Fatalf("follows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -34,11 +34,11 @@ func FetchGatewayBalances(client *xrpl.HTTPClient, account string, hotWallet []s
 	log.Printf("Sending payload: %s\n",
 		payloadJSON)
 
-	return client.Post("", payloadJSON)
+	return client.Post("", payload)
 }
 
 // Stream real-time gateway balances using WebSocket
-func StreamGatewayBalances(client *xrpl.WebSocketClient, account string, hotWallet []string, ledgerIndex string, strict bool, callback func(*GatewayBalancesResponse)) error {
+func StreamGatewayBalances(client *xrpl.WebSocketClient, account string, hotWallet []string, ledgerIndex string, strict bool, callback func(*GatewayBalancesResponse)) (func(), error) {
 	params := GatewayBalancesWSRequest{
 		Command:    "gateway_balances",
 		Account:    account,
@@ -47,12 +47,8 @@ func StreamGatewayBalances(client *xrpl.WebSocketClient, account string, hotWall
 		Strict:      strict,
 	}
 
-	err := client.Subscribe(params)
-	if err != nil {
-		return err
-	}
-
-	client.ReadMessages(func(message []byte) {
+	// Send the command and read its response
+	return client.Request(params, func(message []byte) {
 		var response GatewayBalancesResponse
 		err := json.Unmarshal(message, &response)
 		if err != nil {
@@ -62,5 +58,4 @@ func StreamGatewayBalances(client *xrpl.WebSocketClient, account string, hotWall
 		callback(&response)
 	})
 
-	return nil
 }
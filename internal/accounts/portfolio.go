@@ -0,0 +1,325 @@
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/ledger"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// PortfolioPageLimit is the page size of the paginated methods used by the portfolio
+const PortfolioPageLimit = 400
+
+// portfolioMaxPages bounds the pages read per method, so a huge account cannot stall a request
+const portfolioMaxPages = 50
+
+// GetPortfolio reads account_info, account_lines, account_nfts, account_objects and account_offers
+// concurrently at the latest validated ledger, so every section describes the same ledger state
+func GetPortfolio(client *xrpl.HTTPClient, account string) (*Portfolio, error) {
+	ledgerIndex, err := ledger.FetchValidatedLedgerIndex(client)
+	if err != nil {
+		return nil, err
+	}
+	index := strconv.Itoa(ledgerIndex)
+
+	var (
+		wg      sync.WaitGroup
+		info    *AccountInfoResponse
+		lines   []TrustLine
+		nfts    []NFT
+		objects []interface{}
+		offers  []AccountOffer
+		errs    [5]error
+	)
+
+	wg.Add(5)
+	go func() { defer wg.Done(); info, errs[0] = fetchPortfolioInfo(client, account, index) }()
+	go func() { defer wg.Done(); lines, errs[1] = fetchPortfolioLines(client, account, index) }()
+	go func() { defer wg.Done(); nfts, errs[2] = fetchPortfolioNFTs(client, account, index) }()
+	go func() { defer wg.Done(); objects, errs[3] = fetchPortfolioObjects(client, account, index) }()
+	go func() { defer wg.Done(); offers, errs[4] = fetchPortfolioOffers(client, account, index) }()
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reserves, err := FetchReserveValues(client)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio := &Portfolio{
+		Account:     account,
+		LedgerIndex: ledgerIndex,
+		OwnerCount:  info.Result.AccountData.OwnerCount,
+		Tokens:      []TrustLine{},
+		Offers:      offers,
+		Escrows:     []PortfolioEscrow{},
+		Channels:    []PortfolioChannel{},
+		NFTs:        nfts,
+	}
+	portfolio.XRP = portfolioXRP(reserves, info.Result.AccountData.Balance, info.Result.AccountData.OwnerCount)
+
+	lpLines := []TrustLine{}
+	for _, line := range lines {
+		if isLPToken(line.Currency) {
+			lpLines = append(lpLines, line)
+		} else {
+			portfolio.Tokens = append(portfolio.Tokens, line)
+		}
+	}
+	portfolio.LPPositions = lpPositions(client, lpLines, index)
+
+	for _, object := range objects {
+		switch typed := object.(type) {
+		case *EscrowObject:
+			portfolio.Escrows = append(portfolio.Escrows, portfolioEscrow(account, *typed))
+		case *PayChannelObject:
+			portfolio.Channels = append(portfolio.Channels, portfolioChannel(account, *typed))
+		}
+	}
+	return portfolio, nil
+}
+
+// portfolioXRP computes the reserves from the current network settings
+func portfolioXRP(values ReserveValues, balance string, ownerCount int) PortfolioXRP {
+	reserves := CalculateReserves("", 0, balance, ownerCount, nil, values)
+	return PortfolioXRP{
+		Balance:      balance,
+		BaseReserve:  reserves.BaseReserve,
+		OwnerReserve: reserves.OwnerReserve,
+		Reserve:      reserves.Reserve,
+		Spendable:    reserves.Spendable,
+	}
+}
+
+func fetchPortfolioInfo(client *xrpl.HTTPClient, account string, ledgerIndex string) (*AccountInfoResponse, error) {
+	response, err := FetchAccountInfo(client, account, ledgerIndex, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var info AccountInfoResponse
+	if err := json.Unmarshal(response, &info); err != nil {
+		return nil, err
+	}
+	if info.Result.Error != "" {
+		return nil, &transactions.RPCError{Code: info.Result.Error, Message: info.Result.ErrorMessage}
+	}
+	return &info, nil
+}
+
+func fetchPortfolioLines(client *xrpl.HTTPClient, account string, ledgerIndex string) ([]TrustLine, error) {
+	return collectPages(func(marker interface{}) ([]TrustLine, interface{}, error) {
+		response, err := client.Post("", AccountLinesRequest{
+			Method: "account_lines",
+			Params: []AccountLinesParam{{Account: account, LedgerIndex: ledgerIndex, Limit: PortfolioPageLimit, Marker: marker}},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var page AccountLinesResponse
+		if err := json.Unmarshal(response, &page); err != nil {
+			return nil, nil, err
+		}
+		if page.Result.Error != "" {
+			return nil, nil, &transactions.RPCError{Code: page.Result.Error, Message: page.Result.ErrorMessage}
+		}
+		return page.Result.Lines, page.Result.Marker, nil
+	})
+}
+
+func fetchPortfolioNFTs(client *xrpl.HTTPClient, account string, ledgerIndex string) ([]NFT, error) {
+	return collectPages(func(marker interface{}) ([]NFT, interface{}, error) {
+		params := AccountNFTsParam{Account: account, LedgerIndex: ledgerIndex, Limit: PortfolioPageLimit}
+		if value, ok := marker.(string); ok {
+			params.Marker = value
+		}
+
+		response, err := client.Post("", AccountNFTsRequest{Method: "account_nfts", Params: []AccountNFTsParam{params}})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var page AccountNFTsResponse
+		if err := json.Unmarshal(response, &page); err != nil {
+			return nil, nil, err
+		}
+		if page.Result.Error != "" {
+			return nil, nil, &transactions.RPCError{Code: page.Result.Error, Message: page.Result.ErrorMessage}
+		}
+		if page.Result.Marker == nil {
+			return page.Result.AccountNFTs, nil, nil
+		}
+		return page.Result.AccountNFTs, *page.Result.Marker, nil
+	})
+}
+
+func fetchPortfolioObjects(client *xrpl.HTTPClient, account string, ledgerIndex string) ([]interface{}, error) {
+	return collectPages(func(marker interface{}) ([]interface{}, interface{}, error) {
+		page, err := FetchAccountObjectsPage(client, account, ledgerIndex, "", PortfolioPageLimit, marker)
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Objects, page.Marker, nil
+	})
+}
+
+func fetchPortfolioOffers(client *xrpl.HTTPClient, account string, ledgerIndex string) ([]AccountOffer, error) {
+	return collectPages(func(marker interface{}) ([]AccountOffer, interface{}, error) {
+		page, err := FetchAccountOffersPage(client, account, ledgerIndex, PortfolioPageLimit, marker)
+		if err != nil {
+			return nil, nil, err
+		}
+		return page.Offers, page.Marker, nil
+	})
+}
+
+// collectPages follows the markers of a paginated method until the last page
+func collectPages[T any](fetch func(marker interface{}) ([]T, interface{}, error)) ([]T, error) {
+	items := []T{}
+	var marker interface{}
+	for pages := 0; pages < portfolioMaxPages; pages++ {
+		page, next, err := fetch(marker)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if next == nil {
+			return items, nil
+		}
+		marker = next
+	}
+	return nil, fmt.Errorf("more than %d pages", portfolioMaxPages)
+}
+
+// isLPToken reports whether a currency code is an AMM LP token (hex code starting with 03)
+func isLPToken(currency string) bool {
+	return len(currency) == 40 && strings.HasPrefix(currency, "03")
+}
+
+// lpPositions looks up the pool of each LP token line to compute the share of the pool held
+func lpPositions(client *xrpl.HTTPClient, lines []TrustLine, ledgerIndex string) []LPPosition {
+	positions := make([]LPPosition, len(lines))
+
+	var wg sync.WaitGroup
+	for i, line := range lines {
+		positions[i] = LPPosition{
+			AMMAccount: line.Account,
+			LPToken:    xrpl.Amount{Currency: line.Currency, Issuer: line.Account, Value: line.Balance},
+			Assets:     []xrpl.Amount{},
+		}
+
+		wg.Add(1)
+		go func(position *LPPosition) {
+			defer wg.Done()
+			if err := fillLPPosition(client, position, ledgerIndex); err != nil {
+				log.Printf("⚠️ Erro ao consultar o pool AMM %s: %v", position.AMMAccount, err)
+			}
+		}(&positions[i])
+	}
+	wg.Wait()
+	return positions
+}
+
+func fillLPPosition(client *xrpl.HTTPClient, position *LPPosition, ledgerIndex string) error {
+	response, err := client.Post("", AMMInfoByAccountRequest{
+		Method: "amm_info",
+		Params: []AMMInfoByAccountParam{{AMMAccount: position.AMMAccount, LedgerIndex: ledgerIndex}},
+	})
+	if err != nil {
+		return err
+	}
+
+	var info AMMInfoByAccountResponse
+	if err := json.Unmarshal(response, &info); err != nil {
+		return err
+	}
+	if info.Result.Error != "" {
+		return &transactions.RPCError{Code: info.Result.Error, Message: info.Result.ErrorMessage}
+	}
+
+	pool := info.Result.AMM
+	held, _, errHeld := big.ParseFloat(position.LPToken.Value, 10, 128, big.ToNearestEven)
+	total, _, errTotal := big.ParseFloat(pool.LPToken.Value, 10, 128, big.ToNearestEven)
+	if errHeld != nil || errTotal != nil || total.Sign() == 0 {
+		return fmt.Errorf("invalid LP token supply %q", pool.LPToken.Value)
+	}
+
+	share := new(big.Float).SetPrec(128).Quo(held, total)
+	position.Share, _ = share.Float64()
+	position.TradingFee = pool.TradingFee
+	position.Assets = []xrpl.Amount{poolShare(pool.Amount, share), poolShare(pool.Amount2, share)}
+	return nil
+}
+
+// poolShare returns the part of a pool asset that corresponds to a share; XRP is truncated to drops
+func poolShare(amount xrpl.Amount, share *big.Float) xrpl.Amount {
+	value, _, err := big.ParseFloat(amount.Value, 10, 128, big.ToNearestEven)
+	if err != nil {
+		return amount
+	}
+	value.Mul(value, share)
+
+	result := amount
+	if amount.IsXRP() {
+		drops, _ := value.Int(nil)
+		result.Value = drops.String()
+	} else {
+		result.Value = value.Text('g', 15)
+	}
+	return result
+}
+
+func portfolioEscrow(account string, escrow EscrowObject) PortfolioEscrow {
+	return PortfolioEscrow{
+		ID:             escrow.Index,
+		Account:        escrow.Account,
+		Destination:    escrow.Destination,
+		Amount:         escrow.Amount,
+		Condition:      escrow.Condition,
+		FinishAfter:    rippleTime(escrow.FinishAfter),
+		CancelAfter:    rippleTime(escrow.CancelAfter),
+		DestinationTag: escrow.DestinationTag,
+		Incoming:       escrow.Destination == account && escrow.Account != account,
+	}
+}
+
+func portfolioChannel(account string, channel PayChannelObject) PortfolioChannel {
+	amount, _ := strconv.ParseInt(channel.Amount, 10, 64)
+	balance, _ := strconv.ParseInt(channel.Balance, 10, 64)
+
+	return PortfolioChannel{
+		ChannelID:   channel.Index,
+		Account:     channel.Account,
+		Destination: channel.Destination,
+		Amount:      channel.Amount,
+		Balance:     channel.Balance,
+		Remaining:   strconv.FormatInt(amount-balance, 10),
+		SettleDelay: channel.SettleDelay,
+		Expiration:  rippleTime(channel.Expiration),
+		CancelAfter: rippleTime(channel.CancelAfter),
+		Incoming:    channel.Destination == account && channel.Account != account,
+	}
+}
+
+// rippleTime converts an optional ledger time (seconds since the Ripple epoch) into a time
+func rippleTime(value uint32) *time.Time {
+	if value == 0 {
+		return nil
+	}
+	converted := xrpl.RippleTimeToTime(int64(value))
+	return &converted
+}
@@ -0,0 +1,194 @@
+package accounts
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/network"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/states"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// RippleState flags telling which side of the trust line holds a reserve for it
+const (
+	lsfLowReserve  uint32 = 0x00010000
+	lsfHighReserve uint32 = 0x00020000
+)
+
+// lsfOneOwnerCount marks signer lists created after MultiSignReserve, which count as a single object
+const lsfOneOwnerCount uint32 = 0x00010000
+
+// lsfAccepted marks a credential accepted by its subject
+const lsfAccepted uint32 = 0x00010000
+
+// oracleLargeSize is the number of price entries above which an Oracle counts as two objects
+const oracleLargeSize = 5
+
+// ReserveValues are the reserve_base and reserve_inc in drops, and where they were read from
+type ReserveValues struct {
+	BaseDrops int64
+	IncDrops  int64
+	Source    string
+}
+
+// FetchReserveValues returns the reserves seen on the ledger stream (or the FeeSettings object),
+// falling back to server_state when the service has not seen a ledger yet
+func FetchReserveValues(client *xrpl.HTTPClient) (ReserveValues, error) {
+	if settings := network.CurrentSettings(); settings.ReserveBaseDrops > 0 {
+		return ReserveValues{BaseDrops: settings.ReserveBaseDrops, IncDrops: settings.ReserveIncDrops, Source: "ledger"}, nil
+	}
+
+	response, err := serverinfo.FetchServerState(client)
+	if err != nil {
+		return ReserveValues{}, err
+	}
+
+	var state struct {
+		Result serverinfo.ServerStateResult `json:"result"`
+	}
+	if err := json.Unmarshal(response, &state); err != nil {
+		return ReserveValues{}, err
+	}
+
+	// server_state reports validated_ledger reserves in drops (server_info uses XRP)
+	validated := state.Result.State.ValidatedLedger
+	return ReserveValues{BaseDrops: int64(validated.ReserveBase), IncDrops: int64(validated.ReserveInc), Source: "server_state"}, nil
+}
+
+// GetReserves computes the base and owner reserves, the spendable XRP and the owner reserve
+// per object type of an account at a ledger (defaults to validated)
+func GetReserves(client *xrpl.HTTPClient, account string, ledgerIndex string) (*AccountReserves, error) {
+	if ledgerIndex == "" {
+		ledgerIndex = "validated"
+	}
+
+	info, err := fetchPortfolioInfo(client, account, ledgerIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the objects at the exact ledger of account_info so OwnerCount and the objects agree
+	index := strconv.Itoa(info.Result.LedgerIndex)
+	objects, err := fetchPortfolioObjects(client, account, index)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := FetchReserveValues(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return CalculateReserves(account, info.Result.LedgerIndex, info.Result.AccountData.Balance, info.Result.AccountData.OwnerCount, objects, values), nil
+}
+
+// CalculateReserves builds the reserve breakdown from the account's balance, OwnerCount and decoded objects
+func CalculateReserves(account string, ledgerIndex int, balance string, ownerCount int, objects []interface{}, values ReserveValues) *AccountReserves {
+	drops, _ := strconv.ParseInt(balance, 10, 64)
+	ownerReserve := values.IncDrops * int64(ownerCount)
+	reserve := values.BaseDrops + ownerReserve
+	spendable := drops - reserve
+	if spendable < 0 {
+		spendable = 0
+	}
+
+	reserves := &AccountReserves{
+		Account:          account,
+		LedgerIndex:      ledgerIndex,
+		Balance:          balance,
+		OwnerCount:       ownerCount,
+		BaseReserve:      strconv.FormatInt(values.BaseDrops, 10),
+		OwnerReserve:     strconv.FormatInt(ownerReserve, 10),
+		Reserve:          strconv.FormatInt(reserve, 10),
+		Spendable:        strconv.FormatInt(spendable, 10),
+		ReserveSource:    values.Source,
+		ReserveIncrement: strconv.FormatInt(values.IncDrops, 10),
+		Objects:          []ObjectReserve{},
+	}
+
+	byType := map[string]*ObjectReserve{}
+	attributed := 0
+	for _, object := range objects {
+		entryType, count := objectOwnerCount(account, object)
+		if entryType == "" {
+			continue
+		}
+
+		entry, ok := byType[entryType]
+		if !ok {
+			entry = &ObjectReserve{Type: entryType}
+			byType[entryType] = entry
+		}
+		entry.Count++
+		entry.OwnerCount += count
+		attributed += count
+	}
+
+	for _, entry := range byType {
+		entry.Reserve = strconv.FormatInt(values.IncDrops*int64(entry.OwnerCount), 10)
+		reserves.Objects = append(reserves.Objects, *entry)
+	}
+	sort.Slice(reserves.Objects, func(i, j int) bool { return reserves.Objects[i].Type < reserves.Objects[j].Type })
+
+	if ownerCount > attributed {
+		reserves.Unattributed = ownerCount - attributed
+	}
+	return reserves
+}
+
+// objectOwnerCount returns the entry type of an object and how many units it adds to the account's OwnerCount.
+// Objects listed in the account's directories without being owned by it (incoming escrows, checks and
+// channels, the counterparty side of a trust line) hold no reserve for the account
+func objectOwnerCount(account string, object interface{}) (string, int) {
+	switch typed := object.(type) {
+	case *RippleStateObject:
+		flag := lsfHighReserve
+		if typed.LowLimit.Issuer == account {
+			flag = lsfLowReserve
+		}
+		return typed.LedgerEntryType, ownedIf(typed.Flags&flag != 0)
+	case *SignerListObject:
+		if typed.Flags&lsfOneOwnerCount != 0 {
+			return typed.LedgerEntryType, 1
+		}
+		// Legacy signer lists cost 2 plus one per signer
+		return typed.LedgerEntryType, 2 + len(typed.SignerEntries)
+	case *OracleObject:
+		if len(typed.PriceDataSeries) > oracleLargeSize {
+			return typed.LedgerEntryType, 2
+		}
+		return typed.LedgerEntryType, 1
+	case *EscrowObject:
+		return typed.LedgerEntryType, ownedIf(typed.Account == account)
+	case *CheckObject:
+		return typed.LedgerEntryType, ownedIf(typed.Account == account)
+	case *PayChannelObject:
+		return typed.LedgerEntryType, ownedIf(typed.Account == account)
+	case *CredentialObject:
+		// A credential is held by the issuer until accepted, then by the subject
+		accepted := typed.Flags&lsfAccepted != 0
+		return typed.LedgerEntryType, ownedIf((accepted && typed.Subject == account) || (!accepted && typed.Issuer == account))
+	}
+
+	return objectEntryType(object), 1
+}
+
+// objectEntryType returns the LedgerEntryType of a typed object or of an object of an unknown type
+func objectEntryType(object interface{}) string {
+	if typed, ok := object.(interface{ Header() *LedgerObjectHeader }); ok {
+		return typed.Header().LedgerEntryType
+	}
+	if raw, ok := object.(*map[string]interface{}); ok {
+		entryType, _ := (*raw)["LedgerEntryType"].(string)
+		return entryType
+	}
+	return ""
+}
+
+func ownedIf(owned bool) int {
+	if owned {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,141 @@
+package accounts
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testAccount = "rOwner"
+
+// decodeObject decodes an account_objects entry the way the account_objects pages are decoded
+func decodeObject(t *testing.T, raw string) interface{} {
+	t.Helper()
+
+	object, err := DecodeLedgerObject(json.RawMessage(raw))
+	if err != nil {
+		t.Fatalf("object fixture: %v\n%s", err, raw)
+	}
+	return object
+}
+
+func TestObjectOwnerCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		object    string
+		wantType  string
+		wantCount int
+	}{
+		{
+			name: "trust line reserved on the account's side",
+			object: `{"LedgerEntryType": "RippleState", "index": "RS1", "Flags": 65536,
+				"LowLimit": {"currency": "USD", "issuer": "rOwner", "value": "100"},
+				"HighLimit": {"currency": "USD", "issuer": "rGateway", "value": "0"}}`,
+			wantType:  "RippleState",
+			wantCount: 1,
+		},
+		{
+			name: "trust line reserved by the counterparty",
+			object: `{"LedgerEntryType": "RippleState", "index": "RS2", "Flags": 131072,
+				"LowLimit": {"currency": "USD", "issuer": "rOwner", "value": "0"},
+				"HighLimit": {"currency": "USD", "issuer": "rHolder", "value": "100"}}`,
+			wantType: "RippleState",
+		},
+		{
+			name:      "signer list after MultiSignReserve",
+			object:    `{"LedgerEntryType": "SignerList", "index": "SL1", "Flags": 65536, "SignerEntries": [{"SignerEntry": {"Account": "rA", "SignerWeight": 1}}, {"SignerEntry": {"Account": "rB", "SignerWeight": 1}}]}`,
+			wantType:  "SignerList",
+			wantCount: 1,
+		},
+		{
+			name:      "legacy signer list",
+			object:    `{"LedgerEntryType": "SignerList", "index": "SL2", "Flags": 0, "SignerEntries": [{"SignerEntry": {"Account": "rA", "SignerWeight": 1}}, {"SignerEntry": {"Account": "rB", "SignerWeight": 1}}]}`,
+			wantType:  "SignerList",
+			wantCount: 4,
+		},
+		{
+			name: "oracle with more than five prices",
+			object: `{"LedgerEntryType": "Oracle", "index": "OR1", "Owner": "rOwner", "PriceDataSeries": [
+				{"PriceData": {}}, {"PriceData": {}}, {"PriceData": {}}, {"PriceData": {}}, {"PriceData": {}}, {"PriceData": {}}]}`,
+			wantType:  "Oracle",
+			wantCount: 2,
+		},
+		{
+			name:     "incoming escrow",
+			object:   `{"LedgerEntryType": "Escrow", "index": "E1", "Account": "rSender", "Destination": "rOwner", "Amount": "100"}`,
+			wantType: "Escrow",
+		},
+		{
+			name:      "outgoing check",
+			object:    `{"LedgerEntryType": "Check", "index": "C1", "Account": "rOwner", "Destination": "rOther", "SendMax": "100"}`,
+			wantType:  "Check",
+			wantCount: 1,
+		},
+		{
+			name:      "credential not accepted yet is held by the issuer",
+			object:    `{"LedgerEntryType": "Credential", "index": "CR1", "Flags": 0, "Subject": "rSubject", "Issuer": "rOwner", "CredentialType": "4B5943"}`,
+			wantType:  "Credential",
+			wantCount: 1,
+		},
+		{
+			name:     "accepted credential is held by the subject",
+			object:   `{"LedgerEntryType": "Credential", "index": "CR2", "Flags": 65536, "Subject": "rSubject", "Issuer": "rOwner", "CredentialType": "4B5943"}`,
+			wantType: "Credential",
+		},
+		{
+			name:      "entry type without a struct",
+			object:    `{"LedgerEntryType": "Bridge", "index": "B1", "Account": "rOwner"}`,
+			wantType:  "Bridge",
+			wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entryType, count := objectOwnerCount(testAccount, decodeObject(t, tt.object))
+			if entryType != tt.wantType || count != tt.wantCount {
+				t.Errorf("objectOwnerCount = %s %d, want %s %d", entryType, count, tt.wantType, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestCalculateReserves(t *testing.T) {
+	values := ReserveValues{BaseDrops: 1000000, IncDrops: 200000, Source: "ledger"}
+	objects := []interface{}{
+		decodeObject(t, `{"LedgerEntryType": "Offer", "index": "O1", "Account": "rOwner", "TakerGets": "1", "TakerPays": "1"}`),
+		decodeObject(t, `{"LedgerEntryType": "Offer", "index": "O2", "Account": "rOwner", "TakerGets": "1", "TakerPays": "1"}`),
+		decodeObject(t, `{"LedgerEntryType": "Escrow", "index": "E1", "Account": "rSender", "Destination": "rOwner", "Amount": "100"}`),
+		decodeObject(t, `{"LedgerEntryType": "SignerList", "index": "SL1", "Flags": 0, "SignerEntries": [{"SignerEntry": {"Account": "rA", "SignerWeight": 1}}]}`),
+	}
+
+	t.Run("breakdown by type with unattributed owner count", func(t *testing.T) {
+		reserves := CalculateReserves(testAccount, 100, "5000000", 6, objects, values)
+
+		if reserves.Reserve != "2200000" || reserves.OwnerReserve != "1200000" || reserves.Spendable != "2800000" {
+			t.Errorf("reserve = %s, owner reserve = %s, spendable = %s", reserves.Reserve, reserves.OwnerReserve, reserves.Spendable)
+		}
+		want := []ObjectReserve{
+			{Type: "Escrow", Count: 1, OwnerCount: 0, Reserve: "0"},
+			{Type: "Offer", Count: 2, OwnerCount: 2, Reserve: "400000"},
+			{Type: "SignerList", Count: 1, OwnerCount: 3, Reserve: "600000"},
+		}
+		if len(reserves.Objects) != len(want) {
+			t.Fatalf("objects = %+v, want %+v", reserves.Objects, want)
+		}
+		for i := range want {
+			if reserves.Objects[i] != want[i] {
+				t.Errorf("object %d = %+v, want %+v", i, reserves.Objects[i], want[i])
+			}
+		}
+		if reserves.Unattributed != 1 {
+			t.Errorf("unattributed = %d, want 1", reserves.Unattributed)
+		}
+	})
+
+	t.Run("balance below the reserve has nothing spendable", func(t *testing.T) {
+		reserves := CalculateReserves(testAccount, 100, "1500000", 5, objects, values)
+		if reserves.Spendable != "0" || reserves.Unattributed != 0 {
+			t.Errorf("spendable = %s, unattributed = %d", reserves.Spendable, reserves.Unattributed)
+		}
+	})
+}
@@ -2,18 +2,45 @@ package accounts
 
 import "time"
 
-// TransactionSchema define os campos para salvar transações no MongoDB
-type TransactionSchema struct {
-	Account      string `bson:"account"`
-	Fee          string `bson:"fee"`
-	TakerGets    string `bson:"taker_gets"` // Agora string
-	TakerPays    string `bson:"taker_pays"` // Agora string
-	Date         time.Time `bson:"date"`
-	OwnerFunds   string    `bson:"owner_funds"`
-	Type         string    `bson:"type"`
-	Validated    bool      `bson:"validated"`
-	Status       string    `bson:"status"`
-	LedgerHash   string    `bson:"ledger_hash"`
-	LedgerIndex  int       `bson:"ledger_index"`
-	CreatedAt    time.Time `bson:"created_at"`
+// Status of an account history sync
+const (
+	SyncStatusRunning = "running"
+	SyncStatusLive    = "live"
+	SyncStatusFailed  = "failed"
+)
+
+// AccountSyncSchema define o progresso da sincronização do histórico de uma conta
+type AccountSyncSchema struct {
+	Account string `bson:"account" json:"account"`
+	Status  string `bson:"status" json:"status"`
+
+	// Ledger range of the current account_tx run; Marker allows resuming it after a restart
+	LedgerIndexMin int              `bson:"ledger_index_min" json:"ledger_index_min"`
+	LedgerIndexMax int              `bson:"ledger_index_max" json:"ledger_index_max"`
+	Marker         *AccountTxMarker `bson:"marker,omitempty" json:"marker,omitempty"`
+
+	// SyncedLedger is the last ledger whose history is fully stored
+	SyncedLedger int    `bson:"synced_ledger" json:"synced_ledger"`
+	Pages        int    `bson:"pages" json:"pages"`
+	Transactions int    `bson:"transactions" json:"transactions"`
+	Error        string `bson:"error,omitempty" json:"error,omitempty"`
+
+	StartedAt   time.Time  `bson:"started_at" json:"started_at"`
+	UpdatedAt   time.Time  `bson:"updated_at" json:"updated_at"`
+	CompletedAt *time.Time `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// Status of a watchlist
+const (
+	WatchlistActive = "active"
+	WatchlistPaused = "paused"
+)
+
+// WatchlistSchema define uma lista nomeada de contas acompanhadas pelo stream de contas
+type WatchlistSchema struct {
+	Name      string    `bson:"name" json:"name"`
+	Accounts  []string  `bson:"accounts" json:"accounts"`
+	Status    string    `bson:"status" json:"status"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
 }
@@ -0,0 +1,327 @@
+package accounts
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"reflect"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AccountRoot flags (lsf*)
+const (
+	LsfPasswordSpent                uint32 = 0x00010000
+	LsfRequireDestTag               uint32 = 0x00020000
+	LsfRequireAuth                  uint32 = 0x00040000
+	LsfDisallowXRP                  uint32 = 0x00080000
+	LsfDisableMaster                uint32 = 0x00100000
+	LsfNoFreeze                     uint32 = 0x00200000
+	LsfGlobalFreeze                 uint32 = 0x00400000
+	LsfDefaultRipple                uint32 = 0x00800000
+	LsfDepositAuth                  uint32 = 0x01000000
+	LsfAMM                          uint32 = 0x02000000
+	LsfDisallowIncomingNFTokenOffer uint32 = 0x04000000
+	LsfDisallowIncomingCheck        uint32 = 0x08000000
+	LsfDisallowIncomingPayChan      uint32 = 0x10000000
+	LsfDisallowIncomingTrustline    uint32 = 0x20000000
+	LsfAllowTrustLineLocking        uint32 = 0x40000000
+	LsfAllowTrustLineClawback       uint32 = 0x80000000
+)
+
+// transferRateUnit is the TransferRate of a 0% fee (rates are in billionths)
+const transferRateUnit = 1000000000
+
+// AccountRootFields holds the settings fields of an AccountRoot entry
+type AccountRootFields struct {
+	Flags         uint32 `json:"Flags"`
+	Domain        string `json:"Domain,omitempty"`
+	EmailHash     string `json:"EmailHash,omitempty"`
+	MessageKey    string `json:"MessageKey,omitempty"`
+	RegularKey    string `json:"RegularKey,omitempty"`
+	TransferRate  uint32 `json:"TransferRate,omitempty"`
+	TickSize      uint8  `json:"TickSize,omitempty"`
+	NFTokenMinter string `json:"NFTokenMinter,omitempty"`
+	WalletLocator string `json:"WalletLocator,omitempty"`
+}
+
+// AccountFlags expands the AccountRoot flags into named booleans
+type AccountFlags struct {
+	PasswordSpent                bool `bson:"password_spent" json:"lsfPasswordSpent"`
+	RequireDestTag               bool `bson:"require_dest_tag" json:"lsfRequireDestTag"`
+	RequireAuth                  bool `bson:"require_auth" json:"lsfRequireAuth"`
+	DisallowXRP                  bool `bson:"disallow_xrp" json:"lsfDisallowXRP"`
+	DisableMaster                bool `bson:"disable_master" json:"lsfDisableMaster"`
+	NoFreeze                     bool `bson:"no_freeze" json:"lsfNoFreeze"`
+	GlobalFreeze                 bool `bson:"global_freeze" json:"lsfGlobalFreeze"`
+	DefaultRipple                bool `bson:"default_ripple" json:"lsfDefaultRipple"`
+	DepositAuth                  bool `bson:"deposit_auth" json:"lsfDepositAuth"`
+	AMM                          bool `bson:"amm" json:"lsfAMM"`
+	DisallowIncomingNFTokenOffer bool `bson:"disallow_incoming_nftoken_offer" json:"lsfDisallowIncomingNFTokenOffer"`
+	DisallowIncomingCheck        bool `bson:"disallow_incoming_check" json:"lsfDisallowIncomingCheck"`
+	DisallowIncomingPayChan      bool `bson:"disallow_incoming_paychan" json:"lsfDisallowIncomingPayChan"`
+	DisallowIncomingTrustline    bool `bson:"disallow_incoming_trustline" json:"lsfDisallowIncomingTrustline"`
+	AllowTrustLineLocking        bool `bson:"allow_trustline_locking" json:"lsfAllowTrustLineLocking"`
+	AllowTrustLineClawback       bool `bson:"allow_trustline_clawback" json:"lsfAllowTrustLineClawback"`
+}
+
+// AccountSettings is the interpreted form of the AccountRoot settings
+type AccountSettings struct {
+	Flags        uint32       `bson:"flags" json:"flags"`
+	Decoded      AccountFlags `bson:"decoded" json:"decoded"`
+	Domain       string       `bson:"domain,omitempty" json:"domain,omitempty"`
+	DomainHex    string       `bson:"domain_hex,omitempty" json:"domain_hex,omitempty"`
+	EmailHash    string       `bson:"email_hash,omitempty" json:"email_hash,omitempty"`
+	MessageKey   string       `bson:"message_key,omitempty" json:"message_key,omitempty"`
+	RegularKey   string       `bson:"regular_key,omitempty" json:"regular_key,omitempty"`
+	TransferRate uint32       `bson:"transfer_rate,omitempty" json:"transfer_rate,omitempty"`
+	// TransferFeePercent is the fee charged on transfers of the account's tokens
+	TransferFeePercent float64 `bson:"transfer_fee_percent" json:"transfer_fee_percent"`
+	TickSize           uint8   `bson:"tick_size,omitempty" json:"tick_size,omitempty"`
+	NFTokenMinter      string  `bson:"nftoken_minter,omitempty" json:"nftoken_minter,omitempty"`
+	WalletLocator      string  `bson:"wallet_locator,omitempty" json:"wallet_locator,omitempty"`
+}
+
+// AccountSettingsChangeSchema define uma alteração das configurações de uma conta por uma transação
+type AccountSettingsChangeSchema struct {
+	Account         string          `bson:"account" json:"account"`
+	TxHash          string          `bson:"tx_hash" json:"tx_hash"`
+	TransactionType string          `bson:"transaction_type" json:"transaction_type"`
+	LedgerIndex     int             `bson:"ledger_index" json:"ledger_index"`
+	Date            time.Time       `bson:"date" json:"date"`
+	Changed         []string        `bson:"changed" json:"changed"`
+	Before          AccountSettings `bson:"before" json:"before"`
+	After           AccountSettings `bson:"after" json:"after"`
+	CreatedAt       time.Time       `bson:"created_at" json:"created_at"`
+}
+
+// DecodeFlags expands AccountRoot flags into named booleans
+func DecodeFlags(flags uint32) AccountFlags {
+	return AccountFlags{
+		PasswordSpent:                flags&LsfPasswordSpent != 0,
+		RequireDestTag:               flags&LsfRequireDestTag != 0,
+		RequireAuth:                  flags&LsfRequireAuth != 0,
+		DisallowXRP:                  flags&LsfDisallowXRP != 0,
+		DisableMaster:                flags&LsfDisableMaster != 0,
+		NoFreeze:                     flags&LsfNoFreeze != 0,
+		GlobalFreeze:                 flags&LsfGlobalFreeze != 0,
+		DefaultRipple:                flags&LsfDefaultRipple != 0,
+		DepositAuth:                  flags&LsfDepositAuth != 0,
+		AMM:                          flags&LsfAMM != 0,
+		DisallowIncomingNFTokenOffer: flags&LsfDisallowIncomingNFTokenOffer != 0,
+		DisallowIncomingCheck:        flags&LsfDisallowIncomingCheck != 0,
+		DisallowIncomingPayChan:      flags&LsfDisallowIncomingPayChan != 0,
+		DisallowIncomingTrustline:    flags&LsfDisallowIncomingTrustline != 0,
+		AllowTrustLineLocking:        flags&LsfAllowTrustLineLocking != 0,
+		AllowTrustLineClawback:       flags&LsfAllowTrustLineClawback != 0,
+	}
+}
+
+// DecodeSettings interprets the settings of an AccountRoot: flags, Domain as text
+// and TransferRate as a percentage
+func DecodeSettings(fields AccountRootFields) AccountSettings {
+	settings := AccountSettings{
+		Flags:         fields.Flags,
+		Decoded:       DecodeFlags(fields.Flags),
+		DomainHex:     fields.Domain,
+		EmailHash:     fields.EmailHash,
+		MessageKey:    fields.MessageKey,
+		RegularKey:    fields.RegularKey,
+		TransferRate:  fields.TransferRate,
+		TickSize:      fields.TickSize,
+		NFTokenMinter: fields.NFTokenMinter,
+		WalletLocator: fields.WalletLocator,
+	}
+
+	if domain, err := hex.DecodeString(fields.Domain); err == nil && utf8.Valid(domain) {
+		settings.Domain = string(domain)
+	}
+	if fields.TransferRate > transferRateUnit {
+		settings.TransferFeePercent = float64(fields.TransferRate-transferRateUnit) / 1e7
+	}
+	return settings
+}
+
+// DecodeSettingsMap interprets the settings of an AccountRoot decoded as a generic map
+func DecodeSettingsMap(fields map[string]interface{}) (AccountSettings, error) {
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return AccountSettings{}, err
+	}
+
+	var root AccountRootFields
+	if err := json.Unmarshal(encoded, &root); err != nil {
+		return AccountSettings{}, err
+	}
+	return DecodeSettings(root), nil
+}
+
+// FetchAccountSettings fetches an account's AccountRoot and decodes its settings at the given ledger
+func FetchAccountSettings(client *xrpl.HTTPClient, account string, ledgerIndex string) (*AccountSettings, int, error) {
+	response, err := FetchAccountInfo(client, account, ledgerIndex, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var info struct {
+		Result struct {
+			AccountData  AccountRootFields `json:"account_data"`
+			LedgerIndex  int               `json:"ledger_index"`
+			Error        string            `json:"error"`
+			ErrorMessage string            `json:"error_message"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(response, &info); err != nil {
+		return nil, 0, err
+	}
+	if info.Result.Error != "" {
+		return nil, 0, &transactions.RPCError{Code: info.Result.Error, Message: info.Result.ErrorMessage}
+	}
+
+	settings := DecodeSettings(info.Result.AccountData)
+	return &settings, info.Result.LedgerIndex, nil
+}
+
+// StartSettingsHistory records the settings changes of every validated transaction stored through Ingest or Backfill
+func StartSettingsHistory() {
+	transactions.RegisterStateHandler(HandleSettingsTransaction)
+}
+
+// HandleSettingsTransaction stores the AccountRoot settings changed by a transaction
+func HandleSettingsTransaction(tx *transactions.Transaction) {
+	if !tx.Validated || tx.Meta == nil {
+		return
+	}
+
+	for _, change := range SettingsChanges(tx) {
+		if err := saveSettingsChange(change); err != nil {
+			log.Printf("❌ Erro ao salvar alteração de configurações de %s: %v", change.Account, err)
+			continue
+		}
+		log.Printf("⚙️ Configurações de %s alteradas por %s: %v", change.Account, tx.Hash, change.Changed)
+	}
+}
+
+// SettingsChanges compares the settings of the modified AccountRoot entries before and after a transaction
+func SettingsChanges(tx *transactions.Transaction) []AccountSettingsChangeSchema {
+	changes := []AccountSettingsChangeSchema{}
+	if tx.Meta == nil {
+		return changes
+	}
+
+	for _, affected := range tx.Meta.AffectedNodes {
+		nodeType, node := affected.Node()
+		if node == nil || nodeType != transactions.NodeModified || node.LedgerEntryType != "AccountRoot" || len(node.PreviousFields) == 0 {
+			continue
+		}
+
+		// The previous state is the final state with the previous values of the changed fields
+		previous := map[string]interface{}{}
+		for key, value := range node.FinalFields {
+			previous[key] = value
+		}
+		for key, value := range node.PreviousFields {
+			previous[key] = value
+		}
+
+		before, errBefore := DecodeSettingsMap(previous)
+		after, errAfter := DecodeSettingsMap(node.FinalFields)
+		if errBefore != nil || errAfter != nil {
+			continue
+		}
+
+		changed := changedSettings(before, after)
+		if len(changed) == 0 {
+			continue
+		}
+
+		account, _ := node.FinalFields["Account"].(string)
+		changes = append(changes, AccountSettingsChangeSchema{
+			Account:         account,
+			TxHash:          tx.Hash,
+			TransactionType: tx.TransactionType,
+			LedgerIndex:     tx.LedgerIndex,
+			Date:            tx.Date,
+			Changed:         changed,
+			Before:          before,
+			After:           after,
+			CreatedAt:       time.Now(),
+		})
+	}
+	return changes
+}
+
+// changedSettings lists the settings (by JSON name) that differ, with flags listed individually
+func changedSettings(before, after AccountSettings) []string {
+	changed := []string{}
+
+	flagsBefore := reflect.ValueOf(before.Decoded)
+	flagsAfter := reflect.ValueOf(after.Decoded)
+	flagsType := flagsBefore.Type()
+	for i := 0; i < flagsType.NumField(); i++ {
+		if flagsBefore.Field(i).Bool() != flagsAfter.Field(i).Bool() {
+			changed = append(changed, flagsType.Field(i).Tag.Get("json"))
+		}
+	}
+
+	fields := []struct {
+		name          string
+		before, after interface{}
+	}{
+		{"Domain", before.DomainHex, after.DomainHex},
+		{"EmailHash", before.EmailHash, after.EmailHash},
+		{"MessageKey", before.MessageKey, after.MessageKey},
+		{"RegularKey", before.RegularKey, after.RegularKey},
+		{"TransferRate", before.TransferRate, after.TransferRate},
+		{"TickSize", before.TickSize, after.TickSize},
+		{"NFTokenMinter", before.NFTokenMinter, after.NFTokenMinter},
+		{"WalletLocator", before.WalletLocator, after.WalletLocator},
+	}
+	for _, field := range fields {
+		if field.before != field.after {
+			changed = append(changed, field.name)
+		}
+	}
+	return changed
+}
+
+func saveSettingsChange(change AccountSettingsChangeSchema) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := database.GetAccountSettingsCollection().InsertOne(ctx, change)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// GetSettingsHistory returns the settings changes of an account, newest first
+func GetSettingsHistory(account string, limit int64) ([]AccountSettingsChangeSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetAccountSettingsCollection().Find(ctx, bson.M{"account": account}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	history := []AccountSettingsChangeSchema{}
+	if err := cursor.All(ctx, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
@@ -0,0 +1,201 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AccountTxPageLimit is the number of transactions requested per account_tx page
+const AccountTxPageLimit = 200
+
+var (
+	syncMu       sync.Mutex
+	runningSyncs = map[string]bool{}
+)
+
+// ErrSyncRunning is returned when a sync is already running for the account
+var ErrSyncRunning = errors.New("sync already running for account")
+
+// StartAccountSync pulls the complete history of an account into the transactions collection
+// and then keeps it current through the accounts stream. An interrupted sync resumes from its marker
+func StartAccountSync(client *xrpl.HTTPClient, wsClient *xrpl.WebSocketClient, account string) error {
+	syncMu.Lock()
+	if runningSyncs[account] {
+		syncMu.Unlock()
+		return ErrSyncRunning
+	}
+	runningSyncs[account] = true
+	syncMu.Unlock()
+
+	go func() {
+		defer func() {
+			syncMu.Lock()
+			delete(runningSyncs, account)
+			syncMu.Unlock()
+		}()
+
+		if err := runAccountSync(client, wsClient, account); err != nil {
+			log.Printf("❌ Erro na sincronização da conta %s: %v", account, err)
+		}
+	}()
+	return nil
+}
+
+func runAccountSync(client *xrpl.HTTPClient, wsClient *xrpl.WebSocketClient, account string) error {
+	// Follow the stream before paging so no transaction falls between history and live data
+	if err := FollowAccount(wsClient, account); err != nil {
+		return err
+	}
+
+	progress, err := GetAccountSync(account)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		progress = &AccountSyncSchema{Account: account}
+	} else if err != nil {
+		return err
+	}
+
+	// A new run starts after the last synced ledger; a running one resumes from its marker
+	if progress.Status != SyncStatusRunning || progress.Marker == nil {
+		progress.LedgerIndexMin = -1
+		if progress.SyncedLedger > 0 {
+			progress.LedgerIndexMin = progress.SyncedLedger + 1
+		}
+		progress.LedgerIndexMax = -1
+		progress.Marker = nil
+		progress.StartedAt = time.Now()
+		progress.CompletedAt = nil
+	}
+	progress.Status = SyncStatusRunning
+	progress.Error = ""
+
+	log.Printf("🔄 Sincronizando histórico da conta %s a partir do ledger %d", account, progress.LedgerIndexMin)
+
+	for {
+		page, err := FetchAccountTxPage(client, account, progress.LedgerIndexMin, progress.LedgerIndexMax, true, AccountTxPageLimit, progress.Marker)
+		if err != nil {
+			progress.Status = SyncStatusFailed
+			progress.Error = err.Error()
+			saveAccountSync(progress)
+			return err
+		}
+
+		// Pin the range of the run so the marker stays valid across pages and restarts
+		if progress.LedgerIndexMin < 0 {
+			progress.LedgerIndexMin = page.Result.LedgerIndexMin
+		}
+		if progress.LedgerIndexMax < 0 {
+			progress.LedgerIndexMax = page.Result.LedgerIndexMax
+		}
+
+		for i := range page.Result.Transactions {
+			if _, err := transactions.Backfill(page.Result.Transactions[i].Raw()); err != nil {
+				log.Printf("⚠️ Erro ao salvar transação da conta %s: %v", account, err)
+				continue
+			}
+			progress.Transactions++
+		}
+		progress.Pages++
+		progress.Marker = page.Result.Marker
+
+		if progress.Marker == nil {
+			now := time.Now()
+			progress.Status = SyncStatusLive
+			progress.SyncedLedger = progress.LedgerIndexMax
+			progress.CompletedAt = &now
+			saveAccountSync(progress)
+
+			log.Printf("✅ Histórico da conta %s sincronizado até o ledger %d (%d transações)", account, progress.SyncedLedger, progress.Transactions)
+			return nil
+		}
+
+		if err := saveAccountSync(progress); err != nil {
+			return err
+		}
+	}
+}
+
+// markLiveLedger advances the synced ledger of live accounts touched by a validated transaction
+func markLiveLedger(accounts []string, ledgerIndex int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"account": bson.M{"$in": accounts}, "status": SyncStatusLive}
+	update := bson.M{"$max": bson.M{"synced_ledger": ledgerIndex}, "$set": bson.M{"updated_at": time.Now()}}
+
+	if _, err := database.GetAccountSyncCollection().UpdateMany(ctx, filter, update); err != nil {
+		log.Printf("⚠️ Erro ao atualizar progresso das contas: %v", err)
+	}
+}
+
+// ResumeAccountSyncs restarts running and live syncs after a restart, filling the gap since the last synced ledger
+func ResumeAccountSyncs(client *xrpl.HTTPClient, wsClient *xrpl.WebSocketClient) error {
+	syncs, err := GetAccountSyncs(SyncStatusRunning, SyncStatusLive)
+	if err != nil {
+		return err
+	}
+
+	for _, progress := range syncs {
+		if err := StartAccountSync(client, wsClient, progress.Account); err != nil {
+			log.Printf("⚠️ Não foi possível retomar a sincronização da conta %s: %v", progress.Account, err)
+		}
+	}
+	log.Printf("✅ %d sincronizações de contas retomadas", len(syncs))
+	return nil
+}
+
+func saveAccountSync(progress *AccountSyncSchema) error {
+	progress.UpdatedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := database.GetAccountSyncCollection().ReplaceOne(ctx, bson.M{"account": progress.Account}, progress, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Printf("❌ Erro ao salvar progresso da conta %s: %v", progress.Account, err)
+	}
+	return err
+}
+
+// GetAccountSync returns the sync progress of an account
+func GetAccountSync(account string) (*AccountSyncSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var progress AccountSyncSchema
+	if err := database.GetAccountSyncCollection().FindOne(ctx, bson.M{"account": account}).Decode(&progress); err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// GetAccountSyncs returns the sync progress of every account, optionally filtered by status
+func GetAccountSyncs(statuses ...string) ([]AccountSyncSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
+	}
+
+	cursor, err := database.GetAccountSyncCollection().Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	syncs := []AccountSyncSchema{}
+	if err := cursor.All(ctx, &syncs); err != nil {
+		return nil, err
+	}
+	return syncs, nil
+}
@@ -1,5 +1,13 @@
 package accounts
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
 // ---------- HTTP Types ----------
 
 type Channel struct {
@@ -78,13 +86,16 @@ type AccountInfoResponse struct {
 		AccountData struct {
 			Account           string `json:"Account"`           // Account address.
 			Balance           string `json:"Balance"`           // Current XRP balance in drops.
+			Flags             uint32 `json:"Flags"`             // AccountRoot flags.
 			OwnerCount        int    `json:"OwnerCount"`        // Number of objects owned by the account.
 			Sequence          int    `json:"Sequence"`          // Current transaction sequence number.
 			PreviousTxnID     string `json:"PreviousTxnID"`     // Last transaction affecting the account.
 			PreviousTxnLgrSeq int    `json:"PreviousTxnLgrSeq"` // Ledger sequence of the last transaction affecting the account.
 		} `json:"account_data"`
-		LedgerIndex int  `json:"ledger_index"` // Ledger index used for the query.
-		Validated   bool `json:"validated"`   // Indicates if the data is from a validated ledger.
+		LedgerIndex  int    `json:"ledger_index"` // Ledger index used for the query.
+		Validated    bool   `json:"validated"`   // Indicates if the data is from a validated ledger.
+		Error        string `json:"error,omitempty"`
+		ErrorMessage string `json:"error_message,omitempty"`
 	} `json:"result"`
 }
 
@@ -95,8 +106,10 @@ type AccountLinesRequest struct {
 }
 
 type AccountLinesParam struct {
-	Account     string `json:"account"`                // The account to query for trust lines.
-	LedgerIndex string `json:"ledger_index,omitempty"` // Optional: Specify a ledger index or shortcut.
+	Account     string      `json:"account"`                // The account to query for trust lines.
+	LedgerIndex string      `json:"ledger_index,omitempty"` // Optional: Specify a ledger index or shortcut.
+	Limit       int         `json:"limit,omitempty"`        // Optional: Page size.
+	Marker      interface{} `json:"marker,omitempty"`       // Optional: Marker of the next page.
 }
 
 type AccountLinesResponse struct {
@@ -104,21 +117,33 @@ type AccountLinesResponse struct {
 	Status string `json:"status"`
 	Type   string `json:"type"`
 	Result struct {
-		Account string `json:"account"` // Account queried.
-		Lines   []struct {
-			Account    string `json:"account"`    // Counterparty account.
-			Balance    string `json:"balance"`    // Current balance on the trust line.
-			Currency   string `json:"currency"`   // Currency code.
-			Limit      string `json:"limit"`      // Max amount the account is willing to owe.
-			LimitPeer  string `json:"limit_peer"` // Max amount the peer is willing to owe.
-			QualityIn  int    `json:"quality_in"` // Quality in value for this trust line.
-			QualityOut int    `json:"quality_out"` // Quality out value for this trust line.
-		} `json:"lines"`
-		LedgerIndex int  `json:"ledger_index"` // Ledger index used for the query.
-		Validated   bool `json:"validated"`   // Indicates if the data is from a validated ledger.
+		Account      string      `json:"account"`      // Account queried.
+		Lines        []TrustLine `json:"lines"`        // Trust lines of the account.
+		LedgerIndex  int         `json:"ledger_index"` // Ledger index used for the query.
+		Validated    bool        `json:"validated"`    // Indicates if the data is from a validated ledger.
+		Marker       interface{} `json:"marker,omitempty"`
+		Error        string      `json:"error,omitempty"`
+		ErrorMessage string      `json:"error_message,omitempty"`
 	} `json:"result"`
 }
 
+// TrustLine is one trust line of account_lines, from the account's point of view
+type TrustLine struct {
+	Account        string `json:"account"`    // Counterparty account.
+	Balance        string `json:"balance"`    // Current balance on the trust line.
+	Currency       string `json:"currency"`   // Currency code.
+	Limit          string `json:"limit"`      // Max amount the account is willing to owe.
+	LimitPeer      string `json:"limit_peer"` // Max amount the peer is willing to owe.
+	QualityIn      int    `json:"quality_in"` // Quality in value for this trust line.
+	QualityOut     int    `json:"quality_out"` // Quality out value for this trust line.
+	NoRipple       bool   `json:"no_ripple"`
+	NoRipplePeer   bool   `json:"no_ripple_peer"`
+	Authorized     bool   `json:"authorized"`
+	PeerAuthorized bool   `json:"peer_authorized"`
+	Freeze         bool   `json:"freeze"`
+	FreezePeer     bool   `json:"freeze_peer"`
+}
+
 // ---------- WebSocket Types ----------
 
 // WebSocket: Account Channels
@@ -185,6 +210,7 @@ type AccountInfoWSResponse struct {
 			Sequence          int    `json:"Sequence"`
 			Index             string `json:"index"`
 		} `json:"account_data"`
+		AccountSettings    *AccountSettings `json:"account_settings,omitempty"` // Decoded flags and settings of account_data.
 		LedgerCurrentIndex int  `json:"ledger_current_index"`
 		QueueData          struct {
 			AuthChangeQueued bool   `json:"auth_change_queued"`
@@ -257,6 +283,8 @@ type AccountNFTsResponse struct {
 		LedgerIndex int      `json:"ledger_index,omitempty"`
 		Validated   bool     `json:"validated"`
 		Marker      *string  `json:"marker,omitempty"`
+		Error        string  `json:"error,omitempty"`
+		ErrorMessage string  `json:"error_message,omitempty"`
 	} `json:"result"`
 }
 
@@ -351,21 +379,468 @@ type SubscribeAccountsRequest struct {
 	Accounts []string `json:"accounts"`
 }
 
-// AccountTransactionMessage representa a mensagem de transação recebida do WebSocket
-type AccountTransactionMessage struct {
-	Type        string `json:"type"`
+
+// ACCOUNT TX TYPES
+
+// AccountTxRequest defines the JSON-RPC request for account_tx
+type AccountTxRequest struct {
+	Method string           `json:"method"`
+	Params []AccountTxParam `json:"params"`
+}
+
+// AccountTxParam defines the parameters for account_tx
+type AccountTxParam struct {
+	Account        string           `json:"account"`
+	LedgerIndexMin int              `json:"ledger_index_min"`
+	LedgerIndexMax int              `json:"ledger_index_max"`
+	Forward        bool             `json:"forward,omitempty"`
+	Limit          int              `json:"limit,omitempty"`
+	Marker         *AccountTxMarker `json:"marker,omitempty"`
+}
+
+// AccountTxMarker is the pagination marker returned by account_tx
+type AccountTxMarker struct {
+	Ledger int `json:"ledger" bson:"ledger"`
+	Seq    int `json:"seq" bson:"seq"`
+}
+
+// AccountTxResponse defines the account_tx response (API v1)
+type AccountTxResponse struct {
+	Result struct {
+		Account        string           `json:"account"`
+		LedgerIndexMin int              `json:"ledger_index_min"`
+		LedgerIndexMax int              `json:"ledger_index_max"`
+		Limit          int              `json:"limit"`
+		Marker         *AccountTxMarker `json:"marker,omitempty"`
+		Transactions   []AccountTxEntry `json:"transactions"`
+		Validated      bool             `json:"validated"`
+		Status         string           `json:"status"`
+		Error          string           `json:"error,omitempty"`
+		ErrorMessage   string           `json:"error_message,omitempty"`
+	} `json:"result"`
+}
+
+// AccountTxEntry is one transaction of an account_tx page
+type AccountTxEntry struct {
+	Tx        json.RawMessage               `json:"tx"`
+	Meta      *transactions.TransactionMeta `json:"meta"`
+	Validated bool                          `json:"validated"`
+}
+
+// Raw converts an account_tx entry into a RawTransaction
+func (e *AccountTxEntry) Raw() *transactions.RawTransaction {
+	return &transactions.RawTransaction{
+		TxJSON:    e.Tx,
+		Meta:      e.Meta,
+		Validated: e.Validated,
+	}
+}
+
+
+// ACCOUNT OBJECTS AND OFFERS TYPES
+
+// AccountObjectsRequest defines the JSON-RPC request for account_objects
+type AccountObjectsRequest struct {
+	Method string                 `json:"method"`
+	Params []AccountObjectsParam `json:"params"`
+}
+
+// AccountObjectsParam defines the parameters for account_objects
+type AccountObjectsParam struct {
+	Account     string      `json:"account"`
+	LedgerIndex string      `json:"ledger_index,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	Limit       int         `json:"limit,omitempty"`
+	Marker      interface{} `json:"marker,omitempty"`
+}
+
+// AccountObjectsResponse defines the account_objects response; objects are decoded by type
+type AccountObjectsResponse struct {
+	Result struct {
+		Account        string            `json:"account"`
+		AccountObjects []json.RawMessage `json:"account_objects"`
+		LedgerIndex    int               `json:"ledger_index"`
+		Validated      bool              `json:"validated"`
+		Marker         interface{}       `json:"marker,omitempty"`
+		Error          string            `json:"error,omitempty"`
+		ErrorMessage   string            `json:"error_message,omitempty"`
+	} `json:"result"`
+}
+
+// AccountOffersRequest defines the JSON-RPC request for account_offers
+type AccountOffersRequest struct {
+	Method string               `json:"method"`
+	Params []AccountOffersParam `json:"params"`
+}
+
+// AccountOffersParam defines the parameters for account_offers
+type AccountOffersParam struct {
+	Account     string      `json:"account"`
+	LedgerIndex string      `json:"ledger_index,omitempty"`
+	Limit       int         `json:"limit,omitempty"`
+	Marker      interface{} `json:"marker,omitempty"`
+}
+
+// AccountOffersResponse defines the account_offers response
+type AccountOffersResponse struct {
+	Result struct {
+		Account      string         `json:"account"`
+		Offers       []AccountOffer `json:"offers"`
+		LedgerIndex  int            `json:"ledger_index"`
+		Validated    bool           `json:"validated"`
+		Marker       interface{}    `json:"marker,omitempty"`
+		Error        string         `json:"error,omitempty"`
+		ErrorMessage string         `json:"error_message,omitempty"`
+	} `json:"result"`
+}
+
+// AccountOffer is an open DEX offer of the account
+type AccountOffer struct {
+	Flags      uint32      `json:"flags"`
+	Seq        uint32      `json:"seq"`
+	TakerGets  xrpl.Amount `json:"taker_gets"`
+	TakerPays  xrpl.Amount `json:"taker_pays"`
+	Quality    string      `json:"quality"`
+	Expiration uint32      `json:"expiration,omitempty"`
+}
+
+// LedgerObjectHeader holds the fields shared by every ledger entry
+type LedgerObjectHeader struct {
+	LedgerEntryType   string `json:"LedgerEntryType"`
+	Index             string `json:"index"`
+	Flags             uint32 `json:"Flags"`
+	OwnerNode         string `json:"OwnerNode,omitempty"`
+	PreviousTxnID     string `json:"PreviousTxnID,omitempty"`
+	PreviousTxnLgrSeq uint32 `json:"PreviousTxnLgrSeq,omitempty"`
+}
+
+// CheckObject is a Check ledger entry
+type CheckObject struct {
+	LedgerObjectHeader
+	Account        string      `json:"Account"`
+	Destination    string      `json:"Destination"`
+	SendMax        xrpl.Amount `json:"SendMax"`
+	Sequence       uint32      `json:"Sequence"`
+	Expiration     uint32      `json:"Expiration,omitempty"`
+	InvoiceID      string      `json:"InvoiceID,omitempty"`
+	DestinationTag *uint32     `json:"DestinationTag,omitempty"`
+	SourceTag      *uint32     `json:"SourceTag,omitempty"`
+}
+
+// CredentialObject is a Credential ledger entry
+type CredentialObject struct {
+	LedgerObjectHeader
+	Subject        string `json:"Subject"`
+	Issuer         string `json:"Issuer"`
+	CredentialType string `json:"CredentialType"`
+	Expiration     uint32 `json:"Expiration,omitempty"`
+	URI            string `json:"URI,omitempty"`
+}
+
+// DepositPreauthObject is a DepositPreauth ledger entry
+type DepositPreauthObject struct {
+	LedgerObjectHeader
+	Account   string `json:"Account"`
+	Authorize string `json:"Authorize,omitempty"`
+}
+
+// DIDObject is a DID ledger entry
+type DIDObject struct {
+	LedgerObjectHeader
 	Account     string `json:"Account"`
-	LedgerHash  string `json:"ledger_hash"`
-	LedgerIndex int    `json:"ledger_index"`
-	Tx          struct {
-		TransactionType string      `json:"TransactionType"`
-		Account         string      `json:"Account"`
-		Fee             string      `json:"Fee"`
-		TakerGets       interface{} `json:"TakerGets"`
-		TakerPays       interface{} `json:"TakerPays"`
-		Date            int64       `json:"date"`
-		OwnerFunds      string      `json:"owner_funds"`
-	} `json:"transaction"`
-	Validated bool   `json:"validated"`
-	Status    string `json:"status"`
-}
\ No newline at end of file
+	DIDDocument string `json:"DIDDocument,omitempty"`
+	Data        string `json:"Data,omitempty"`
+	URI         string `json:"URI,omitempty"`
+}
+
+// EscrowObject is an Escrow ledger entry
+type EscrowObject struct {
+	LedgerObjectHeader
+	Account        string      `json:"Account"`
+	Destination    string      `json:"Destination"`
+	Amount         xrpl.Amount `json:"Amount"`
+	Condition      string      `json:"Condition,omitempty"`
+	FinishAfter    uint32      `json:"FinishAfter,omitempty"`
+	CancelAfter    uint32      `json:"CancelAfter,omitempty"`
+	DestinationTag *uint32     `json:"DestinationTag,omitempty"`
+	SourceTag      *uint32     `json:"SourceTag,omitempty"`
+}
+
+// MPTokenObject is an MPToken ledger entry (a holder's balance of an MPT)
+type MPTokenObject struct {
+	LedgerObjectHeader
+	Account           string `json:"Account"`
+	MPTokenIssuanceID string `json:"MPTokenIssuanceID"`
+	MPTAmount         string `json:"MPTAmount,omitempty"`
+}
+
+// MPTokenIssuanceObject is an MPTokenIssuance ledger entry
+type MPTokenIssuanceObject struct {
+	LedgerObjectHeader
+	Issuer            string `json:"Issuer"`
+	Sequence          uint32 `json:"Sequence"`
+	AssetScale        uint8  `json:"AssetScale,omitempty"`
+	MaximumAmount     string `json:"MaximumAmount,omitempty"`
+	OutstandingAmount string `json:"OutstandingAmount"`
+	TransferFee       uint16 `json:"TransferFee,omitempty"`
+	MPTokenMetadata   string `json:"MPTokenMetadata,omitempty"`
+}
+
+// NFTokenOfferObject is an NFTokenOffer ledger entry
+type NFTokenOfferObject struct {
+	LedgerObjectHeader
+	Owner       string      `json:"Owner"`
+	NFTokenID   string      `json:"NFTokenID"`
+	Amount      xrpl.Amount `json:"Amount"`
+	Destination string      `json:"Destination,omitempty"`
+	Expiration  uint32      `json:"Expiration,omitempty"`
+}
+
+// NFTokenPageObject is an NFTokenPage ledger entry
+type NFTokenPageObject struct {
+	LedgerObjectHeader
+	NFTokens []struct {
+		NFToken struct {
+			NFTokenID string `json:"NFTokenID"`
+			URI       string `json:"URI,omitempty"`
+		} `json:"NFToken"`
+	} `json:"NFTokens"`
+	NextPageMin     string `json:"NextPageMin,omitempty"`
+	PreviousPageMin string `json:"PreviousPageMin,omitempty"`
+}
+
+// OfferObject is an Offer ledger entry
+type OfferObject struct {
+	LedgerObjectHeader
+	Account       string      `json:"Account"`
+	Sequence      uint32      `json:"Sequence"`
+	TakerGets     xrpl.Amount `json:"TakerGets"`
+	TakerPays     xrpl.Amount `json:"TakerPays"`
+	BookDirectory string      `json:"BookDirectory"`
+	BookNode      string      `json:"BookNode,omitempty"`
+	Expiration    uint32      `json:"Expiration,omitempty"`
+}
+
+// OracleObject is an Oracle ledger entry
+type OracleObject struct {
+	LedgerObjectHeader
+	Owner           string `json:"Owner"`
+	Provider        string `json:"Provider"`
+	AssetClass      string `json:"AssetClass"`
+	URI             string `json:"URI,omitempty"`
+	LastUpdateTime  uint32 `json:"LastUpdateTime"`
+	PriceDataSeries []struct {
+		PriceData OraclePrice `json:"PriceData"`
+	} `json:"PriceDataSeries"`
+}
+
+// OraclePrice is one price of an oracle
+type OraclePrice struct {
+	BaseAsset  string `json:"BaseAsset"`
+	QuoteAsset string `json:"QuoteAsset"`
+	AssetPrice string `json:"AssetPrice,omitempty"`
+	Scale      uint8  `json:"Scale,omitempty"`
+}
+
+// PayChannelObject is a PayChannel ledger entry
+type PayChannelObject struct {
+	LedgerObjectHeader
+	Account        string  `json:"Account"`
+	Destination    string  `json:"Destination"`
+	Amount         string  `json:"Amount"`
+	Balance        string  `json:"Balance"`
+	PublicKey      string  `json:"PublicKey"`
+	SettleDelay    uint32  `json:"SettleDelay"`
+	Expiration     uint32  `json:"Expiration,omitempty"`
+	CancelAfter    uint32  `json:"CancelAfter,omitempty"`
+	DestinationTag *uint32 `json:"DestinationTag,omitempty"`
+	SourceTag      *uint32 `json:"SourceTag,omitempty"`
+}
+
+// RippleStateObject is a RippleState (trust line) ledger entry; Balance is from the low account's point of view
+type RippleStateObject struct {
+	LedgerObjectHeader
+	Balance   xrpl.Amount `json:"Balance"`
+	LowLimit  xrpl.Amount `json:"LowLimit"`
+	HighLimit xrpl.Amount `json:"HighLimit"`
+	LowNode   string      `json:"LowNode,omitempty"`
+	HighNode  string      `json:"HighNode,omitempty"`
+}
+
+// SignerListObject is a SignerList ledger entry
+type SignerListObject struct {
+	LedgerObjectHeader
+	SignerListID  uint32 `json:"SignerListID"`
+	SignerQuorum  uint32 `json:"SignerQuorum"`
+	SignerEntries []struct {
+		SignerEntry SignerEntry `json:"SignerEntry"`
+	} `json:"SignerEntries"`
+}
+
+// SignerEntry is one signer of a signer list
+type SignerEntry struct {
+	Account       string `json:"Account"`
+	SignerWeight  uint16 `json:"SignerWeight"`
+	WalletLocator string `json:"WalletLocator,omitempty"`
+}
+
+// TicketObject is a Ticket ledger entry
+type TicketObject struct {
+	LedgerObjectHeader
+	Account        string `json:"Account"`
+	TicketSequence uint32 `json:"TicketSequence"`
+}
+
+// AccountObjectsPage is a decoded page of account_objects. Objects hold the typed entries
+// (*EscrowObject, *CheckObject...) or a map for entry types without a struct
+type AccountObjectsPage struct {
+	Account     string        `json:"account"`
+	LedgerIndex int           `json:"ledger_index"`
+	Validated   bool          `json:"validated"`
+	Objects     []interface{} `json:"account_objects"`
+	Marker      interface{}   `json:"marker,omitempty"`
+}
+
+// AccountOffersPage is a decoded page of account_offers
+type AccountOffersPage struct {
+	Account     string         `json:"account"`
+	LedgerIndex int            `json:"ledger_index"`
+	Validated   bool           `json:"validated"`
+	Offers      []AccountOffer `json:"offers"`
+	Marker      interface{}    `json:"marker,omitempty"`
+}
+
+// WebSocket: Account Objects
+type AccountObjectsWSRequest struct {
+	ID          int         `json:"id"`
+	Command     string      `json:"command"`
+	Account     string      `json:"account"`
+	LedgerIndex string      `json:"ledger_index,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	Limit       int         `json:"limit,omitempty"`
+	Marker      interface{} `json:"marker,omitempty"`
+}
+
+// WebSocket: Account Offers
+type AccountOffersWSRequest struct {
+	ID          int         `json:"id"`
+	Command     string      `json:"command"`
+	Account     string      `json:"account"`
+	LedgerIndex string      `json:"ledger_index,omitempty"`
+	Limit       int         `json:"limit,omitempty"`
+	Marker      interface{} `json:"marker,omitempty"`
+}
+
+
+// PORTFOLIO TYPES
+
+// Portfolio is the complete picture of an account at one validated ledger
+type Portfolio struct {
+	Account     string             `json:"account"`
+	LedgerIndex int                `json:"ledger_index"`
+	XRP         PortfolioXRP       `json:"xrp"`
+	OwnerCount  int                `json:"owner_count"`
+	Tokens      []TrustLine        `json:"tokens"`
+	Offers      []AccountOffer     `json:"offers"`
+	Escrows     []PortfolioEscrow  `json:"escrows"`
+	Channels    []PortfolioChannel `json:"channels"`
+	NFTs        []NFT              `json:"nfts"`
+	LPPositions []LPPosition       `json:"lp_positions"`
+}
+
+// PortfolioXRP holds the XRP balance and reserves of an account, in drops
+type PortfolioXRP struct {
+	Balance      string `json:"balance"`
+	BaseReserve  string `json:"base_reserve"`
+	OwnerReserve string `json:"owner_reserve"`
+	Reserve      string `json:"reserve"`
+	Spendable    string `json:"spendable"`
+}
+
+// PortfolioEscrow is an escrow sent (Incoming false) or to be received by the account
+type PortfolioEscrow struct {
+	ID             string      `json:"id"`
+	Account        string      `json:"account"`
+	Destination    string      `json:"destination"`
+	Amount         xrpl.Amount `json:"amount"`
+	Condition      string      `json:"condition,omitempty"`
+	FinishAfter    *time.Time  `json:"finish_after,omitempty"`
+	CancelAfter    *time.Time  `json:"cancel_after,omitempty"`
+	DestinationTag *uint32     `json:"destination_tag,omitempty"`
+	Incoming       bool        `json:"incoming"`
+}
+
+// PortfolioChannel is a payment channel opened by (Incoming false) or to the account; amounts in drops
+type PortfolioChannel struct {
+	ChannelID   string     `json:"channel_id"`
+	Account     string     `json:"account"`
+	Destination string     `json:"destination"`
+	Amount      string     `json:"amount"`
+	Balance     string     `json:"balance"`
+	Remaining   string     `json:"remaining"`
+	SettleDelay uint32     `json:"settle_delay"`
+	Expiration  *time.Time `json:"expiration,omitempty"`
+	CancelAfter *time.Time `json:"cancel_after,omitempty"`
+	Incoming    bool       `json:"incoming"`
+}
+
+// LPPosition is a holding of AMM LP tokens and the share of the pool it represents
+type LPPosition struct {
+	AMMAccount string        `json:"amm_account"`
+	LPToken    xrpl.Amount   `json:"lp_token"`
+	Share      float64       `json:"share"`
+	Assets     []xrpl.Amount `json:"assets"`
+	TradingFee int           `json:"trading_fee"`
+}
+
+// AMMInfoByAccountRequest defines the amm_info request by AMM account
+type AMMInfoByAccountRequest struct {
+	Method string                  `json:"method"`
+	Params []AMMInfoByAccountParam `json:"params"`
+}
+
+type AMMInfoByAccountParam struct {
+	AMMAccount  string `json:"amm_account"`
+	LedgerIndex string `json:"ledger_index,omitempty"`
+}
+
+// AMMInfoByAccountResponse defines the fields of amm_info used for LP positions
+type AMMInfoByAccountResponse struct {
+	Result struct {
+		AMM struct {
+			Account    string      `json:"account"`
+			Amount     xrpl.Amount `json:"amount"`
+			Amount2    xrpl.Amount `json:"amount2"`
+			LPToken    xrpl.Amount `json:"lp_token"`
+			TradingFee int         `json:"trading_fee"`
+		} `json:"amm"`
+		Error        string `json:"error,omitempty"`
+		ErrorMessage string `json:"error_message,omitempty"`
+	} `json:"result"`
+}
+
+// AccountReserves breaks down the XRP reserve of an account, in drops
+type AccountReserves struct {
+	Account      string `json:"account"`
+	LedgerIndex  int    `json:"ledger_index"`
+	Balance      string `json:"balance"`
+	OwnerCount   int    `json:"owner_count"`
+	BaseReserve  string `json:"base_reserve"`
+	OwnerReserve string `json:"owner_reserve"`
+	Reserve      string `json:"reserve"`
+	Spendable    string `json:"spendable"`
+	// ReserveSource tells where reserve_base/reserve_inc came from: "ledger" (stream or FeeSettings) or "server_state"
+	ReserveSource    string          `json:"reserve_source"`
+	ReserveIncrement string          `json:"reserve_increment"`
+	Objects          []ObjectReserve `json:"objects"`
+	// Unattributed is the part of OwnerCount not matched to any listed object (e.g. objects not returned by account_objects)
+	Unattributed int `json:"unattributed"`
+}
+
+// ObjectReserve is the owner reserve held by the objects of one ledger entry type
+type ObjectReserve struct {
+	Type       string `json:"type"`
+	Count      int    `json:"count"`
+	OwnerCount int    `json:"owner_count"`
+	Reserve    string `json:"reserve"`
+}
@@ -0,0 +1,240 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DefaultWatchlist holds the accounts subscribed through POST /accounts/subscribe
+const DefaultWatchlist = "default"
+
+// ErrWatchlistExists is returned when creating a watchlist with a name already in use
+var ErrWatchlistExists = errors.New("watchlist already exists")
+
+// watchlistMu serializes watchlist changes so the stored lists and the upstream subscriptions agree
+var watchlistMu sync.Mutex
+
+// CreateWatchlist stores a new active watchlist and subscribes its accounts
+func CreateWatchlist(wsClient *xrpl.WebSocketClient, name string, accounts []string) (*WatchlistSchema, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	watchlistMu.Lock()
+	defer watchlistMu.Unlock()
+
+	now := time.Now()
+	watchlist := &WatchlistSchema{
+		Name:      name,
+		Accounts:  uniqueAccounts(accounts),
+		Status:    WatchlistActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := database.GetWatchlistCollection().InsertOne(ctx, watchlist); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrWatchlistExists
+		}
+		return nil, err
+	}
+
+	if err := FollowAccounts(wsClient, watchlistOwner(name), watchlist.Accounts); err != nil {
+		return nil, err
+	}
+	log.Printf("👀 Watchlist %s criada com %d contas", name, len(watchlist.Accounts))
+	return watchlist, nil
+}
+
+// AddWatchlistAccounts adds accounts to a watchlist, subscribing them while it is active
+func AddWatchlistAccounts(wsClient *xrpl.WebSocketClient, name string, accounts []string) (*WatchlistSchema, error) {
+	return updateWatchlist(wsClient, name, func(watchlist *WatchlistSchema) ([]string, []string) {
+		added := []string{}
+		for _, account := range uniqueAccounts(accounts) {
+			if !contains(watchlist.Accounts, account) {
+				added = append(added, account)
+			}
+		}
+		watchlist.Accounts = append(watchlist.Accounts, added...)
+		return added, nil
+	})
+}
+
+// RemoveWatchlistAccounts removes accounts from a watchlist and releases their subscriptions
+func RemoveWatchlistAccounts(wsClient *xrpl.WebSocketClient, name string, accounts []string) (*WatchlistSchema, error) {
+	return updateWatchlist(wsClient, name, func(watchlist *WatchlistSchema) ([]string, []string) {
+		kept := []string{}
+		removed := []string{}
+		for _, account := range watchlist.Accounts {
+			if contains(accounts, account) {
+				removed = append(removed, account)
+			} else {
+				kept = append(kept, account)
+			}
+		}
+		watchlist.Accounts = kept
+		return nil, removed
+	})
+}
+
+// PauseWatchlist releases the subscriptions of a watchlist but keeps it stored
+func PauseWatchlist(wsClient *xrpl.WebSocketClient, name string) (*WatchlistSchema, error) {
+	return updateWatchlist(wsClient, name, func(watchlist *WatchlistSchema) ([]string, []string) {
+		if watchlist.Status == WatchlistPaused {
+			return nil, nil
+		}
+		watchlist.Status = WatchlistPaused
+		return nil, watchlist.Accounts
+	})
+}
+
+// ResumeWatchlist subscribes the accounts of a paused watchlist again
+func ResumeWatchlist(wsClient *xrpl.WebSocketClient, name string) (*WatchlistSchema, error) {
+	return updateWatchlist(wsClient, name, func(watchlist *WatchlistSchema) ([]string, []string) {
+		if watchlist.Status == WatchlistActive {
+			return nil, nil
+		}
+		watchlist.Status = WatchlistActive
+		return watchlist.Accounts, nil
+	})
+}
+
+// updateWatchlist applies a change to a stored watchlist. The change returns the accounts to
+// follow and to unfollow, which only take effect upstream while the watchlist is active
+func updateWatchlist(wsClient *xrpl.WebSocketClient, name string, change func(*WatchlistSchema) ([]string, []string)) (*WatchlistSchema, error) {
+	watchlistMu.Lock()
+	defer watchlistMu.Unlock()
+
+	watchlist, err := GetWatchlist(name)
+	if err != nil {
+		return nil, err
+	}
+
+	wasActive := watchlist.Status == WatchlistActive
+	follow, unfollow := change(watchlist)
+	watchlist.UpdatedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := database.GetWatchlistCollection().ReplaceOne(ctx, bson.M{"name": name}, watchlist); err != nil {
+		return nil, err
+	}
+
+	owner := watchlistOwner(name)
+	if len(unfollow) > 0 && wasActive {
+		if err := UnfollowAccounts(wsClient, owner, unfollow); err != nil {
+			return nil, err
+		}
+	}
+	if len(follow) > 0 && watchlist.Status == WatchlistActive {
+		if err := FollowAccounts(wsClient, owner, follow); err != nil {
+			return nil, err
+		}
+	}
+	return watchlist, nil
+}
+
+// DeleteWatchlist removes a watchlist and releases its subscriptions
+func DeleteWatchlist(wsClient *xrpl.WebSocketClient, name string) error {
+	watchlistMu.Lock()
+	defer watchlistMu.Unlock()
+
+	watchlist, err := GetWatchlist(name)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := database.GetWatchlistCollection().DeleteOne(ctx, bson.M{"name": name}); err != nil {
+		return err
+	}
+
+	if watchlist.Status == WatchlistActive {
+		return UnfollowAccounts(wsClient, watchlistOwner(name), watchlist.Accounts)
+	}
+	return nil
+}
+
+// RestoreWatchlists subscribes the accounts of every active watchlist after a restart
+func RestoreWatchlists(wsClient *xrpl.WebSocketClient) error {
+	watchlistMu.Lock()
+	defer watchlistMu.Unlock()
+
+	watchlists, err := GetWatchlists()
+	if err != nil {
+		return err
+	}
+
+	restored := 0
+	for _, watchlist := range watchlists {
+		if watchlist.Status != WatchlistActive || len(watchlist.Accounts) == 0 {
+			continue
+		}
+		if err := FollowAccounts(wsClient, watchlistOwner(watchlist.Name), watchlist.Accounts); err != nil {
+			log.Printf("⚠️ Não foi possível restaurar a watchlist %s: %v", watchlist.Name, err)
+			continue
+		}
+		restored++
+	}
+	log.Printf("✅ %d watchlists restauradas", restored)
+	return nil
+}
+
+// GetWatchlist returns a watchlist by name
+func GetWatchlist(name string) (*WatchlistSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var watchlist WatchlistSchema
+	if err := database.GetWatchlistCollection().FindOne(ctx, bson.M{"name": name}).Decode(&watchlist); err != nil {
+		return nil, err
+	}
+	return &watchlist, nil
+}
+
+// GetWatchlists returns every watchlist ordered by name
+func GetWatchlists() ([]WatchlistSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := database.GetWatchlistCollection().Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	watchlists := []WatchlistSchema{}
+	if err := cursor.All(ctx, &watchlists); err != nil {
+		return nil, err
+	}
+	return watchlists, nil
+}
+
+func watchlistOwner(name string) string {
+	return "watchlist:" + name
+}
+
+// uniqueAccounts removes duplicated and empty accounts
+func uniqueAccounts(accounts []string) []string {
+	result := []string{}
+	for _, account := range accounts {
+		if account != "" && !contains(result, account) {
+			result = append(result, account)
+		}
+	}
+	return result
+}
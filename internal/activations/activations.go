@@ -0,0 +1,196 @@
+package activations
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MaxChainDepth bounds the activator chain walked for an account
+const MaxChainDepth = 100
+
+// Start records activations and deletions for every validated transaction stored through Ingest or Backfill.
+// The graph covers the ledgers that were ingested (live stream, account syncs and backfills)
+func Start() {
+	transactions.RegisterStateHandler(HandleTransaction)
+}
+
+// HandleTransaction stores the accounts created and deleted by a validated transaction
+func HandleTransaction(tx *transactions.Transaction) {
+	if !tx.Validated || tx.Meta == nil || tx.Result != "tesSUCCESS" {
+		return
+	}
+
+	for _, activation := range Activations(tx) {
+		if err := save(database.GetActivationCollection(), activation); err != nil {
+			log.Printf("❌ Erro ao salvar ativação de %s: %v", activation.Account, err)
+			continue
+		}
+		log.Printf("🌱 Conta %s ativada por %s com %s drops", activation.Account, activation.Activator, activation.Amount)
+	}
+
+	if deletion := Deletion(tx); deletion != nil {
+		if err := save(database.GetAccountDeletionCollection(), deletion); err != nil {
+			log.Printf("❌ Erro ao salvar remoção da conta %s: %v", deletion.Account, err)
+			return
+		}
+		log.Printf("🪦 Conta %s removida, saldo enviado para %s", deletion.Account, deletion.Destination)
+	}
+}
+
+// Activations returns the AccountRoot entries created by a transaction. The activator is the
+// sender of the transaction (a Payment in practice; AMMCreate creates the AMM account the same way)
+func Activations(tx *transactions.Transaction) []ActivationSchema {
+	activations := []ActivationSchema{}
+	if tx.Meta == nil {
+		return activations
+	}
+
+	for _, affected := range tx.Meta.AffectedNodes {
+		nodeType, node := affected.Node()
+		if node == nil || nodeType != transactions.NodeCreated || node.LedgerEntryType != "AccountRoot" {
+			continue
+		}
+
+		account, _ := node.NewFields["Account"].(string)
+		if account == "" || account == tx.Account {
+			continue
+		}
+		balance, _ := node.NewFields["Balance"].(string)
+
+		activations = append(activations, ActivationSchema{
+			Account:         account,
+			Activator:       tx.Account,
+			Amount:          balance,
+			TxHash:          tx.Hash,
+			TransactionType: tx.TransactionType,
+			LedgerIndex:     tx.LedgerIndex,
+			Date:            tx.Date,
+			CreatedAt:       time.Now(),
+		})
+	}
+	return activations
+}
+
+// Deletion returns the account removed by an AccountDelete transaction, or nil
+func Deletion(tx *transactions.Transaction) *DeletionSchema {
+	body, ok := tx.Body.(*transactions.AccountDelete)
+	if tx.TransactionType != "AccountDelete" || !ok {
+		return nil
+	}
+
+	deletion := &DeletionSchema{
+		Account:        tx.Account,
+		Destination:    body.Destination,
+		DestinationTag: body.DestinationTag,
+		TxHash:         tx.Hash,
+		LedgerIndex:    tx.LedgerIndex,
+		Date:           tx.Date,
+		CreatedAt:      time.Now(),
+	}
+	if tx.DeliveredAmount != nil {
+		deletion.Amount = tx.DeliveredAmount.Value
+	}
+	return deletion
+}
+
+func save(collection *mongo.Collection, document interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := collection.InsertOne(ctx, document)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// GetActivation returns the latest activation of an account, or nil when it is not indexed
+func GetActivation(account string) (*ActivationSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var activation ActivationSchema
+	opts := options.FindOne().SetSort(bson.D{{Key: "ledger_index", Value: -1}})
+	err := database.GetActivationCollection().FindOne(ctx, bson.M{"account": account}, opts).Decode(&activation)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &activation, nil
+}
+
+// GetChain walks the activators of an account up to the first account whose activation is not
+// indexed (usually the genesis account or an account created before the indexed range)
+func GetChain(account string) ([]ChainLink, error) {
+	chain := []ChainLink{}
+	seen := map[string]bool{}
+
+	for current := account; current != "" && !seen[current] && len(chain) < MaxChainDepth; {
+		seen[current] = true
+
+		activation, err := GetActivation(current)
+		if err != nil {
+			return nil, err
+		}
+		deletions, err := GetDeletions(current)
+		if err != nil {
+			return nil, err
+		}
+
+		chain = append(chain, ChainLink{Account: current, Activation: activation, Deletions: deletions})
+		if activation == nil {
+			break
+		}
+		current = activation.Activator
+	}
+	return chain, nil
+}
+
+// GetActivated returns the accounts activated by an account, oldest first
+func GetActivated(account string, limit int64) ([]ActivationSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetActivationCollection().Find(ctx, bson.M{"activator": account}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	activated := []ActivationSchema{}
+	if err := cursor.All(ctx, &activated); err != nil {
+		return nil, err
+	}
+	return activated, nil
+}
+
+// GetDeletions returns the AccountDelete events of an account, oldest first
+func GetDeletions(account string) ([]DeletionSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: 1}})
+	cursor, err := database.GetAccountDeletionCollection().Find(ctx, bson.M{"account": account}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	deletions := []DeletionSchema{}
+	if err := cursor.All(ctx, &deletions); err != nil {
+		return nil, err
+	}
+	return deletions, nil
+}
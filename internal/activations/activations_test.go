@@ -0,0 +1,105 @@
+package activations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions/txtest"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+func TestActivations(t *testing.T) {
+	tests := []struct {
+		name  string
+		nodes []string
+		want  []string // account:activator:amount
+	}{
+		{
+			name: "payment funding a new account",
+			nodes: []string{
+				txtest.AccountRootNode("rFunder", "50000000", "39999988"),
+				txtest.CreatedAccountNode("rNew", "10000000"),
+			},
+			want: []string{"rNew:rFunder:10000000"},
+		},
+		{
+			name:  "payment to an existing account",
+			nodes: []string{txtest.AccountRootNode("rFunder", "50000000", "39999988"), txtest.AccountRootNode("rOld", "1", "10000001")},
+			want:  []string{},
+		},
+		{
+			name: "AMMCreate creates the pool account",
+			nodes: []string{
+				txtest.CreatedAccountNode("rAMM", "100000000"),
+				`{"CreatedNode": {"LedgerEntryType": "AMM", "LedgerIndex": "POOL", "NewFields": {"Account": "rAMM"}}}`,
+			},
+			want: []string{"rAMM:rFunder:100000000"},
+		},
+		{
+			name:  "the sender's own account root is not an activation",
+			nodes: []string{txtest.CreatedAccountNode("rFunder", "10000000")},
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &transactions.Transaction{Hash: "H", TransactionType: "Payment", Account: "rFunder", LedgerIndex: 100,
+				Meta: txtest.Meta(t, tt.nodes...)}
+
+			got := []string{}
+			for _, activation := range Activations(tx) {
+				if activation.TxHash != "H" || activation.LedgerIndex != 100 {
+					t.Errorf("activation envelope = %+v", activation)
+				}
+				got = append(got, activation.Account+":"+activation.Activator+":"+activation.Amount)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("activations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeletion(t *testing.T) {
+	tag := uint32(42)
+
+	tests := []struct {
+		name string
+		tx   *transactions.Transaction
+		want *DeletionSchema
+	}{
+		{
+			name: "AccountDelete sends the balance to its destination",
+			tx: &transactions.Transaction{Hash: "H", TransactionType: "AccountDelete", Account: "rGone", LedgerIndex: 100,
+				Body:            &transactions.AccountDelete{Destination: "rHeir", DestinationTag: &tag},
+				DeliveredAmount: &xrpl.Amount{Currency: "XRP", Value: "7999990"}},
+			want: &DeletionSchema{Account: "rGone", Destination: "rHeir", DestinationTag: &tag, Amount: "7999990", TxHash: "H", LedgerIndex: 100},
+		},
+		{
+			name: "other transactions delete nothing",
+			tx: &transactions.Transaction{Hash: "H", TransactionType: "Payment", Account: "rGone",
+				Body: &transactions.Payment{Destination: "rHeir"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Deletion(tt.tx)
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("deletion = %+v, want none", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("no deletion")
+			}
+			if got.Account != tt.want.Account || got.Destination != tt.want.Destination || got.DestinationTag != tt.want.DestinationTag ||
+				got.Amount != tt.want.Amount || got.TxHash != tt.want.TxHash || got.LedgerIndex != tt.want.LedgerIndex {
+				t.Errorf("deletion = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package activations
+
+import "time"
+
+// ActivationSchema define a criação (ativação) de uma conta: quem a financiou, com quanto e em qual ledger.
+// Uma conta apagada e recriada tem uma ativação por criação
+type ActivationSchema struct {
+	Account         string `bson:"account" json:"account"`
+	Activator       string `bson:"activator" json:"activator"`
+	Amount          string `bson:"amount" json:"amount"` // Saldo inicial em drops
+	TxHash          string `bson:"tx_hash" json:"tx_hash"`
+	TransactionType string `bson:"transaction_type" json:"transaction_type"`
+	LedgerIndex     int    `bson:"ledger_index" json:"ledger_index"`
+
+	Date      time.Time `bson:"date" json:"date"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// DeletionSchema define a remoção de uma conta por AccountDelete e o destino do saldo restante
+type DeletionSchema struct {
+	Account        string  `bson:"account" json:"account"`
+	Destination    string  `bson:"destination" json:"destination"`
+	DestinationTag *uint32 `bson:"destination_tag,omitempty" json:"destination_tag,omitempty"`
+	Amount         string  `bson:"amount,omitempty" json:"amount,omitempty"` // XRP entregue ao destino, em drops
+	TxHash         string  `bson:"tx_hash" json:"tx_hash"`
+	LedgerIndex    int     `bson:"ledger_index" json:"ledger_index"`
+
+	Date      time.Time `bson:"date" json:"date"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// ChainLink is one account of an activator chain, with the activation that created it
+type ChainLink struct {
+	Account    string            `json:"account"`
+	Activation *ActivationSchema `json:"activation,omitempty"`
+	Deletions  []DeletionSchema  `json:"deletions,omitempty"`
+}
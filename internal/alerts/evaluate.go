@@ -0,0 +1,254 @@
+package alerts
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/labels"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// queueSize bounds the transactions waiting for evaluation; the ingest path never blocks on alerts
+const queueSize = 1000
+
+var queue = make(chan *transactions.Transaction, queueSize)
+
+// Start evaluates the rules on every validated transaction saved through Store: the followed
+// accounts and the ingest routes with a mongo sink. History stored through Backfill is not evaluated
+func Start(client *xrpl.HTTPClient) {
+	transactions.RegisterHandler(func(tx *transactions.Transaction) {
+		if !tx.Validated || tx.Meta == nil || tx.Result != "tesSUCCESS" {
+			return
+		}
+		select {
+		case queue <- tx:
+		default:
+			log.Printf("⚠️ Fila de alertas cheia, transação %s não avaliada", tx.Hash)
+		}
+	})
+
+	go func() {
+		for tx := range queue {
+			Evaluate(client, tx)
+		}
+	}()
+}
+
+// Transfers returns the values moved by a transaction: the delivered amount of a payment,
+// the TakerGets filled of each consumed offer and the output of each AMM swap
+func Transfers(tx *transactions.Transaction) []Transfer {
+	transfers := []Transfer{}
+	if tx.Meta == nil {
+		return transfers
+	}
+
+	if payment, ok := tx.Body.(*transactions.Payment); ok && tx.DeliveredAmount != nil {
+		transfers = append(transfers, Transfer{Kind: KindPayment, Source: tx.Account, Destination: payment.Destination, Amount: *tx.DeliveredAmount})
+	}
+
+	// The offer owner gave TakerGets to the taker: the sender of an OfferCreate, or the path of a
+	// cross-currency payment, whose output ends at the payment destination
+	taker := tx.Account
+	if payment, ok := tx.Body.(*transactions.Payment); ok {
+		taker = payment.Destination
+	}
+	for _, hop := range transactions.ConsumedOffers(tx.Meta) {
+		transfers = append(transfers, Transfer{Kind: KindOfferFill, Source: hop.Account, Destination: taker, Amount: hop.Out})
+	}
+
+	// Deposits and withdrawals also move the pool; only swaps between the two pool assets are trades
+	changes := transactions.BalanceChanges(tx.Meta)
+	for _, hop := range transactions.AMMSwaps(transactions.AMMAccounts(tx.Meta), changes) {
+		if isLPToken(hop.In.Currency) || isLPToken(hop.Out.Currency) {
+			continue
+		}
+		transfers = append(transfers, Transfer{Kind: KindAMMTrade, Source: hop.Account, Destination: taker, Amount: hop.Out})
+	}
+	return transfers
+}
+
+// Evaluate runs every active rule on the transfers of a transaction
+func Evaluate(client *xrpl.HTTPClient, tx *transactions.Transaction) {
+	transfers := Transfers(tx)
+	if len(transfers) == 0 {
+		return
+	}
+
+	rulesMu.RLock()
+	active := make([]*rule, 0, len(rules))
+	for _, loaded := range rules {
+		if !loaded.Disabled {
+			active = append(active, loaded)
+		}
+	}
+	rulesMu.RUnlock()
+
+	// The fills on the path of a payment deliver the same value to its destination: a rule that
+	// already alerted on the payment does not alert on them again
+	alerted := map[*rule]bool{}
+	_, isPayment := tx.Body.(*transactions.Payment)
+
+	for _, transfer := range transfers {
+		// Converted at most once per transfer, and only when a rule needs it
+		var xrpValue *float64
+		normalize := func() (float64, bool) {
+			if xrpValue == nil {
+				value, err := XRPValue(client, transfer.Amount)
+				if err != nil {
+					log.Printf("⚠️ Sem preço em XRP para %s: %v", transfer.Amount.Key(), err)
+					value = -1
+				}
+				xrpValue = &value
+			}
+			return *xrpValue, *xrpValue >= 0
+		}
+
+		for _, loaded := range active {
+			if isPayment && transfer.Kind != KindPayment && alerted[loaded] {
+				continue
+			}
+			value, ok := loaded.value(transfer, normalize)
+			if !ok || value < loaded.Threshold {
+				continue
+			}
+			if transfer.Kind == KindPayment {
+				alerted[loaded] = true
+			}
+
+			alert := &AlertSchema{
+				Rule:            loaded.Name,
+				Kind:            transfer.Kind,
+				TxHash:          tx.Hash,
+				TransactionType: tx.TransactionType,
+				LedgerIndex:     tx.LedgerIndex,
+				Source:          transfer.Source,
+				Destination:     transfer.Destination,
+				Amount:          transfer.Amount,
+				Value:           value,
+				Threshold:       loaded.Threshold,
+				Date:            tx.Date,
+				CreatedAt:       time.Now(),
+			}
+			if xrpValue != nil && *xrpValue >= 0 {
+				alert.XRPValue = xrpValue
+			}
+			annotated := labels.Annotate([]string{transfer.Source, transfer.Destination})
+			if label, ok := annotated[transfer.Source]; ok {
+				alert.SourceLabel = &label
+			}
+			if label, ok := annotated[transfer.Destination]; ok {
+				alert.DestinationLabel = &label
+			}
+
+			loaded.deliver(alert)
+		}
+	}
+}
+
+// value returns the amount of a transfer in the unit of the rule's threshold, or false when the rule does not apply
+func (r *rule) value(transfer Transfer, normalize func() (float64, bool)) (float64, bool) {
+	if len(r.Kinds) > 0 && !contains(r.Kinds, transfer.Kind) {
+		return 0, false
+	}
+	if len(r.Accounts) > 0 && !contains(r.Accounts, transfer.Source) && !contains(r.Accounts, transfer.Destination) {
+		return 0, false
+	}
+	if r.Currency != "" && transfer.Amount.Key() != r.Currency {
+		return 0, false
+	}
+
+	if r.NormalizeXRP {
+		return normalize()
+	}
+	return transfer.Amount.Float(), true
+}
+
+// deliver applies the dedupe window and the rate limit of the rule, then queues the alert for its notifiers
+func (r *rule) deliver(alert *AlertSchema) {
+	key := strings.Join([]string{alert.TxHash, alert.Kind, alert.Source, alert.Destination, alert.Amount.Key(), alert.Amount.Value}, "|")
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		// Replaced or deleted while the transaction was being evaluated
+		return
+	}
+	r.stats.Matched++
+	window := time.Duration(r.DedupeSeconds) * time.Second
+	for seenKey, at := range r.seen {
+		if now.Sub(at) > window {
+			delete(r.seen, seenKey)
+		}
+	}
+	if _, duplicate := r.seen[key]; duplicate {
+		r.stats.Deduped++
+		return
+	}
+	r.seen[key] = now
+
+	if now.Sub(r.windowStart) >= time.Minute {
+		r.windowStart = now
+		r.windowCount = 0
+	}
+	if r.windowCount >= r.MaxPerMinute {
+		r.stats.RateLimited++
+		return
+	}
+	r.windowCount++
+
+	// A slow notifier only delays the alerts of its own rule, never the evaluation of the feed
+	select {
+	case r.outbox <- alert:
+	default:
+		log.Printf("⚠️ Fila de envio da regra %s cheia, alerta %s descartado", r.Name, alert.TxHash)
+		r.stats.Dropped++
+	}
+}
+
+// run sends the queued alerts of the rule until it is stopped
+func (r *rule) run() {
+	for alert := range r.outbox {
+		failed := false
+		for _, notifier := range r.notifiers {
+			if err := notifier.Notify(alert); err != nil {
+				log.Printf("❌ Erro ao enviar alerta da regra %s: %v", r.Name, err)
+				failed = true
+			}
+		}
+
+		r.mu.Lock()
+		if failed {
+			r.stats.Failed++
+		} else {
+			r.stats.Sent++
+		}
+		r.mu.Unlock()
+	}
+}
+
+// stop ends the worker of the rule once the queued alerts are sent
+func (r *rule) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.stopped {
+		r.stopped = true
+		close(r.outbox)
+	}
+}
+
+// isLPToken reports whether a currency code is an AMM LP token (hex code starting with 03)
+func isLPToken(currency string) bool {
+	return len(currency) == 40 && strings.HasPrefix(currency, "03")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
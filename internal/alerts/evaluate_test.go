@@ -0,0 +1,226 @@
+package alerts
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions/txtest"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+func TestTransfers(t *testing.T) {
+	usd := xrpl.Amount{Currency: "USD", Issuer: "rGateway", Value: "10"}
+
+	tests := []struct {
+		name  string
+		tx    *transactions.Transaction
+		nodes []string
+		want  []Transfer
+	}{
+		{
+			name: "direct payment",
+			tx: &transactions.Transaction{TransactionType: "Payment", Account: "rSender",
+				Body: &transactions.Payment{Destination: "rDest", Amount: usd}, DeliveredAmount: &usd},
+			want: []Transfer{{Kind: KindPayment, Source: "rSender", Destination: "rDest", Amount: usd}},
+		},
+		{
+			name: "offer filled by an OfferCreate goes to its sender",
+			tx: &transactions.Transaction{TransactionType: "OfferCreate", Account: "rTaker",
+				Body: &transactions.OfferCreate{TakerGets: xrpl.Amount{Currency: "XRP", Value: "5000000"}, TakerPays: usd}},
+			nodes: []string{txtest.OfferNode("rMaker", txtest.IOU("USD", "rGateway", "30"), txtest.IOU("USD", "rGateway", "20"), `"15000000"`, `"10000000"`)},
+			want:  []Transfer{{Kind: KindOfferFill, Source: "rMaker", Destination: "rTaker", Amount: usd}},
+		},
+		{
+			name: "offer filled on the path of a cross-currency payment goes to the destination",
+			tx: &transactions.Transaction{TransactionType: "Payment", Account: "rSender",
+				Body: &transactions.Payment{Destination: "rDest", Amount: usd, SendMax: &xrpl.Amount{Currency: "XRP", Value: "6000000"}}},
+			nodes: []string{txtest.OfferNode("rMaker", txtest.IOU("USD", "rGateway", "30"), txtest.IOU("USD", "rGateway", "20"), `"15000000"`, `"10000000"`)},
+			want:  []Transfer{{Kind: KindOfferFill, Source: "rMaker", Destination: "rDest", Amount: usd}},
+		},
+		{
+			name: "AMM swap on the path of a payment goes to the destination",
+			tx: &transactions.Transaction{TransactionType: "Payment", Account: "rSender",
+				Body: &transactions.Payment{Destination: "rDest", Amount: usd}},
+			nodes: []string{
+				txtest.AMMNode("rAMM", "1000000000", "1005000000"),
+				txtest.RippleStateNode("rAMM", "rGateway", "USD", "100", "90"),
+			},
+			want: []Transfer{{Kind: KindAMMTrade, Source: "rAMM", Destination: "rDest", Amount: usd}},
+		},
+		{
+			name: "AMM deposit of LP tokens is not a trade",
+			tx: &transactions.Transaction{TransactionType: "AMMDeposit", Account: "rProvider",
+				Body: map[string]interface{}{}},
+			nodes: []string{
+				txtest.AMMNode("rAMM", "1000000000", "1005000000"),
+				txtest.RippleStateNode("rAMM", "rProvider", "03ABCDEF0123456789ABCDEF0123456789ABCDEF", "0", "-3"),
+			},
+			want: []Transfer{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.tx.Meta = txtest.Meta(t, tt.nodes...)
+
+			got := Transfers(tt.tx)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d transfers %+v, want %d", len(got), got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if got[i] != want {
+					t.Errorf("transfer %d = %+v, want %+v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+// blockingNotifier holds every alert until release is closed
+type blockingNotifier struct {
+	release chan struct{}
+	sent    chan *AlertSchema
+}
+
+func (n *blockingNotifier) Notify(alert *AlertSchema) error {
+	<-n.release
+	n.sent <- alert
+	return nil
+}
+
+func TestDeliver(t *testing.T) {
+	notifier := &blockingNotifier{release: make(chan struct{}), sent: make(chan *AlertSchema, 10)}
+	loaded := newRule(RuleSchema{Name: "whales", DedupeSeconds: 60, MaxPerMinute: 2}, []Notifier{notifier})
+	defer loaded.stop()
+
+	alert := func(hash string) *AlertSchema {
+		return &AlertSchema{Rule: "whales", Kind: KindPayment, TxHash: hash, Source: "rSender", Destination: "rDest",
+			Amount: xrpl.Amount{Currency: "XRP", Value: "1000000"}}
+	}
+
+	// A notifier that does not answer never blocks the evaluation
+	done := make(chan struct{})
+	go func() {
+		loaded.deliver(alert("H1"))
+		loaded.deliver(alert("H1"))
+		loaded.deliver(alert("H2"))
+		loaded.deliver(alert("H3"))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("deliver blocked on the notifier")
+	}
+
+	close(notifier.release)
+	for _, want := range []string{"H1", "H2"} {
+		select {
+		case sent := <-notifier.sent:
+			if sent.TxHash != want {
+				t.Fatalf("sent %s, want %s", sent.TxHash, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("alert %s not sent", want)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		loaded.mu.Lock()
+		stats := loaded.stats
+		loaded.mu.Unlock()
+		if stats.Sent == 2 {
+			want := RuleStats{Matched: 4, Sent: 2, Deduped: 1, RateLimited: 1}
+			if stats != want {
+				t.Fatalf("stats = %+v, want %+v", stats, want)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("stats = %+v, want 2 sent", stats)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A rule replaced or deleted ignores the transactions still being evaluated
+	loaded.stop()
+	loaded.deliver(alert("H4"))
+	loaded.mu.Lock()
+	defer loaded.mu.Unlock()
+	if loaded.stats.Matched != 4 {
+		t.Fatalf("matched = %d after stop, want 4", loaded.stats.Matched)
+	}
+}
+
+func TestDeliverDropsWhenOutboxIsFull(t *testing.T) {
+	notifier := &blockingNotifier{release: make(chan struct{}), sent: make(chan *AlertSchema, outboxSize+1)}
+	loaded := newRule(RuleSchema{Name: "flood", DedupeSeconds: 60, MaxPerMinute: outboxSize + 10}, []Notifier{notifier})
+	defer func() {
+		close(notifier.release)
+		loaded.stop()
+	}()
+
+	// One alert is held by the notifier and outboxSize wait in the queue
+	for i := 0; i < outboxSize+5; i++ {
+		loaded.deliver(&AlertSchema{TxHash: "H" + strconv.Itoa(i), Amount: xrpl.Amount{Currency: "XRP", Value: "1"}})
+		if i == 0 {
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+
+	loaded.mu.Lock()
+	defer loaded.mu.Unlock()
+	if loaded.stats.Dropped != 4 {
+		t.Fatalf("dropped = %d, want 4", loaded.stats.Dropped)
+	}
+}
+
+// recordingNotifier collects the alerts it is sent
+type recordingNotifier struct {
+	sent chan *AlertSchema
+}
+
+func (n *recordingNotifier) Notify(alert *AlertSchema) error {
+	n.sent <- alert
+	return nil
+}
+
+func TestEvaluateAlertsOncePerPayment(t *testing.T) {
+	notifier := &recordingNotifier{sent: make(chan *AlertSchema, 10)}
+	loaded := newRule(RuleSchema{Name: "usd", Threshold: 5, DedupeSeconds: 60, MaxPerMinute: 10}, []Notifier{notifier})
+	rulesMu.Lock()
+	rules = map[string]*rule{"usd": loaded}
+	rulesMu.Unlock()
+	defer func() {
+		rulesMu.Lock()
+		rules = map[string]*rule{}
+		rulesMu.Unlock()
+		loaded.stop()
+	}()
+
+	// A cross-currency payment delivered through an offer: the fill is the payment itself
+	usd := xrpl.Amount{Currency: "USD", Issuer: "rGateway", Value: "10"}
+	tx := &transactions.Transaction{Hash: "H1", TransactionType: "Payment", Account: "rSender",
+		Body:            &transactions.Payment{Destination: "rDest", Amount: usd, SendMax: &xrpl.Amount{Currency: "XRP", Value: "6000000"}},
+		DeliveredAmount: &usd}
+	tx.Meta = txtest.Meta(t, txtest.OfferNode("rMaker", txtest.IOU("USD", "rGateway", "30"), txtest.IOU("USD", "rGateway", "20"), `"15000000"`, `"10000000"`))
+
+	Evaluate(nil, tx)
+
+	select {
+	case alert := <-notifier.sent:
+		if alert.Kind != KindPayment {
+			t.Fatalf("alert kind = %s, want %s", alert.Kind, KindPayment)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("payment alert not sent")
+	}
+	select {
+	case alert := <-notifier.sent:
+		t.Fatalf("unexpected second alert %+v", alert)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
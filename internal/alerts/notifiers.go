@@ -0,0 +1,101 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/labels"
+)
+
+// Notifier receives the alerts of a rule
+type Notifier interface {
+	Notify(alert *AlertSchema) error
+}
+
+// NewNotifier builds a notifier from its configuration
+func NewNotifier(cfg NotifierConfig) (Notifier, error) {
+	switch cfg.Type {
+	case NotifierLog:
+		return LogNotifier{}, nil
+	case NotifierFeed:
+		return FeedNotifier{}, nil
+	case NotifierWebhook:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("webhook notifier requires url")
+		}
+		return &WebhookNotifier{
+			URL:     cfg.URL,
+			Headers: cfg.Headers,
+			Client:  &http.Client{Timeout: 10 * time.Second},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown notifier type %q", cfg.Type)
+}
+
+// LogNotifier writes a summary line per alert
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(alert *AlertSchema) error {
+	log.Printf("🐋 [%s] %s de %s %s: %s -> %s (%s)", alert.Rule, alert.Kind, alert.Amount.Value, alert.Amount.Key(),
+		accountName(alert.Source, alert.SourceLabel), accountName(alert.Destination, alert.DestinationLabel), alert.TxHash)
+	return nil
+}
+
+// accountName shows the label of an account next to its address when there is one
+func accountName(account string, label *labels.Label) string {
+	if label == nil {
+		return account
+	}
+	return label.Name + " (" + account + ")"
+}
+
+// FeedNotifier saves alerts in the alerts collection, read by the in-app alert feed
+type FeedNotifier struct{}
+
+func (FeedNotifier) Notify(alert *AlertSchema) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := database.GetAlertCollection().InsertOne(ctx, alert)
+	return err
+}
+
+// WebhookNotifier posts each alert as JSON to a URL
+type WebhookNotifier struct {
+	URL     string
+	Headers map[string]string
+	Client  *http.Client
+}
+
+func (n *WebhookNotifier) Notify(alert *AlertSchema) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range n.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned %s", n.URL, resp.Status)
+	}
+	return nil
+}
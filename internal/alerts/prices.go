@@ -0,0 +1,87 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/orderbook"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// neutralTaker is ACCOUNT_ONE, used so the book is not filtered by a real taker's own offers
+const neutralTaker = "rrrrrrrrrrrrrrrrrrrrBZbvji"
+
+// priceTTL is how long an XRP price read from the order book is reused
+const priceTTL = time.Minute
+
+type cachedPrice struct {
+	xrp     float64
+	fetched time.Time
+}
+
+var (
+	prices   = map[string]cachedPrice{}
+	pricesMu sync.Mutex
+)
+
+// XRPValue converts an amount to XRP using the best offer of the book that sells XRP for its asset
+func XRPValue(client *xrpl.HTTPClient, amount xrpl.Amount) (float64, error) {
+	if amount.IsXRP() {
+		return amount.Float(), nil
+	}
+	if amount.MPTIssuanceID != "" {
+		return 0, fmt.Errorf("no XRP price for MPT %s", amount.MPTIssuanceID)
+	}
+
+	price, err := xrpPrice(client, amount.Currency, amount.Issuer)
+	if err != nil {
+		return 0, err
+	}
+	return amount.Float() * price, nil
+}
+
+// xrpPrice returns the XRP received per unit of a token when selling it into the order book
+func xrpPrice(client *xrpl.HTTPClient, currency, issuer string) (float64, error) {
+	key := currency + "." + issuer
+
+	pricesMu.Lock()
+	cached, ok := prices[key]
+	pricesMu.Unlock()
+	if ok && time.Since(cached.fetched) < priceTTL {
+		return cached.xrp, nil
+	}
+
+	// Offers that give XRP and take the token: the taker sells the token
+	response, err := orderbook.FetchBookOffers(client, orderbook.BookOffersParams{
+		Taker:     neutralTaker,
+		TakerGets: orderbook.AssetParam{Currency: "XRP"},
+		TakerPays: orderbook.AssetParam{Currency: currency, Issuer: issuer},
+		Limit:     1,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var book orderbook.BookOffersResponse
+	if err := json.Unmarshal(response, &book); err != nil {
+		return 0, err
+	}
+	if len(book.Result.Offers) == 0 {
+		return 0, fmt.Errorf("no XRP order book for %s", key)
+	}
+
+	offer := book.Result.Offers[0]
+	gets, okGets := xrpl.ParseAmount(offer.TakerGets)
+	pays, okPays := xrpl.ParseAmount(offer.TakerPays)
+	if !okGets || !okPays || pays.Float() == 0 {
+		return 0, fmt.Errorf("invalid offer in the XRP order book for %s", key)
+	}
+
+	price := gets.Float() / pays.Float()
+	pricesMu.Lock()
+	prices[key] = cachedPrice{xrp: price, fetched: time.Now()}
+	pricesMu.Unlock()
+	return price, nil
+}
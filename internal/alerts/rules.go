@@ -0,0 +1,249 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrRuleNotFound is returned when no rule has the given name
+var ErrRuleNotFound = errors.New("alert rule not found")
+
+// outboxSize bounds the alerts of a rule waiting for its notifiers
+const outboxSize = 100
+
+// rule is a loaded rule with its notifiers and delivery state
+type rule struct {
+	RuleSchema
+	notifiers []Notifier
+	outbox    chan *AlertSchema
+
+	mu          sync.Mutex
+	seen        map[string]time.Time
+	windowStart time.Time
+	windowCount int
+	stopped     bool
+	stats       RuleStats
+}
+
+// newRule loads a rule and starts the worker that sends its alerts
+func newRule(schema RuleSchema, notifiers []Notifier) *rule {
+	loaded := &rule{
+		RuleSchema: schema,
+		notifiers:  notifiers,
+		outbox:     make(chan *AlertSchema, outboxSize),
+		seen:       map[string]time.Time{},
+	}
+	go loaded.run()
+	return loaded
+}
+
+var (
+	rules   = map[string]*rule{}
+	rulesMu sync.RWMutex
+)
+
+// Validate checks a rule and applies its defaults
+func Validate(schema *RuleSchema) error {
+	schema.Name = strings.TrimSpace(schema.Name)
+	if schema.Name == "" {
+		return fmt.Errorf("rule name is required")
+	}
+	if schema.Threshold <= 0 {
+		return fmt.Errorf("threshold must be positive")
+	}
+	if schema.Currency == "" && !schema.NormalizeXRP {
+		return fmt.Errorf("either currency or normalize_xrp is required")
+	}
+	for _, kind := range schema.Kinds {
+		if kind != KindPayment && kind != KindOfferFill && kind != KindAMMTrade {
+			return fmt.Errorf("unknown kind %q", kind)
+		}
+	}
+	if len(schema.Notifiers) == 0 {
+		schema.Notifiers = []NotifierConfig{{Type: NotifierLog}, {Type: NotifierFeed}}
+	}
+	for _, cfg := range schema.Notifiers {
+		if _, err := NewNotifier(cfg); err != nil {
+			return err
+		}
+	}
+	if schema.DedupeSeconds <= 0 {
+		schema.DedupeSeconds = int(DefaultDedupeWindow / time.Second)
+	}
+	if schema.MaxPerMinute <= 0 {
+		schema.MaxPerMinute = DefaultMaxPerMinute
+	}
+	return nil
+}
+
+// SaveRule creates or replaces a rule and activates it
+func SaveRule(schema RuleSchema) (*RuleSchema, error) {
+	if err := Validate(&schema); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	schema.UpdatedAt = now
+	update := bson.M{
+		"$set": bson.M{
+			"kinds":          schema.Kinds,
+			"currency":       schema.Currency,
+			"normalize_xrp":  schema.NormalizeXRP,
+			"threshold":      schema.Threshold,
+			"accounts":       schema.Accounts,
+			"notifiers":      schema.Notifiers,
+			"dedupe_seconds": schema.DedupeSeconds,
+			"max_per_minute": schema.MaxPerMinute,
+			"disabled":       schema.Disabled,
+			"updated_at":     now,
+		},
+		"$setOnInsert": bson.M{"name": schema.Name, "created_at": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved RuleSchema
+	if err := database.GetAlertRuleCollection().FindOneAndUpdate(ctx, bson.M{"name": schema.Name}, update, opts).Decode(&saved); err != nil {
+		return nil, err
+	}
+
+	if err := activate(saved); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// DeleteRule removes a rule
+func DeleteRule(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := database.GetAlertRuleCollection().DeleteOne(ctx, bson.M{"name": name})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrRuleNotFound
+	}
+
+	rulesMu.Lock()
+	if loaded, ok := rules[name]; ok {
+		loaded.stop()
+		delete(rules, name)
+	}
+	rulesMu.Unlock()
+	return nil
+}
+
+// LoadRules activates the saved rules
+func LoadRules() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := database.GetAlertRuleCollection().Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+
+	saved := []RuleSchema{}
+	if err := cursor.All(ctx, &saved); err != nil {
+		return err
+	}
+	for _, schema := range saved {
+		if err := activate(schema); err != nil {
+			log.Printf("⚠️ Regra de alerta %s ignorada: %v", schema.Name, err)
+		}
+	}
+	log.Printf("🔔 %d regras de alerta carregadas", len(saved))
+	return nil
+}
+
+func activate(schema RuleSchema) error {
+	notifiers := []Notifier{}
+	for _, cfg := range schema.Notifiers {
+		notifier, err := NewNotifier(cfg)
+		if err != nil {
+			return err
+		}
+		notifiers = append(notifiers, notifier)
+	}
+	loaded := newRule(schema, notifiers)
+
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	if previous, ok := rules[schema.Name]; ok {
+		// Keep the counters of the rule being replaced
+		previous.mu.Lock()
+		loaded.stats = previous.stats
+		previous.mu.Unlock()
+		previous.stop()
+	}
+	rules[schema.Name] = loaded
+	return nil
+}
+
+// RuleStatus is a rule with its counters
+type RuleStatus struct {
+	RuleSchema
+	Stats RuleStats `json:"stats"`
+}
+
+// GetRules returns the active rules and their counters
+func GetRules() []RuleStatus {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	statuses := make([]RuleStatus, 0, len(rules))
+	for _, loaded := range rules {
+		loaded.mu.Lock()
+		statuses = append(statuses, RuleStatus{RuleSchema: loaded.RuleSchema, Stats: loaded.stats})
+		loaded.mu.Unlock()
+	}
+	return statuses
+}
+
+// GetAlerts returns the alert feed, newest first, optionally for one rule and after a date
+func GetAlerts(ruleName string, since time.Time, limit int64) ([]AlertSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if ruleName != "" {
+		filter["rule"] = ruleName
+	}
+	if !since.IsZero() {
+		filter["created_at"] = bson.M{"$gt": since}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetAlertCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := []AlertSchema{}
+	if err := cursor.All(ctx, &alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// IsNotFound reports whether err means the rule does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrRuleNotFound) || errors.Is(err, mongo.ErrNoDocuments)
+}
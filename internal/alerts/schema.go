@@ -0,0 +1,93 @@
+package alerts
+
+import (
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/labels"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// Kinds of transfers evaluated by the rules
+const (
+	KindPayment   = "payment"
+	KindOfferFill = "offer_fill"
+	KindAMMTrade  = "amm_trade"
+)
+
+// Notifier types
+const (
+	NotifierLog     = "log"
+	NotifierWebhook = "webhook"
+	NotifierFeed    = "feed"
+)
+
+// Defaults of a rule
+const (
+	DefaultDedupeWindow = 10 * time.Minute
+	DefaultMaxPerMinute = 30
+)
+
+// RuleSchema define uma regra de alerta de transferências grandes.
+// Com Currency, Threshold está na moeda (XRP em XRP, não drops); com NormalizeXRP, em XRP equivalente
+type RuleSchema struct {
+	Name         string           `bson:"name" json:"name"`
+	Kinds        []string         `bson:"kinds,omitempty" json:"kinds,omitempty"`       // Vazio: todos
+	Currency     string           `bson:"currency,omitempty" json:"currency,omitempty"` // "XRP" ou "USD.rIssuer"
+	NormalizeXRP bool             `bson:"normalize_xrp" json:"normalize_xrp"`
+	Threshold    float64          `bson:"threshold" json:"threshold"`
+	Accounts     []string         `bson:"accounts,omitempty" json:"accounts,omitempty"` // Origem ou destino
+	Notifiers    []NotifierConfig `bson:"notifiers" json:"notifiers"`
+
+	// DedupeSeconds suppresses repeated alerts for the same transfer; MaxPerMinute caps alerts per rule
+	DedupeSeconds int `bson:"dedupe_seconds,omitempty" json:"dedupe_seconds,omitempty"`
+	MaxPerMinute  int `bson:"max_per_minute,omitempty" json:"max_per_minute,omitempty"`
+
+	Disabled  bool      `bson:"disabled" json:"disabled"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// NotifierConfig defines where the alerts of a rule are sent
+type NotifierConfig struct {
+	Type    string            `bson:"type" json:"type"`
+	URL     string            `bson:"url,omitempty" json:"url,omitempty"`
+	Headers map[string]string `bson:"headers,omitempty" json:"headers,omitempty"`
+}
+
+// Transfer is a value moved by a transaction: a delivered payment, an offer filled or an AMM swap
+type Transfer struct {
+	Kind        string
+	Source      string
+	Destination string
+	Amount      xrpl.Amount
+}
+
+// AlertSchema define um alerta disparado por uma regra
+type AlertSchema struct {
+	Rule             string        `bson:"rule" json:"rule"`
+	Kind             string        `bson:"kind" json:"kind"`
+	TxHash           string        `bson:"tx_hash" json:"tx_hash"`
+	TransactionType  string        `bson:"transaction_type" json:"transaction_type"`
+	LedgerIndex      int           `bson:"ledger_index" json:"ledger_index"`
+	Source           string        `bson:"source" json:"source"`
+	SourceLabel      *labels.Label `bson:"source_label,omitempty" json:"source_label,omitempty"`
+	Destination      string        `bson:"destination" json:"destination"`
+	DestinationLabel *labels.Label `bson:"destination_label,omitempty" json:"destination_label,omitempty"`
+	Amount           xrpl.Amount   `bson:"amount" json:"amount"` // Valor entregue
+	Value            float64       `bson:"value" json:"value"`   // Valor comparado ao limite
+	XRPValue         *float64      `bson:"xrp_value,omitempty" json:"xrp_value,omitempty"`
+	Threshold        float64       `bson:"threshold" json:"threshold"`
+
+	Date      time.Time `bson:"date" json:"date"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// RuleStats counts what a rule matched, sent and suppressed since the service started
+type RuleStats struct {
+	Matched     int64 `json:"matched"`
+	Sent        int64 `json:"sent"`
+	Deduped     int64 `json:"deduped"`
+	RateLimited int64 `json:"rate_limited"`
+	Failed      int64 `json:"failed"`
+	Dropped     int64 `json:"dropped"`
+}
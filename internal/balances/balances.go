@@ -0,0 +1,294 @@
+package balances
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/accounts"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrNotSynced is returned for accounts without a completed sync, whose balance history is partial
+var ErrNotSynced = errors.New("balance history requires a completed account sync")
+
+// Start records balance events for every validated transaction stored through Ingest or Backfill.
+// Only synced accounts are recorded: their whole history goes through Backfill before the live feed
+func Start() {
+	transactions.RegisterStateHandler(HandleTransaction)
+}
+
+// HandleTransaction derives and stores the balance events of the synced accounts touched by a
+// validated transaction
+func HandleTransaction(tx *transactions.Transaction) {
+	if !tx.Validated || tx.Meta == nil {
+		return
+	}
+
+	events := Events(tx)
+	if len(events) == 0 {
+		return
+	}
+	synced, err := syncedAccounts(events)
+	if err != nil {
+		log.Printf("❌ Erro ao buscar contas sincronizadas da transação %s: %v", tx.Hash, err)
+		return
+	}
+
+	kept := []BalanceEventSchema{}
+	for _, event := range events {
+		if synced[event.Account] {
+			kept = append(kept, event)
+		}
+	}
+	if err := saveEvents(kept); err != nil {
+		log.Printf("❌ Erro ao salvar eventos de saldo da transação %s: %v", tx.Hash, err)
+	}
+}
+
+// syncedAccounts returns the accounts of the events that are being synced or were synced
+func syncedAccounts(events []BalanceEventSchema) (map[string]bool, error) {
+	candidates := []string{}
+	for _, event := range events {
+		candidates = append(candidates, event.Account)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"account": bson.M{"$in": candidates}, "status": bson.M{"$in": []string{accounts.SyncStatusRunning, accounts.SyncStatusLive}}}
+	values, err := database.GetAccountSyncCollection().Distinct(ctx, "account", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	synced := map[string]bool{}
+	for _, value := range values {
+		if account, ok := value.(string); ok {
+			synced[account] = true
+		}
+	}
+	return synced, nil
+}
+
+// checkSynced refuses accounts whose history has not been synced up to the live feed
+func checkSynced(account string) error {
+	progress, err := accounts.GetAccountSync(account)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrNotSynced
+	}
+	if err != nil {
+		return err
+	}
+	if progress.Status != accounts.SyncStatusLive {
+		return ErrNotSynced
+	}
+	return nil
+}
+
+// Events turns the AccountRoot and RippleState diffs of a transaction into balance events.
+// The fee is recorded as its own event, so the sender's XRP delta is split in two
+func Events(tx *transactions.Transaction) []BalanceEventSchema {
+	events := []BalanceEventSchema{}
+	if tx.Meta == nil {
+		return events
+	}
+
+	amm := transactions.AMMAccounts(tx.Meta)
+	owners := offerOwners(tx.Meta)
+	now := time.Now()
+
+	add := func(change transactions.BalanceChange, delta string, balance string, cause string) {
+		events = append(events, BalanceEventSchema{
+			Account:         change.Account,
+			Asset:           xrpl.Issue{Currency: change.Currency, Issuer: change.Issuer}.Key(),
+			Currency:        change.Currency,
+			Issuer:          change.Issuer,
+			Delta:           delta,
+			Balance:         balance,
+			Cause:           cause,
+			TxHash:          tx.Hash,
+			TransactionType: tx.TransactionType,
+			LedgerIndex:     tx.LedgerIndex,
+			TxIndex:         tx.Meta.TransactionIndex,
+			Seq:             len(events),
+			Date:            tx.Date,
+			CreatedAt:       now,
+		})
+	}
+
+	for _, change := range transactions.BalanceChanges(tx.Meta) {
+		if change.Account == tx.Account && change.Currency == "XRP" && xrpl.ParseValue(tx.Fee).Sign() > 0 {
+			// Balance before the transaction minus the fee
+			afterFee := xrpl.SubtractValues(xrpl.SubtractValues(change.Balance, change.Value), tx.Fee)
+			add(change, xrpl.NegateValue(tx.Fee), afterFee, CauseFee)
+
+			rest := xrpl.AddValues(change.Value, tx.Fee)
+			if rest != "0" {
+				add(change, rest, change.Balance, cause(tx, change.Account, amm, owners))
+			}
+			continue
+		}
+		add(change, change.Value, change.Balance, cause(tx, change.Account, amm, owners))
+	}
+	return events
+}
+
+// cause classifies why the balance of an account changed in a transaction
+func cause(tx *transactions.Transaction, account string, amm map[string]bool, owners map[string]bool) string {
+	if amm[account] || (strings.HasPrefix(tx.TransactionType, "AMM") && account == tx.Account) {
+		return CauseAMM
+	}
+
+	switch body := tx.Body.(type) {
+	case *transactions.Payment:
+		switch {
+		case account == tx.Account || account == body.Destination:
+			return CausePayment
+		case owners[account]:
+			return CauseOffer
+		}
+		return CauseRippling
+	}
+
+	if tx.TransactionType == "OfferCreate" || tx.TransactionType == "OfferCancel" {
+		if account == tx.Account || owners[account] {
+			return CauseOffer
+		}
+		return CauseRippling
+	}
+	return CauseOther
+}
+
+// offerOwners returns the owners of the offers touched by a transaction
+func offerOwners(meta *transactions.TransactionMeta) map[string]bool {
+	owners := map[string]bool{}
+	for _, affected := range meta.AffectedNodes {
+		_, node := affected.Node()
+		if node == nil || node.LedgerEntryType != "Offer" {
+			continue
+		}
+		if owner, ok := node.Fields()["Account"].(string); ok {
+			owners[owner] = true
+		}
+	}
+	return owners
+}
+
+// saveEvents appends balance events, ignoring the ones already stored for the transaction
+func saveEvents(events []BalanceEventSchema) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(events))
+	for i := range events {
+		documents[i] = events[i]
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := database.GetBalanceEventCollection().InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	return nil
+}
+
+// GetHistory returns the balance events of a synced account in ledger order, optionally for one
+// asset ("XRP" or "CUR.issuer") and a ledger range (0 means unbounded)
+func GetHistory(account string, asset string, fromLedger int, toLedger int, limit int64) ([]BalanceEventSchema, error) {
+	if err := checkSynced(account); err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"account": account}
+	if asset != "" {
+		filter["asset"] = asset
+	}
+	ledgerRange := bson.M{}
+	if fromLedger > 0 {
+		ledgerRange["$gte"] = fromLedger
+	}
+	if toLedger > 0 {
+		ledgerRange["$lte"] = toLedger
+	}
+	if len(ledgerRange) > 0 {
+		filter["ledger_index"] = ledgerRange
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: 1}, {Key: "tx_index", Value: 1}, {Key: "seq", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetBalanceEventCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []BalanceEventSchema{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetBalancesAt returns the balance of a synced account in every asset as of a ledger index
+func GetBalancesAt(account string, ledgerIndex int) ([]Balance, error) {
+	if err := checkSynced(account); err != nil {
+		return nil, err
+	}
+	return balancesAt(bson.M{"account": account, "ledger_index": bson.M{"$lte": ledgerIndex}})
+}
+
+// GetBalancesAtTime returns the balance of a synced account in every asset as of a point in time
+func GetBalancesAtTime(account string, at time.Time) ([]Balance, error) {
+	if err := checkSynced(account); err != nil {
+		return nil, err
+	}
+	return balancesAt(bson.M{"account": account, "date": bson.M{"$lte": at}})
+}
+
+// balancesAt keeps the latest event of each asset among the events matching the filter
+func balancesAt(match bson.M) ([]Balance, error) {
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$sort": bson.D{{Key: "ledger_index", Value: -1}, {Key: "tx_index", Value: -1}, {Key: "seq", Value: -1}}},
+		{"$group": bson.M{
+			"_id":          "$asset",
+			"currency":     bson.M{"$first": "$currency"},
+			"issuer":       bson.M{"$first": "$issuer"},
+			"balance":      bson.M{"$first": "$balance"},
+			"ledger_index": bson.M{"$first": "$ledger_index"},
+			"tx_hash":      bson.M{"$first": "$tx_hash"},
+			"date":         bson.M{"$first": "$date"},
+		}},
+		{"$sort": bson.D{{Key: "_id", Value: 1}}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := database.GetBalanceEventCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := []Balance{}
+	if err := cursor.All(ctx, &balances); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
@@ -0,0 +1,111 @@
+package balances
+
+import (
+	"testing"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions/txtest"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+func TestEvents(t *testing.T) {
+	usd := xrpl.Amount{Currency: "USD", Issuer: "rGateway", Value: "10"}
+
+	tests := []struct {
+		name  string
+		tx    *transactions.Transaction
+		nodes []string
+		want  []string // account:asset:delta:balance:cause
+	}{
+		{
+			name: "XRP payment splits the fee from the sender's delta",
+			tx: &transactions.Transaction{TransactionType: "Payment", Account: "rSender", Fee: "12",
+				Body: &transactions.Payment{Destination: "rDest"}},
+			nodes: []string{
+				txtest.AccountRootNode("rSender", "50000000", "39999988"),
+				txtest.AccountRootNode("rDest", "1000000", "11000000"),
+			},
+			want: []string{
+				"rSender:XRP:-12:49999988:fee",
+				"rSender:XRP:-10000000:39999988:payment",
+				"rDest:XRP:10000000:11000000:payment",
+			},
+		},
+		{
+			name: "transaction that only burns the fee",
+			tx:   &transactions.Transaction{TransactionType: "AccountSet", Account: "rSender", Fee: "12", Body: map[string]interface{}{}},
+			nodes: []string{
+				txtest.AccountRootNode("rSender", "50000000", "49999988"),
+			},
+			want: []string{"rSender:XRP:-12:49999988:fee"},
+		},
+		{
+			name: "cross-currency payment through an offer and an intermediary trust line",
+			tx: &transactions.Transaction{TransactionType: "Payment", Account: "rSender",
+				Body: &transactions.Payment{Destination: "rDest", Amount: usd}},
+			nodes: []string{
+				txtest.OfferNode("rMaker", txtest.IOU("USD", "rGateway", "30"), txtest.IOU("USD", "rGateway", "20"), `"15000000"`, `"10000000"`),
+				txtest.AccountRootNode("rMaker", "1000000", "6000000"),
+				txtest.RippleStateNode("rHop", "rGateway", "USD", "5", "4"),
+			},
+			want: []string{
+				"rMaker:XRP:5000000:6000000:offer",
+				"rHop:USD.rGateway:-1:4:rippling",
+				"rGateway:USD.rHop:1:-4:rippling",
+			},
+		},
+		{
+			name: "AMM pool and the account trading with it",
+			tx:   &transactions.Transaction{TransactionType: "AMMDeposit", Account: "rProvider", Body: map[string]interface{}{}},
+			nodes: []string{
+				txtest.AMMNode("rAMM", "1000000000", "1005000000"),
+				txtest.AccountRootNode("rProvider", "20000000", "15000000"),
+			},
+			want: []string{
+				"rAMM:XRP:5000000:1005000000:amm",
+				"rProvider:XRP:-5000000:15000000:amm",
+			},
+		},
+		{
+			name: "offer placed by the sender",
+			tx:   &transactions.Transaction{TransactionType: "OfferCreate", Account: "rTaker", Body: &transactions.OfferCreate{}},
+			nodes: []string{
+				txtest.AccountRootNode("rTaker", "20000000", "15000000"),
+				txtest.AccountRootNode("rOther", "1", "2"),
+			},
+			want: []string{
+				"rTaker:XRP:-5000000:15000000:offer",
+				"rOther:XRP:1:2:rippling",
+			},
+		},
+		{
+			name: "other transaction types",
+			tx:   &transactions.Transaction{TransactionType: "EscrowFinish", Account: "rFinisher", Body: map[string]interface{}{}},
+			nodes: []string{
+				txtest.AccountRootNode("rOwner", "1000000", "3000000"),
+			},
+			want: []string{"rOwner:XRP:2000000:3000000:other"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.tx.Meta = txtest.Meta(t, tt.nodes...)
+
+			events := Events(tt.tx)
+			if len(events) != len(tt.want) {
+				t.Fatalf("got %d events %+v, want %d", len(events), events, len(tt.want))
+			}
+			for i, want := range tt.want {
+				event := events[i]
+				got := event.Account + ":" + event.Asset + ":" + event.Delta + ":" + event.Balance + ":" + event.Cause
+				if got != want {
+					t.Errorf("event %d = %s, want %s", i, got, want)
+				}
+				if event.Seq != i {
+					t.Errorf("event %d seq = %d", i, event.Seq)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package balances
+
+import "time"
+
+// Causes of a balance event
+const (
+	CauseFee      = "fee"
+	CausePayment  = "payment"
+	CauseOffer    = "offer"
+	CauseAMM      = "amm"
+	CauseRippling = "rippling"
+	CauseOther    = "other"
+)
+
+// BalanceEventSchema define uma alteração de saldo de uma conta em um ativo, derivada do metadata.
+// Delta e Balance estão em drops para XRP; para tokens, do ponto de vista da conta
+type BalanceEventSchema struct {
+	Account  string `bson:"account" json:"account"`
+	Asset    string `bson:"asset" json:"asset"`
+	Currency string `bson:"currency" json:"currency"`
+	Issuer   string `bson:"issuer,omitempty" json:"issuer,omitempty"`
+	Delta    string `bson:"delta" json:"delta"`
+	Balance  string `bson:"balance" json:"balance"`
+	Cause    string `bson:"cause" json:"cause"`
+
+	TxHash          string `bson:"tx_hash" json:"tx_hash"`
+	TransactionType string `bson:"transaction_type" json:"transaction_type"`
+	LedgerIndex     int    `bson:"ledger_index" json:"ledger_index"`
+	// TxIndex and Seq order the events of a ledger and of a transaction
+	TxIndex   int       `bson:"tx_index" json:"tx_index"`
+	Seq       int       `bson:"seq" json:"seq"`
+	Date      time.Time `bson:"date" json:"date"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// Balance is the balance of an account in one asset as of a ledger
+type Balance struct {
+	Asset       string    `bson:"_id" json:"asset"`
+	Currency    string    `bson:"currency" json:"currency"`
+	Issuer      string    `bson:"issuer,omitempty" json:"issuer,omitempty"`
+	Balance     string    `bson:"balance" json:"balance"`
+	LedgerIndex int       `bson:"ledger_index" json:"ledger_index"`
+	TxHash      string    `bson:"tx_hash" json:"tx_hash"`
+	Date        time.Time `bson:"date" json:"date"`
+}
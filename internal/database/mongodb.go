@@ -47,6 +47,111 @@ func GetTransactionCollection() *mongo.Collection {
 	return Client.Database("xrpl").Collection("transactions")
 }
 
+// GetAmendmentCollection retorna a coleção de eventos de amendments
+func GetAmendmentCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("amendments")
+}
+
+// GetFeeSettingsCollection retorna a coleção de mudanças de fee/reserve
+func GetFeeSettingsCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("fee_settings")
+}
+
+// GetValidationCollection retorna a coleção de validações recebidas
+func GetValidationCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("validations")
+}
+
+// GetManifestCollection retorna a coleção de manifests de validadores
+func GetManifestCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("manifests")
+}
+
+// GetStateChangeCollection retorna a coleção de mudanças de estado extraídas do metadata
+func GetStateChangeCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("state_changes")
+}
+
+// GetAccountSyncCollection retorna a coleção de progresso da sincronização de contas
+func GetAccountSyncCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("account_sync")
+}
+
+// GetTrackedTransactionCollection retorna a coleção de transações acompanhadas por hash
+func GetTrackedTransactionCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("tracked_transactions")
+}
+
+// GetDepositAccountCollection retorna a coleção de contas monitoradas para depósitos
+func GetDepositAccountCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("deposit_accounts")
+}
+
+// GetDepositCollection retorna a coleção de depósitos recebidos
+func GetDepositCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("deposits")
+}
+
+// GetInvoiceCollection retorna a coleção de cobranças (payment requests)
+func GetInvoiceCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("invoices")
+}
+
+// GetWatchlistCollection retorna a coleção de watchlists de contas
+func GetWatchlistCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("watchlists")
+}
+
+// GetBalanceEventCollection retorna a coleção de eventos de saldo (histórico de saldos por conta e ativo)
+func GetBalanceEventCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("balance_events")
+}
+
+// GetAccountSettingsCollection retorna a coleção de alterações de configurações (flags, domain, transfer rate) das contas
+func GetAccountSettingsCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("account_settings_history")
+}
+
+// GetActivationCollection retorna a coleção de ativações de contas (grafo de quem financiou cada conta)
+func GetActivationCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("activations")
+}
+
+// GetAccountDeletionCollection retorna a coleção de contas removidas por AccountDelete
+func GetAccountDeletionCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("account_deletions")
+}
+
+// GetLabelCollection retorna a coleção de rótulos (entidades) de contas
+func GetLabelCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("labels")
+}
+
+// GetAlertRuleCollection retorna a coleção de regras de alerta de transferências grandes
+func GetAlertRuleCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("alert_rules")
+}
+
+// GetAlertCollection retorna a coleção de alertas disparados (feed de alertas)
+func GetAlertCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("alerts")
+}
+
+// GetIssuerCollection retorna a coleção de emissores monitorados (obrigações via gateway_balances)
+func GetIssuerCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("issuers")
+}
+
+// GetIssuerSnapshotCollection retorna a coleção de snapshots de gateway_balances dos emissores
+func GetIssuerSnapshotCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("issuer_snapshots")
+}
+
+// GetObligationChangeCollection retorna a coleção de variações de obrigações atribuídas a transações
+func GetObligationChangeCollection() *mongo.Collection {
+	return Client.Database("xrpl").Collection("obligation_changes")
+}
+
 func CreateIndexes() error {
     collection := GetLedgerCollection()
 
@@ -70,6 +175,207 @@ func CreateIndexes() error {
 				
     }
 
+    // Índices para transações normalizadas
+    _, err = GetTransactionCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "hash", Value: 1}}, Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"hash": bson.M{"$exists": true}})},
+        {Keys: bson.D{{Key: "accounts", Value: 1}, {Key: "ledger_index", Value: -1}}},
+        {Keys: bson.D{{Key: "transaction_type", Value: 1}, {Key: "ledger_index", Value: -1}}},
+        {Keys: bson.D{{Key: "body.destination", Value: 1}, {Key: "ledger_index", Value: -1}}},
+        {Keys: bson.D{{Key: "account", Value: 1}, {Key: "date", Value: -1}}},
+        {Keys: bson.D{{Key: "date", Value: -1}, {Key: "result_category", Value: 1}}},
+        {Keys: bson.D{{Key: "path_analysis.corridor", Value: 1}, {Key: "date", Value: -1}}, Options: options.Index().SetPartialFilterExpression(bson.M{"path_analysis": bson.M{"$exists": true}})},
+        {Keys: bson.D{{Key: "memos.decoded.data", Value: "text"}, {Key: "memos.decoded.type", Value: "text"}}, Options: options.Index().SetName("memos_text")},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para transactions já existem: %v", err)
+    }
+
+    // Índices para histórico de configurações da rede
+    _, err = GetAmendmentCollection().Indexes().CreateOne(context.Background(), mongo.IndexModel{
+        Keys: bson.D{{Key: "amendment", Value: 1}, {Key: "ledger_index", Value: -1}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índice para amendments já existe: %v", err)
+    }
+
+    _, err = GetFeeSettingsCollection().Indexes().CreateOne(context.Background(), mongo.IndexModel{
+        Keys: bson.D{{Key: "ledger_index", Value: -1}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índice para fee_settings já existe: %v", err)
+    }
+
+    // Índices para validações (TTL de 30 dias) e manifests
+    _, err = GetValidationCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "master_key", Value: 1}, {Key: "ledger_index", Value: -1}}},
+        {Keys: bson.D{{Key: "created_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(30 * 24 * 60 * 60)},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para validations já existem: %v", err)
+    }
+
+    _, err = GetManifestCollection().Indexes().CreateOne(context.Background(), mongo.IndexModel{
+        Keys:    bson.D{{Key: "master_key", Value: 1}},
+        Options: options.Index().SetUnique(true),
+    })
+    if err != nil {
+        log.Printf("⚠️ Índice para manifests já existe: %v", err)
+    }
+
+    // Índices para mudanças de estado por objeto, ledger e conta
+    _, err = GetStateChangeCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "tx_hash", Value: 1}, {Key: "object_key", Value: 1}}, Options: options.Index().SetUnique(true)},
+        {Keys: bson.D{{Key: "object_key", Value: 1}, {Key: "ledger_index", Value: -1}}},
+        {Keys: bson.D{{Key: "ledger_index", Value: -1}}},
+        {Keys: bson.D{{Key: "accounts", Value: 1}, {Key: "ledger_index", Value: -1}}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para state_changes já existem: %v", err)
+    }
+
+    _, err = GetAccountSyncCollection().Indexes().CreateOne(context.Background(), mongo.IndexModel{
+        Keys:    bson.D{{Key: "account", Value: 1}},
+        Options: options.Index().SetUnique(true),
+    })
+    if err != nil {
+        log.Printf("⚠️ Índice para account_sync já existe: %v", err)
+    }
+
+    _, err = GetWatchlistCollection().Indexes().CreateOne(context.Background(), mongo.IndexModel{
+        Keys:    bson.D{{Key: "name", Value: 1}},
+        Options: options.Index().SetUnique(true),
+    })
+    if err != nil {
+        log.Printf("⚠️ Índice para watchlists já existe: %v", err)
+    }
+
+    // Índices para o histórico de saldos: um evento por (transação, seq), consultas por conta e ativo
+    _, err = GetBalanceEventCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "tx_hash", Value: 1}, {Key: "seq", Value: 1}}, Options: options.Index().SetUnique(true)},
+        {Keys: bson.D{{Key: "account", Value: 1}, {Key: "asset", Value: 1}, {Key: "ledger_index", Value: -1}, {Key: "tx_index", Value: -1}, {Key: "seq", Value: -1}}},
+        {Keys: bson.D{{Key: "account", Value: 1}, {Key: "date", Value: -1}}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para balance_events já existem: %v", err)
+    }
+
+    // Índices para o histórico de configurações: uma alteração por (transação, conta)
+    _, err = GetAccountSettingsCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "tx_hash", Value: 1}, {Key: "account", Value: 1}}, Options: options.Index().SetUnique(true)},
+        {Keys: bson.D{{Key: "account", Value: 1}, {Key: "ledger_index", Value: -1}}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para account_settings_history já existem: %v", err)
+    }
+
+    // Índices para o grafo de ativações: uma ativação por (transação, conta), consultas por conta e por ativador
+    _, err = GetActivationCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "tx_hash", Value: 1}, {Key: "account", Value: 1}}, Options: options.Index().SetUnique(true)},
+        {Keys: bson.D{{Key: "account", Value: 1}, {Key: "ledger_index", Value: -1}}},
+        {Keys: bson.D{{Key: "activator", Value: 1}, {Key: "ledger_index", Value: 1}}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para activations já existem: %v", err)
+    }
+
+    _, err = GetAccountDeletionCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "tx_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+        {Keys: bson.D{{Key: "account", Value: 1}, {Key: "ledger_index", Value: 1}}},
+        {Keys: bson.D{{Key: "destination", Value: 1}}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para account_deletions já existem: %v", err)
+    }
+
+    // Índices para rótulos: um por conta, listagem por categoria
+    _, err = GetLabelCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "account", Value: 1}}, Options: options.Index().SetUnique(true)},
+        {Keys: bson.D{{Key: "category", Value: 1}, {Key: "name", Value: 1}}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para labels já existem: %v", err)
+    }
+
+    _, err = GetAlertRuleCollection().Indexes().CreateOne(context.Background(), mongo.IndexModel{
+        Keys:    bson.D{{Key: "name", Value: 1}},
+        Options: options.Index().SetUnique(true),
+    })
+    if err != nil {
+        log.Printf("⚠️ Índice para alert_rules já existe: %v", err)
+    }
+
+    // Índices para o feed de alertas (TTL de 30 dias)
+    _, err = GetAlertCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "rule", Value: 1}, {Key: "created_at", Value: -1}}},
+        {Keys: bson.D{{Key: "created_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(30 * 24 * 60 * 60)},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para alerts já existem: %v", err)
+    }
+
+    // Índices para emissores: snapshots por (emissor, ledger) e variações por (transação, emissor, moeda, contraparte)
+    _, err = GetIssuerCollection().Indexes().CreateOne(context.Background(), mongo.IndexModel{
+        Keys:    bson.D{{Key: "account", Value: 1}},
+        Options: options.Index().SetUnique(true),
+    })
+    if err != nil {
+        log.Printf("⚠️ Índice para issuers já existe: %v", err)
+    }
+
+    _, err = GetIssuerSnapshotCollection().Indexes().CreateOne(context.Background(), mongo.IndexModel{
+        Keys:    bson.D{{Key: "issuer", Value: 1}, {Key: "ledger_index", Value: 1}},
+        Options: options.Index().SetUnique(true),
+    })
+    if err != nil {
+        log.Printf("⚠️ Índice para issuer_snapshots já existe: %v", err)
+    }
+
+    _, err = GetObligationChangeCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "tx_hash", Value: 1}, {Key: "issuer", Value: 1}, {Key: "currency", Value: 1}, {Key: "holder", Value: 1}}, Options: options.Index().SetUnique(true)},
+        {Keys: bson.D{{Key: "issuer", Value: 1}, {Key: "currency", Value: 1}, {Key: "ledger_index", Value: 1}}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para obligation_changes já existem: %v", err)
+    }
+
+    _, err = GetTrackedTransactionCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+        {Keys: bson.D{{Key: "status", Value: 1}}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para tracked_transactions já existem: %v", err)
+    }
+
+    _, err = GetDepositAccountCollection().Indexes().CreateOne(context.Background(), mongo.IndexModel{
+        Keys:    bson.D{{Key: "account", Value: 1}},
+        Options: options.Index().SetUnique(true),
+    })
+    if err != nil {
+        log.Printf("⚠️ Índice para deposit_accounts já existe: %v", err)
+    }
+
+    // Índices para depósitos por hash, (conta, destination tag) e confirmação
+    _, err = GetDepositCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "tx_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+        {Keys: bson.D{{Key: "account", Value: 1}, {Key: "destination_tag", Value: 1}, {Key: "ledger_index", Value: -1}}},
+        {Keys: bson.D{{Key: "status", Value: 1}, {Key: "credit_ledger", Value: 1}}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para deposits já existem: %v", err)
+    }
+
+    // Índices para cobranças por id, destino/tag em aberto e pagamentos aplicados
+    _, err = GetInvoiceCollection().Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+        {Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+        {Keys: bson.D{{Key: "destination", Value: 1}, {Key: "destination_tag", Value: 1}, {Key: "status", Value: 1}}},
+        {Keys: bson.D{{Key: "payments.tx_hash", Value: 1}}},
+        {Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}}},
+        {Keys: bson.D{{Key: "created_at", Value: -1}}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Índices para invoices já existem: %v", err)
+    }
+
     log.Println("✅ Índices criados com sucesso!")
     return nil
 }
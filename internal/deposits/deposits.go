@@ -0,0 +1,440 @@
+package deposits
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/accounts"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/ledger"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Events sent to the callback URL of a deposit account
+const (
+	EventCredited = "deposit.credited"
+	EventReview   = "deposit.review"
+)
+
+// DepositEvent is posted to the callback URL of the receiving account
+type DepositEvent struct {
+	Event   string        `json:"event"`
+	Deposit DepositSchema `json:"deposit"`
+}
+
+var (
+	mu       sync.RWMutex
+	watched  = map[string]*DepositAccountSchema{}
+	handler  sync.Once
+	callback = &http.Client{Timeout: 10 * time.Second}
+
+	// latestLedger is the newest validated ledger seen on the ledger stream or in a transaction
+	latestLedger int64
+)
+
+// Start loads the deposit accounts, follows them through the accounts stream and credits
+// pending deposits on every validated ledger
+func Start(wsClient *xrpl.WebSocketClient) error {
+	registerHandler()
+
+	_, err := ledger.SubscribeValidatedLedgers(wsClient, func(closed *ledger.LedgerSubscribeClosedResponse) {
+		recordLedger(closed.LedgerIndex)
+		go OnValidatedLedger(closed.LedgerIndex)
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := database.GetDepositAccountCollection().Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+
+	loaded := []DepositAccountSchema{}
+	if err := cursor.All(ctx, &loaded); err != nil {
+		return err
+	}
+
+	for i := range loaded {
+		mu.Lock()
+		watched[loaded[i].Account] = &loaded[i]
+		mu.Unlock()
+
+		if err := accounts.FollowAccount(wsClient, loaded[i].Account); err != nil {
+			log.Printf("⚠️ Erro ao acompanhar a conta de depósitos %s: %v", loaded[i].Account, err)
+		}
+	}
+	log.Printf("✅ %d contas de depósito monitoradas", len(loaded))
+	return nil
+}
+
+// RegisterAccount starts monitoring deposits to an account from the current ledger on
+func RegisterAccount(client *xrpl.HTTPClient, wsClient *xrpl.WebSocketClient, account DepositAccountSchema) (*DepositAccountSchema, error) {
+	if account.Account == "" {
+		return nil, errors.New("account is required")
+	}
+	if account.Confirmations <= 0 {
+		account.Confirmations = DefaultConfirmations
+	}
+	if account.Tags == nil {
+		account.Tags = []uint32{}
+	}
+	since, err := sinceLedger(client)
+	if err != nil {
+		return nil, fmt.Errorf("fetch validated ledger: %w", err)
+	}
+	account.SinceLedger = since
+	account.CreatedAt = time.Now()
+
+	if err := saveAccount(&account); err != nil {
+		return nil, err
+	}
+	registerHandler()
+
+	mu.Lock()
+	watched[account.Account] = &account
+	mu.Unlock()
+
+	if err := accounts.FollowAccount(wsClient, account.Account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// AddTags assigns destination tags to customers of a deposit account
+func AddTags(account string, tags []uint32) (*DepositAccountSchema, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	watchedAccount, ok := watched[account]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	updated := *watchedAccount
+	updated.Tags = append([]uint32{}, watchedAccount.Tags...)
+	for _, tag := range tags {
+		if !containsTag(updated.Tags, tag) {
+			updated.Tags = append(updated.Tags, tag)
+		}
+	}
+
+	if err := saveAccount(&updated); err != nil {
+		return nil, err
+	}
+	watched[account] = &updated
+	return &updated, nil
+}
+
+// ListAccounts returns the monitored deposit accounts
+func ListAccounts() []DepositAccountSchema {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	result := make([]DepositAccountSchema, 0, len(watched))
+	for _, account := range watched {
+		result = append(result, *account)
+	}
+	return result
+}
+
+func registerHandler() {
+	handler.Do(func() {
+		transactions.RegisterHandler(HandleTransaction)
+	})
+}
+
+// HandleTransaction records validated payments received by a monitored account
+func HandleTransaction(tx *transactions.Transaction) {
+	if tx.Validated {
+		recordLedger(tx.LedgerIndex)
+	}
+
+	payment, ok := tx.Body.(*transactions.Payment)
+	if !ok || !tx.Validated || tx.Result != "tesSUCCESS" {
+		return
+	}
+
+	mu.RLock()
+	account, ok := watched[payment.Destination]
+	mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	deposit, ok := newDeposit(tx, payment, account)
+	if !ok {
+		return
+	}
+
+	inserted, err := insertDeposit(deposit)
+	if err != nil {
+		log.Printf("❌ Erro ao salvar depósito %s: %v", tx.Hash, err)
+		return
+	}
+	if inserted && deposit.Status == StatusReview {
+		log.Printf("🚩 Depósito %s para %s em revisão: %s", tx.Hash, account.Account, deposit.ReviewReason)
+		go notify(account.CallbackURL, DepositEvent{Event: EventReview, Deposit: *deposit})
+	}
+
+	OnValidatedLedger(tx.LedgerIndex)
+}
+
+// newDeposit builds the deposit of a validated payment to a monitored account. Payments before
+// the account was registered and payments the account sent to itself are not deposits
+func newDeposit(tx *transactions.Transaction, payment *transactions.Payment, account *DepositAccountSchema) (*DepositSchema, bool) {
+	if tx.LedgerIndex < account.SinceLedger || tx.Account == account.Account {
+		return nil, false
+	}
+	if tx.DeliveredAmount == nil {
+		log.Printf("⚠️ Pagamento %s para %s sem delivered_amount", tx.Hash, account.Account)
+		return nil, false
+	}
+
+	deposit := &DepositSchema{
+		TxHash:         tx.Hash,
+		Account:        account.Account,
+		DestinationTag: payment.DestinationTag,
+		From:           tx.Account,
+		Amount:         *tx.DeliveredAmount,
+		PartialPayment: tx.PartialPayment,
+		LedgerIndex:    tx.LedgerIndex,
+		CreditLedger:   tx.LedgerIndex + account.Confirmations,
+		Status:         StatusPending,
+		CreatedAt:      time.Now(),
+	}
+	switch {
+	case payment.DestinationTag == nil:
+		deposit.Status = StatusReview
+		deposit.ReviewReason = ReviewMissingTag
+	case len(account.Tags) > 0 && !containsTag(account.Tags, *payment.DestinationTag):
+		deposit.Status = StatusReview
+		deposit.ReviewReason = ReviewUnknownTag
+	}
+	return deposit, true
+}
+
+// sinceLedger returns the ledger a new deposit account is monitored from: the newest validated
+// ledger seen, or the one rippled reports when no ledger has been seen since startup
+func sinceLedger(client *xrpl.HTTPClient) (int, error) {
+	if latest := atomic.LoadInt64(&latestLedger); latest > 0 {
+		return int(latest), nil
+	}
+
+	ledgerIndex, err := ledger.FetchValidatedLedgerIndex(client)
+	if err != nil {
+		return 0, err
+	}
+	recordLedger(ledgerIndex)
+	return ledgerIndex, nil
+}
+
+// recordLedger advances latestLedger, which anchors the SinceLedger of new deposit accounts
+func recordLedger(ledgerIndex int) {
+	for {
+		current := atomic.LoadInt64(&latestLedger)
+		if int64(ledgerIndex) <= current || atomic.CompareAndSwapInt64(&latestLedger, current, int64(ledgerIndex)) {
+			return
+		}
+	}
+}
+
+// OnValidatedLedger credits the pending deposits that reached their confirmations
+func OnValidatedLedger(ledgerIndex int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := database.GetDepositCollection().Find(ctx, confirmedFilter(ledgerIndex))
+	if err != nil {
+		log.Printf("❌ Erro ao buscar depósitos pendentes: %v", err)
+		return
+	}
+
+	confirmed := []DepositSchema{}
+	if err := cursor.All(ctx, &confirmed); err != nil {
+		log.Printf("❌ Erro ao ler depósitos pendentes: %v", err)
+		return
+	}
+
+	for i := range confirmed {
+		if _, err := credit(&confirmed[i], StatusPending); err != nil {
+			log.Printf("❌ Erro ao creditar depósito %s: %v", confirmed[i].TxHash, err)
+		}
+	}
+}
+
+// confirmedFilter matches the pending deposits whose credit ledger a validated ledger reached
+func confirmedFilter(ledgerIndex int) bson.M {
+	return bson.M{"status": StatusPending, "credit_ledger": bson.M{"$lte": ledgerIndex}}
+}
+
+// Resolve settles a deposit under review, crediting or rejecting it
+func Resolve(txHash string, approve bool) (*DepositSchema, error) {
+	deposit, err := GetDeposit(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if deposit.Status != StatusReview {
+		return nil, fmt.Errorf("deposit %s is %s, not under review", txHash, deposit.Status)
+	}
+
+	if approve {
+		if _, err := credit(deposit, StatusReview); err != nil {
+			return nil, err
+		}
+		return deposit, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = database.GetDepositCollection().UpdateOne(ctx,
+		bson.M{"tx_hash": txHash, "status": StatusReview},
+		bson.M{"$set": bson.M{"status": StatusRejected}})
+	if err != nil {
+		return nil, err
+	}
+	deposit.Status = StatusRejected
+	return deposit, nil
+}
+
+// credit moves a deposit from a status to credited, emitting the credited event exactly once
+func credit(deposit *DepositSchema, from string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	result, err := database.GetDepositCollection().UpdateOne(ctx,
+		bson.M{"tx_hash": deposit.TxHash, "status": from},
+		bson.M{"$set": bson.M{"status": StatusCredited, "credited_at": now}})
+	if err != nil || result.ModifiedCount == 0 {
+		return false, err
+	}
+
+	deposit.Status = StatusCredited
+	deposit.CreditedAt = &now
+	log.Printf("💰 Depósito creditado: %s tag=%v %s %s", deposit.Account, tagValue(deposit.DestinationTag), deposit.Amount.Value, deposit.Amount.Currency)
+
+	mu.RLock()
+	callbackURL := ""
+	if account, ok := watched[deposit.Account]; ok {
+		callbackURL = account.CallbackURL
+	}
+	mu.RUnlock()
+
+	go notify(callbackURL, DepositEvent{Event: EventCredited, Deposit: *deposit})
+	return true, nil
+}
+
+func notify(url string, event DepositEvent) {
+	if url == "" {
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	resp, err := callback.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("❌ Erro ao enviar evento %s de %s: %v", event.Event, event.Deposit.TxHash, err)
+		return
+	}
+	resp.Body.Close()
+}
+
+func insertDeposit(deposit *DepositSchema) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := database.GetDepositCollection().InsertOne(ctx, deposit)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func saveAccount(account *DepositAccountSchema) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := database.GetDepositAccountCollection().ReplaceOne(ctx, bson.M{"account": account.Account}, account, options.Replace().SetUpsert(true))
+	return err
+}
+
+// GetDeposit returns a deposit by transaction hash
+func GetDeposit(txHash string) (*DepositSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var deposit DepositSchema
+	if err := database.GetDepositCollection().FindOne(ctx, bson.M{"tx_hash": txHash}).Decode(&deposit); err != nil {
+		return nil, err
+	}
+	return &deposit, nil
+}
+
+// GetDeposits returns the deposits of an account, optionally filtered by status and destination tag, newest first
+func GetDeposits(account string, status string, tag *uint32, limit int64) ([]DepositSchema, error) {
+	filter := bson.M{}
+	if account != "" {
+		filter["account"] = account
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+	if tag != nil {
+		filter["destination_tag"] = *tag
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetDepositCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	deposits := []DepositSchema{}
+	if err := cursor.All(ctx, &deposits); err != nil {
+		return nil, err
+	}
+	return deposits, nil
+}
+
+func containsTag(tags []uint32, tag uint32) bool {
+	for _, candidate := range tags {
+		if candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func tagValue(tag *uint32) interface{} {
+	if tag == nil {
+		return "none"
+	}
+	return *tag
+}
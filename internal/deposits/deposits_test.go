@@ -0,0 +1,94 @@
+package deposits
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestNewDeposit(t *testing.T) {
+	account := &DepositAccountSchema{Account: "rExchange", Confirmations: 3, Tags: []uint32{7}, SinceLedger: 100}
+	amount := xrpl.Amount{Currency: "XRP", Value: "1000000"}
+	tag := func(value uint32) *uint32 { return &value }
+
+	tests := []struct {
+		name       string
+		from       string
+		ledger     int
+		tag        *uint32
+		delivered  *xrpl.Amount
+		wantOK     bool
+		wantStatus string
+		wantReason string
+	}{
+		{name: "known tag waits for its confirmations", from: "rCustomer", ledger: 120, tag: tag(7), delivered: &amount, wantOK: true, wantStatus: StatusPending},
+		{name: "missing tag goes to review", from: "rCustomer", ledger: 120, delivered: &amount, wantOK: true, wantStatus: StatusReview, wantReason: ReviewMissingTag},
+		{name: "unknown tag goes to review", from: "rCustomer", ledger: 120, tag: tag(8), delivered: &amount, wantOK: true, wantStatus: StatusReview, wantReason: ReviewUnknownTag},
+		{name: "payment before registration", from: "rCustomer", ledger: 99, tag: tag(7), delivered: &amount},
+		{name: "payment sent by the account itself", from: "rExchange", ledger: 120, tag: tag(7), delivered: &amount},
+		{name: "payment without delivered amount", from: "rCustomer", ledger: 120, tag: tag(7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment := &transactions.Payment{Destination: "rExchange", DestinationTag: tt.tag}
+			tx := &transactions.Transaction{Hash: "H", Account: tt.from, LedgerIndex: tt.ledger, Validated: true,
+				Result: "tesSUCCESS", Body: payment, DeliveredAmount: tt.delivered}
+
+			deposit, ok := newDeposit(tx, payment, account)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if deposit.Status != tt.wantStatus || deposit.ReviewReason != tt.wantReason {
+				t.Errorf("status = %s (%s), want %s (%s)", deposit.Status, deposit.ReviewReason, tt.wantStatus, tt.wantReason)
+			}
+			if deposit.CreditLedger != tt.ledger+account.Confirmations {
+				t.Errorf("credit ledger = %d, want %d", deposit.CreditLedger, tt.ledger+account.Confirmations)
+			}
+		})
+	}
+}
+
+func TestConfirmedFilter(t *testing.T) {
+	want := bson.M{"status": StatusPending, "credit_ledger": bson.M{"$lte": 123}}
+	if got := confirmedFilter(123); !reflect.DeepEqual(got, want) {
+		t.Fatalf("confirmedFilter = %v, want %v", got, want)
+	}
+}
+
+func TestSinceLedger(t *testing.T) {
+	defer atomic.StoreInt64(&latestLedger, 0)
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"result": {"ledger": {"ledger_index": "500"}, "validated": true}}`))
+	}))
+	defer server.Close()
+	client := xrpl.NewHTTPClient(server.URL)
+
+	// Before the ledger stream delivers anything, rippled is asked for the validated ledger
+	atomic.StoreInt64(&latestLedger, 0)
+	since, err := sinceLedger(client)
+	if err != nil || since != 500 {
+		t.Fatalf("sinceLedger = %d, %v, want 500", since, err)
+	}
+
+	recordLedger(510)
+	since, err = sinceLedger(client)
+	if err != nil || since != 510 {
+		t.Fatalf("sinceLedger = %d, %v, want 510", since, err)
+	}
+	if requests.Load() != 1 {
+		t.Fatalf("%d requests, want 1", requests.Load())
+	}
+}
@@ -0,0 +1,51 @@
+package deposits
+
+import (
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// DefaultConfirmations is the number of validated ledgers to wait before crediting a deposit
+const DefaultConfirmations = 1
+
+// Deposit status
+const (
+	StatusPending  = "pending"
+	StatusCredited = "credited"
+	StatusReview   = "review"
+	StatusRejected = "rejected"
+)
+
+// Review reasons
+const (
+	ReviewMissingTag = "missing_tag"
+	ReviewUnknownTag = "unknown_tag"
+)
+
+// DepositAccountSchema define uma conta recebedora monitorada para depósitos
+type DepositAccountSchema struct {
+	Account       string `bson:"account" json:"account"`
+	Confirmations int    `bson:"confirmations" json:"confirmations"`
+	// Tags lists the destination tags assigned to customers; empty accepts any tag
+	Tags        []uint32  `bson:"tags" json:"tags"`
+	CallbackURL string    `bson:"callback_url,omitempty" json:"callback_url,omitempty"`
+	SinceLedger int       `bson:"since_ledger" json:"since_ledger"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+}
+
+// DepositSchema define um pagamento recebido por uma conta monitorada, chaveado por (account, destination_tag)
+type DepositSchema struct {
+	TxHash         string      `bson:"tx_hash" json:"tx_hash"`
+	Account        string      `bson:"account" json:"account"`
+	DestinationTag *uint32     `bson:"destination_tag,omitempty" json:"destination_tag,omitempty"`
+	From           string      `bson:"from" json:"from"`
+	Amount         xrpl.Amount `bson:"amount" json:"amount"`
+	PartialPayment bool        `bson:"partial_payment" json:"partial_payment"`
+	LedgerIndex    int         `bson:"ledger_index" json:"ledger_index"`
+	CreditLedger   int         `bson:"credit_ledger" json:"credit_ledger"`
+	Status         string      `bson:"status" json:"status"`
+	ReviewReason   string      `bson:"review_reason,omitempty" json:"review_reason,omitempty"`
+	CreditedAt     *time.Time  `bson:"credited_at,omitempty" json:"credited_at,omitempty"`
+	CreatedAt      time.Time   `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,71 @@
+package ingest
+
+import (
+	"strings"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// Match reports whether a normalized transaction satisfies the filter
+func (f *Filter) Match(tx *transactions.Transaction) bool {
+	if f.ValidatedOnly && !tx.Validated {
+		return false
+	}
+	if len(f.TransactionTypes) > 0 && !contains(f.TransactionTypes, tx.TransactionType) {
+		return false
+	}
+	if len(f.Results) > 0 && !matchResult(f.Results, tx.Result) {
+		return false
+	}
+	if len(f.Accounts) > 0 && !containsAny(f.Accounts, tx.Accounts) {
+		return false
+	}
+	if len(f.Currencies) == 0 && len(f.Issuers) == 0 && f.MinAmount == 0 {
+		return true
+	}
+
+	for _, amount := range transactions.Amounts(tx) {
+		if f.matchAmount(amount) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Filter) matchAmount(amount xrpl.Amount) bool {
+	if len(f.Currencies) > 0 && !contains(f.Currencies, amount.Currency) {
+		return false
+	}
+	if len(f.Issuers) > 0 && !contains(f.Issuers, amount.Issuer) {
+		return false
+	}
+	return amount.Float() >= f.MinAmount
+}
+
+func matchResult(results []string, result string) bool {
+	for _, expected := range results {
+		if result == expected || (len(expected) == 3 && strings.HasPrefix(result, expected)) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(values []string, candidates []string) bool {
+	for _, candidate := range candidates {
+		if contains(values, candidate) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,233 @@
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// RouteQueueSize is the number of transactions buffered per route before new ones are dropped
+const RouteQueueSize = 1000
+
+// ErrNotRunning is returned when stopping an ingester that is not running
+var ErrNotRunning = errors.New("transactions ingester is not running")
+
+type route struct {
+	Route
+	sinks     []Sink
+	queue     chan *transactions.Transaction
+	matched   int64
+	delivered int64
+	failed    int64
+}
+
+var (
+	mu       sync.Mutex
+	active   *Config
+	routes   []*route
+	received int64
+
+	// HandleMessage is registered once on the client's shared read loop and only dispatches
+	// while a config is active, so restarting the ingester never registers it twice
+	handlerOnce sync.Once
+)
+
+// Start subscribes to the transaction streams and routes matching transactions to the configured sinks
+func Start(wsClient *xrpl.WebSocketClient, cfg *Config) error {
+	built, err := buildRoutes(cfg)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if active != nil {
+		unsubscribe(wsClient, active)
+		stopRoutes()
+	}
+
+	request := StreamRequest{
+		ID:      "subscribe_transactions",
+		Command: "subscribe",
+		Streams: streams(cfg),
+	}
+	log.Printf("🔗 Enviando comando de inscrição para os streams: %v", request.Streams)
+	if err := wsClient.Subscribe(request); err != nil {
+		log.Printf("❌ Erro ao enviar o comando subscribe: %v", err)
+		return err
+	}
+
+	for _, r := range built {
+		go r.run()
+	}
+	active = cfg
+	routes = built
+	atomic.StoreInt64(&received, 0)
+
+	handlerOnce.Do(func() {
+		wsClient.AddHandler(HandleMessage)
+	})
+	return nil
+}
+
+// Stop unsubscribes from the transaction streams and stops the route workers
+func Stop(wsClient *xrpl.WebSocketClient) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if active == nil {
+		return ErrNotRunning
+	}
+	unsubscribe(wsClient, active)
+	stopRoutes()
+	active = nil
+	log.Println("⛔ Ingestão de transações encerrada.")
+	return nil
+}
+
+// CurrentStatus returns the active configuration and route counters
+func CurrentStatus() Status {
+	mu.Lock()
+	defer mu.Unlock()
+
+	status := Status{
+		Running:  active != nil,
+		Config:   active,
+		Received: atomic.LoadInt64(&received),
+		Routes:   map[string]RouteStats{},
+	}
+	for _, r := range routes {
+		status.Routes[r.Name] = RouteStats{
+			Matched:   atomic.LoadInt64(&r.matched),
+			Delivered: atomic.LoadInt64(&r.delivered),
+			Failed:    atomic.LoadInt64(&r.failed),
+		}
+	}
+	return status
+}
+
+// HandleMessage normalizes a stream transaction and queues it on every matching route
+func HandleMessage(msg []byte) {
+	var message transactions.StreamTransactionMessage
+	if err := json.Unmarshal(msg, &message); err != nil || message.Type != "transaction" {
+		return
+	}
+
+	tx, err := transactions.Normalize(message.Raw())
+	if err != nil {
+		log.Printf("⚠️ Erro ao normalizar transação do stream: %v", err)
+		return
+	}
+
+	// Holding mu keeps Stop from closing a queue during dispatch; sends never block
+	mu.Lock()
+	defer mu.Unlock()
+	if active == nil {
+		return
+	}
+	atomic.AddInt64(&received, 1)
+
+	for _, r := range routes {
+		if !r.Filter.Match(tx) {
+			continue
+		}
+		atomic.AddInt64(&r.matched, 1)
+
+		select {
+		case r.queue <- tx:
+		default:
+			atomic.AddInt64(&r.failed, 1)
+			log.Printf("⚠️ Fila da rota %s cheia, transação %s descartada", r.Name, tx.Hash)
+		}
+	}
+}
+
+// LoadConfigFile reads an ingester configuration from a JSON file
+func LoadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (r *route) run() {
+	for tx := range r.queue {
+		for _, sink := range r.sinks {
+			if err := sink.Write(tx); err != nil {
+				atomic.AddInt64(&r.failed, 1)
+				log.Printf("❌ Erro ao enviar transação %s pela rota %s: %v", tx.Hash, r.Name, err)
+				continue
+			}
+			atomic.AddInt64(&r.delivered, 1)
+		}
+	}
+}
+
+func buildRoutes(cfg *Config) ([]*route, error) {
+	if cfg == nil || len(cfg.Routes) == 0 {
+		return nil, errors.New("config requires at least one route")
+	}
+
+	built := make([]*route, 0, len(cfg.Routes))
+	for i := range cfg.Routes {
+		if cfg.Routes[i].Name == "" {
+			cfg.Routes[i].Name = fmt.Sprintf("route_%d", i+1)
+		}
+		r := &route{Route: cfg.Routes[i], queue: make(chan *transactions.Transaction, RouteQueueSize)}
+
+		if len(r.Route.Sinks) == 0 {
+			return nil, fmt.Errorf("route %s requires at least one sink", r.Name)
+		}
+		for _, sinkConfig := range r.Route.Sinks {
+			sink, err := NewSink(sinkConfig)
+			if err != nil {
+				return nil, fmt.Errorf("route %s: %w", r.Name, err)
+			}
+			r.sinks = append(r.sinks, sink)
+		}
+		built = append(built, r)
+	}
+	return built, nil
+}
+
+// stopRoutes closes the route queues; the caller holds mu
+func stopRoutes() {
+	for _, r := range routes {
+		close(r.queue)
+	}
+	routes = nil
+}
+
+// unsubscribe releases the ingester's streams; the client keeps subscribed the streams another
+// consumer still holds, such as transactions_proposed for the pending pool
+func unsubscribe(wsClient *xrpl.WebSocketClient, cfg *Config) {
+	request := StreamRequest{
+		ID:      "unsubscribe_transactions",
+		Command: "unsubscribe",
+		Streams: streams(cfg),
+	}
+	if err := wsClient.Subscribe(request); err != nil {
+		log.Printf("⚠️ Erro ao enviar o comando unsubscribe: %v", err)
+	}
+}
+
+func streams(cfg *Config) []string {
+	if cfg.IncludeProposed {
+		return []string{StreamTransactions, StreamTransactionsProposed}
+	}
+	return []string{StreamTransactions}
+}
@@ -0,0 +1,87 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+)
+
+// Sink receives the transactions matched by a route
+type Sink interface {
+	Write(tx *transactions.Transaction) error
+}
+
+// NewSink builds a sink from its configuration
+func NewSink(cfg SinkConfig) (Sink, error) {
+	switch cfg.Type {
+	case SinkMongo:
+		return MongoSink{}, nil
+	case SinkLog:
+		return LogSink{}, nil
+	case SinkWebhook:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("webhook sink requires url")
+		}
+		return &WebhookSink{
+			URL:     cfg.URL,
+			Headers: cfg.Headers,
+			Client:  &http.Client{Timeout: 10 * time.Second},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown sink type %q", cfg.Type)
+}
+
+// MongoSink saves transactions in the transactions collection and runs the registered
+// handlers, so alerts, invoices and the other consumers see the ingested feed
+type MongoSink struct{}
+
+func (MongoSink) Write(tx *transactions.Transaction) error {
+	return transactions.Store(tx)
+}
+
+// LogSink writes a summary line per transaction
+type LogSink struct{}
+
+func (LogSink) Write(tx *transactions.Transaction) error {
+	log.Printf("📥 %s %s %s ledger=%d result=%s", tx.TransactionType, tx.Hash, tx.Account, tx.LedgerIndex, tx.Result)
+	return nil
+}
+
+// WebhookSink posts each transaction as JSON to a URL
+type WebhookSink struct {
+	URL     string
+	Headers map[string]string
+	Client  *http.Client
+}
+
+func (s *WebhookSink) Write(tx *transactions.Transaction) error {
+	body, err := json.Marshal(tx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned %s", s.URL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package ingest
+
+// Streams that carry transactions
+const (
+	StreamTransactions         = "transactions"
+	StreamTransactionsProposed = "transactions_proposed"
+)
+
+// Sink types
+const (
+	SinkMongo   = "mongo"
+	SinkLog     = "log"
+	SinkWebhook = "webhook"
+)
+
+// Config is the declarative configuration of the global transactions ingester
+type Config struct {
+	// IncludeProposed also subscribes to transactions_proposed (unvalidated transactions)
+	IncludeProposed bool    `json:"include_proposed"`
+	Routes          []Route `json:"routes"`
+}
+
+// Route sends the transactions matching its filter to its sinks
+type Route struct {
+	Name   string       `json:"name"`
+	Filter Filter       `json:"filter"`
+	Sinks  []SinkConfig `json:"sinks"`
+}
+
+// Filter selects transactions; empty fields match everything and all set fields must match
+type Filter struct {
+	TransactionTypes []string `json:"transaction_types,omitempty"`
+	// Results accepts exact codes ("tesSUCCESS") or class prefixes ("tec")
+	Results    []string `json:"results,omitempty"`
+	Accounts   []string `json:"accounts,omitempty"`
+	Currencies []string `json:"currencies,omitempty"`
+	Issuers    []string `json:"issuers,omitempty"`
+	// MinAmount applies to amounts in the filtered currencies (XRP, not drops, for XRP)
+	MinAmount     float64 `json:"min_amount,omitempty"`
+	ValidatedOnly bool    `json:"validated_only,omitempty"`
+}
+
+// SinkConfig defines where matching transactions are sent
+type SinkConfig struct {
+	Type    string            `json:"type"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// StreamRequest defines the subscribe command for transaction streams
+type StreamRequest struct {
+	ID      string   `json:"id"`
+	Command string   `json:"command"`
+	Streams []string `json:"streams"`
+}
+
+// RouteStats counts the transactions matched and delivered by a route
+type RouteStats struct {
+	Matched   int64 `json:"matched"`
+	Delivered int64 `json:"delivered"`
+	Failed    int64 `json:"failed"`
+}
+
+// Status reports the running configuration and counters of the ingester
+type Status struct {
+	Running  bool                  `json:"running"`
+	Config   *Config               `json:"config,omitempty"`
+	Received int64                 `json:"received"`
+	Routes   map[string]RouteStats `json:"routes"`
+}
@@ -0,0 +1,355 @@
+package invoices
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/accounts"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// reconcileMu serializes payment matching so a payment is applied to a single invoice
+	reconcileMu sync.Mutex
+	handler     sync.Once
+)
+
+// Start follows the destinations of open invoices and registers the payment handler
+func Start(wsClient *xrpl.WebSocketClient) error {
+	registerHandler()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	destinations, err := database.GetInvoiceCollection().Distinct(ctx, "destination", bson.M{"status": bson.M{"$in": []string{StatusOpen, StatusUnderpaid}}})
+	if err != nil {
+		return err
+	}
+
+	for _, destination := range destinations {
+		if account, ok := destination.(string); ok {
+			if err := accounts.FollowAccount(wsClient, account); err != nil {
+				log.Printf("⚠️ Erro ao acompanhar a conta de cobrança %s: %v", account, err)
+			}
+		}
+	}
+	return nil
+}
+
+// CreateInvoice saves a payment request and follows its destination account
+func CreateInvoice(wsClient *xrpl.WebSocketClient, request CreateInvoiceRequest) (*InvoiceSchema, error) {
+	if request.Destination == "" {
+		return nil, errors.New("destination is required")
+	}
+	if request.Amount.Currency == "" || xrpl.ParseValue(request.Amount.Value).Sign() <= 0 {
+		return nil, errors.New("amount with currency and a positive value is required")
+	}
+	if !request.Amount.IsXRP() && request.Amount.Issuer == "" {
+		return nil, errors.New("issuer is required for token amounts")
+	}
+	if request.ExpiresAt.IsZero() || request.ExpiresAt.Before(time.Now()) {
+		return nil, errors.New("expires_at must be in the future")
+	}
+
+	id, err := newInvoiceID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invoice := &InvoiceSchema{
+		ID:             id,
+		Reference:      request.Reference,
+		Destination:    request.Destination,
+		DestinationTag: request.DestinationTag,
+		Amount:         request.Amount,
+		Received:       "0",
+		Payments:       []InvoicePayment{},
+		Status:         StatusOpen,
+		ExpiresAt:      request.ExpiresAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := database.GetInvoiceCollection().InsertOne(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	registerHandler()
+	if err := accounts.FollowAccount(wsClient, invoice.Destination); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+func registerHandler() {
+	handler.Do(func() {
+		transactions.RegisterHandler(HandleTransaction)
+	})
+}
+
+// HandleTransaction applies a validated incoming payment to the invoice it pays
+func HandleTransaction(tx *transactions.Transaction) {
+	payment, ok := tx.Body.(*transactions.Payment)
+	if !ok || !tx.Validated || tx.Result != "tesSUCCESS" || tx.DeliveredAmount == nil {
+		return
+	}
+
+	reconcileMu.Lock()
+	defer reconcileMu.Unlock()
+
+	invoice, err := findInvoice(tx, payment)
+	if err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			log.Printf("❌ Erro ao buscar cobrança para o pagamento %s: %v", tx.Hash, err)
+		}
+		return
+	}
+
+	invoice.Payments = append(invoice.Payments, InvoicePayment{
+		TxHash:      tx.Hash,
+		From:        tx.Account,
+		Amount:      *tx.DeliveredAmount,
+		LedgerIndex: tx.LedgerIndex,
+		Date:        tx.Date,
+	})
+	invoice.Received = xrpl.AddValues(invoice.Received, tx.DeliveredAmount.Value)
+	invoice.Status = reconcileStatus(invoice)
+	invoice.UpdatedAt = time.Now()
+	if invoice.Status != StatusUnderpaid && invoice.PaidAt == nil {
+		paidAt := tx.Date
+		invoice.PaidAt = &paidAt
+	}
+
+	if err := saveInvoice(invoice); err != nil {
+		log.Printf("❌ Erro ao salvar cobrança %s: %v", invoice.ID, err)
+		return
+	}
+	log.Printf("🧾 Cobrança %s: %s (%s de %s %s)", invoice.ID, invoice.Status, invoice.Received, invoice.Amount.Value, invoice.Amount.Currency)
+}
+
+// findInvoice returns the invoice paid by a payment: the one named by its InvoiceID or else
+// the oldest unpaid invoice for the same destination, tag and asset. Expiry is checked against
+// the payment's ledger date, so invoices expired while the payment was being processed still
+// match. When every matching invoice is already paid the payment is an overpayment of the
+// latest paid one
+func findInvoice(tx *transactions.Transaction, payment *transactions.Payment) (*InvoiceSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := database.GetInvoiceCollection()
+
+	// A payment is applied only once, even when it is ingested again
+	count, err := collection.CountDocuments(ctx, bson.M{"payments.tx_hash": tx.Hash})
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	unpaid, paid := invoiceFilters(tx, payment)
+
+	var invoice InvoiceSchema
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	err = collection.FindOne(ctx, unpaid, opts).Decode(&invoice)
+	if err == nil {
+		return &invoice, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	opts = options.FindOne().SetSort(bson.D{{Key: "paid_at", Value: -1}})
+	if err := collection.FindOne(ctx, paid, opts).Decode(&invoice); err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// invoiceFilters builds the queries of findInvoice: the unpaid invoices the payment can pay and
+// the paid ones it can overpay. Only invoices created before the payment match. Without an
+// InvoiceID, a payment after expiry is not attributed to an invoice that was already paid
+func invoiceFilters(tx *transactions.Transaction, payment *transactions.Payment) (unpaid bson.M, paid bson.M) {
+	filter := bson.M{
+		"destination":     payment.Destination,
+		"amount.currency": tx.DeliveredAmount.Currency,
+		"created_at":      bson.M{"$lte": tx.Date},
+	}
+	if payment.InvoiceID != "" {
+		filter["id"] = strings.ToUpper(payment.InvoiceID)
+	} else if payment.DestinationTag != nil {
+		filter["destination_tag"] = *payment.DestinationTag
+	} else {
+		filter["destination_tag"] = bson.M{"$exists": false}
+	}
+	if !tx.DeliveredAmount.IsXRP() {
+		filter["amount.issuer"] = tx.DeliveredAmount.Issuer
+	}
+
+	unpaid = bson.M{}
+	paid = bson.M{}
+	for key, value := range filter {
+		unpaid[key] = value
+		paid[key] = value
+	}
+	unpaid["status"] = bson.M{"$in": []string{StatusOpen, StatusUnderpaid, StatusExpired}}
+	unpaid["expires_at"] = bson.M{"$gte": tx.Date}
+
+	paid["status"] = bson.M{"$in": []string{StatusPaid, StatusOverpaid}}
+	if payment.InvoiceID == "" {
+		paid["expires_at"] = bson.M{"$gte": tx.Date}
+	}
+	return unpaid, paid
+}
+
+func reconcileStatus(invoice *InvoiceSchema) string {
+	switch xrpl.ParseValue(invoice.Received).Cmp(xrpl.ParseValue(invoice.Amount.Value)) {
+	case -1:
+		return StatusUnderpaid
+	case 0:
+		return StatusPaid
+	}
+	return StatusOverpaid
+}
+
+// ExpireInvoices marks open invoices past their expiry as expired. Underpaid invoices keep their
+// status; payments validated before expires_at are still applied by findInvoice
+func ExpireInvoices() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := database.GetInvoiceCollection().UpdateMany(ctx,
+		bson.M{"status": StatusOpen, "expires_at": bson.M{"$lt": time.Now()}},
+		bson.M{"$set": bson.M{"status": StatusExpired, "updated_at": time.Now()}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// RunExpiry expires invoices at a fixed interval
+func RunExpiry(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		expired, err := ExpireInvoices()
+		if err != nil {
+			log.Printf("❌ Erro ao expirar cobranças: %v", err)
+			continue
+		}
+		if expired > 0 {
+			log.Printf("⌛ %d cobranças expiradas", expired)
+		}
+	}
+}
+
+// GetInvoice returns an invoice by ID
+func GetInvoice(id string) (*InvoiceSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var invoice InvoiceSchema
+	if err := database.GetInvoiceCollection().FindOne(ctx, bson.M{"id": strings.ToUpper(id)}).Decode(&invoice); err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// GetInvoices returns invoices filtered by destination and status, newest first
+func GetInvoices(destination string, status string, limit int64) ([]InvoiceSchema, error) {
+	filter := bson.M{}
+	if destination != "" {
+		filter["destination"] = destination
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+	return findInvoices(filter, limit)
+}
+
+// Report aggregates the invoices created in a period by status and asset
+func Report(from, to time.Time, destination string) ([]ReportEntry, error) {
+	filter := bson.M{"created_at": bson.M{"$gte": from, "$lt": to}}
+	if destination != "" {
+		filter["destination"] = destination
+	}
+
+	invoices, err := findInvoices(filter, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := map[string]*ReportEntry{}
+	order := []string{}
+	for _, invoice := range invoices {
+		key := invoice.Status + "|" + invoice.Amount.Key()
+		entry, ok := entries[key]
+		if !ok {
+			entry = &ReportEntry{Status: invoice.Status, Asset: invoice.Amount.Key(), Expected: "0", Received: "0"}
+			entries[key] = entry
+			order = append(order, key)
+		}
+		entry.Count++
+		entry.Expected = xrpl.AddValues(entry.Expected, invoice.Amount.Value)
+		entry.Received = xrpl.AddValues(entry.Received, invoice.Received)
+	}
+
+	report := make([]ReportEntry, 0, len(order))
+	for _, key := range order {
+		report = append(report, *entries[key])
+	}
+	return report, nil
+}
+
+func findInvoices(filter bson.M, limit int64) ([]InvoiceSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetInvoiceCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	invoices := []InvoiceSchema{}
+	if err := cursor.All(ctx, &invoices); err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+func saveInvoice(invoice *InvoiceSchema) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := database.GetInvoiceCollection().ReplaceOne(ctx, bson.M{"id": invoice.ID}, invoice)
+	return err
+}
+
+func newInvoiceID() (string, error) {
+	id := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(id)), nil
+}
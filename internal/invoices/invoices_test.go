@@ -0,0 +1,93 @@
+package invoices
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestReconcileStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   xrpl.Amount
+		received string
+		want     string
+	}{
+		{name: "nothing received", amount: xrpl.Amount{Currency: "XRP", Value: "1000000"}, received: "0", want: StatusUnderpaid},
+		{name: "partial drops", amount: xrpl.Amount{Currency: "XRP", Value: "1000000"}, received: "999999", want: StatusUnderpaid},
+		{name: "exact drops", amount: xrpl.Amount{Currency: "XRP", Value: "1000000"}, received: "1000000", want: StatusPaid},
+		{name: "exact token with different notation", amount: xrpl.Amount{Currency: "USD", Issuer: "rGateway", Value: "10"}, received: "1e1", want: StatusPaid},
+		{name: "token overpayment", amount: xrpl.Amount{Currency: "USD", Issuer: "rGateway", Value: "10"}, received: "10.0000001", want: StatusOverpaid},
+		{name: "sum of payments", amount: xrpl.Amount{Currency: "USD", Issuer: "rGateway", Value: "0.3"}, received: xrpl.AddValues("0.1", "0.2"), want: StatusPaid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoice := &InvoiceSchema{Amount: tt.amount, Received: tt.received}
+			if got := reconcileStatus(invoice); got != tt.want {
+				t.Fatalf("reconcileStatus = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvoiceFilters(t *testing.T) {
+	date := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tag := uint32(42)
+	unpaidStatus := bson.M{"$in": []string{StatusOpen, StatusUnderpaid, StatusExpired}}
+	paidStatus := bson.M{"$in": []string{StatusPaid, StatusOverpaid}}
+
+	tests := []struct {
+		name       string
+		delivered  xrpl.Amount
+		payment    transactions.Payment
+		wantUnpaid bson.M
+		wantPaid   bson.M
+	}{
+		{
+			name:      "invoice ID can overpay after expiry",
+			delivered: xrpl.Amount{Currency: "XRP", Value: "1000000"},
+			payment:   transactions.Payment{Destination: "rMerchant", InvoiceID: "abc123"},
+			wantUnpaid: bson.M{"destination": "rMerchant", "amount.currency": "XRP", "created_at": bson.M{"$lte": date},
+				"id": "ABC123", "status": unpaidStatus, "expires_at": bson.M{"$gte": date}},
+			wantPaid: bson.M{"destination": "rMerchant", "amount.currency": "XRP", "created_at": bson.M{"$lte": date},
+				"id": "ABC123", "status": paidStatus},
+		},
+		{
+			name:      "destination tag overpays only before expiry",
+			delivered: xrpl.Amount{Currency: "USD", Issuer: "rGateway", Value: "10"},
+			payment:   transactions.Payment{Destination: "rMerchant", DestinationTag: &tag},
+			wantUnpaid: bson.M{"destination": "rMerchant", "amount.currency": "USD", "amount.issuer": "rGateway",
+				"created_at": bson.M{"$lte": date}, "destination_tag": tag, "status": unpaidStatus, "expires_at": bson.M{"$gte": date}},
+			wantPaid: bson.M{"destination": "rMerchant", "amount.currency": "USD", "amount.issuer": "rGateway",
+				"created_at": bson.M{"$lte": date}, "destination_tag": tag, "status": paidStatus, "expires_at": bson.M{"$gte": date}},
+		},
+		{
+			name:      "untagged payment matches untagged invoices",
+			delivered: xrpl.Amount{Currency: "XRP", Value: "1000000"},
+			payment:   transactions.Payment{Destination: "rMerchant"},
+			wantUnpaid: bson.M{"destination": "rMerchant", "amount.currency": "XRP", "created_at": bson.M{"$lte": date},
+				"destination_tag": bson.M{"$exists": false}, "status": unpaidStatus, "expires_at": bson.M{"$gte": date}},
+			wantPaid: bson.M{"destination": "rMerchant", "amount.currency": "XRP", "created_at": bson.M{"$lte": date},
+				"destination_tag": bson.M{"$exists": false}, "status": paidStatus, "expires_at": bson.M{"$gte": date}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &transactions.Transaction{Date: date, DeliveredAmount: &tt.delivered}
+
+			unpaid, paid := invoiceFilters(tx, &tt.payment)
+			if !reflect.DeepEqual(unpaid, tt.wantUnpaid) {
+				t.Errorf("unpaid = %v, want %v", unpaid, tt.wantUnpaid)
+			}
+			if !reflect.DeepEqual(paid, tt.wantPaid) {
+				t.Errorf("paid = %v, want %v", paid, tt.wantPaid)
+			}
+		})
+	}
+}
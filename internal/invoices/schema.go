@@ -0,0 +1,61 @@
+package invoices
+
+import (
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// Invoice status
+const (
+	StatusOpen      = "open"
+	StatusPaid      = "paid"
+	StatusUnderpaid = "underpaid"
+	StatusOverpaid  = "overpaid"
+	StatusExpired   = "expired"
+)
+
+// InvoiceSchema define uma cobrança (payment request) salva no MongoDB.
+// ID is a 256-bit hex value that customers can send as the Payment InvoiceID;
+// Amount uses the ledger convention (drops for XRP)
+type InvoiceSchema struct {
+	ID             string           `bson:"id" json:"id"`
+	Reference      string           `bson:"reference,omitempty" json:"reference,omitempty"`
+	Destination    string           `bson:"destination" json:"destination"`
+	DestinationTag *uint32          `bson:"destination_tag,omitempty" json:"destination_tag,omitempty"`
+	Amount         xrpl.Amount      `bson:"amount" json:"amount"`
+	Received       string           `bson:"received" json:"received"`
+	Payments       []InvoicePayment `bson:"payments" json:"payments"`
+	Status         string           `bson:"status" json:"status"`
+	ExpiresAt      time.Time        `bson:"expires_at" json:"expires_at"`
+	PaidAt         *time.Time       `bson:"paid_at,omitempty" json:"paid_at,omitempty"`
+	CreatedAt      time.Time        `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time        `bson:"updated_at" json:"updated_at"`
+}
+
+// InvoicePayment is a validated payment applied to an invoice
+type InvoicePayment struct {
+	TxHash      string      `bson:"tx_hash" json:"tx_hash"`
+	From        string      `bson:"from" json:"from"`
+	Amount      xrpl.Amount `bson:"amount" json:"amount"`
+	LedgerIndex int         `bson:"ledger_index" json:"ledger_index"`
+	Date        time.Time   `bson:"date" json:"date"`
+}
+
+// CreateInvoiceRequest defines the payload to create an invoice
+type CreateInvoiceRequest struct {
+	Reference      string      `json:"reference"`
+	Destination    string      `json:"destination"`
+	DestinationTag *uint32     `json:"destination_tag"`
+	Amount         xrpl.Amount `json:"amount"`
+	ExpiresAt      time.Time   `json:"expires_at"`
+}
+
+// ReportEntry aggregates invoices by status and asset
+type ReportEntry struct {
+	Status   string `json:"status"`
+	Asset    string `json:"asset"`
+	Count    int    `json:"count"`
+	Expected string `json:"expected"`
+	Received string `json:"received"`
+}
@@ -0,0 +1,242 @@
+package issuers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/accounts"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/ledger"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// followOwner holds the monitored issuers on the shared accounts stream
+const followOwner = "issuers"
+
+// monitored maps each monitored issuer to its hot wallets, for the transaction handler
+var (
+	monitored   = map[string]map[string]bool{}
+	monitoredMu sync.RWMutex
+)
+
+// SaveIssuer adds an issuer to the monitored list or updates its hot wallets and interval, and
+// follows it on the accounts stream so its transactions reach HandleTransaction
+func SaveIssuer(wsClient *xrpl.WebSocketClient, issuer IssuerSchema) (*IssuerSchema, error) {
+	issuer.Account = strings.TrimSpace(issuer.Account)
+	if issuer.Account == "" {
+		return nil, fmt.Errorf("account is required")
+	}
+	if issuer.IntervalLedgers <= 0 {
+		issuer.IntervalLedgers = DefaultIntervalLedgers
+	}
+	if issuer.HotWallets == nil {
+		issuer.HotWallets = []string{}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"hot_wallets":      issuer.HotWallets,
+			"interval_ledgers": issuer.IntervalLedgers,
+			"updated_at":       now,
+		},
+		"$setOnInsert": bson.M{"account": issuer.Account, "last_snapshot_ledger": 0, "created_at": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved IssuerSchema
+	if err := database.GetIssuerCollection().FindOneAndUpdate(ctx, bson.M{"account": issuer.Account}, update, opts).Decode(&saved); err != nil {
+		return nil, err
+	}
+
+	setMonitored(saved)
+	if err := accounts.FollowAccounts(wsClient, followOwner, []string{saved.Account}); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// DeleteIssuer stops monitoring an issuer; its snapshots and changes are kept
+func DeleteIssuer(wsClient *xrpl.WebSocketClient, account string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := database.GetIssuerCollection().DeleteOne(ctx, bson.M{"account": account})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	monitoredMu.Lock()
+	delete(monitored, account)
+	monitoredMu.Unlock()
+	return accounts.UnfollowAccounts(wsClient, followOwner, []string{account})
+}
+
+// GetIssuers returns the monitored issuers
+func GetIssuers() ([]IssuerSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := database.GetIssuerCollection().Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	issuers := []IssuerSchema{}
+	if err := cursor.All(ctx, &issuers); err != nil {
+		return nil, err
+	}
+	return issuers, nil
+}
+
+// Start loads the monitored issuers, follows them on the accounts stream and attributes
+// obligation changes for every validated transaction stored through Ingest or Backfill
+func Start(wsClient *xrpl.WebSocketClient) error {
+	transactions.RegisterStateHandler(HandleTransaction)
+
+	issuers, err := GetIssuers()
+	if err != nil {
+		return err
+	}
+	followed := make([]string, 0, len(issuers))
+	for _, issuer := range issuers {
+		setMonitored(issuer)
+		followed = append(followed, issuer.Account)
+	}
+	if len(followed) > 0 {
+		if err := accounts.FollowAccounts(wsClient, followOwner, followed); err != nil {
+			return err
+		}
+	}
+	log.Printf("🏦 %d emissores monitorados", len(issuers))
+	return nil
+}
+
+func setMonitored(issuer IssuerSchema) {
+	hotWallets := map[string]bool{}
+	for _, wallet := range issuer.HotWallets {
+		hotWallets[wallet] = true
+	}
+
+	monitoredMu.Lock()
+	monitored[issuer.Account] = hotWallets
+	monitoredMu.Unlock()
+}
+
+// Run snapshots the issuers that are due every interval, at the latest validated ledger
+func Run(client *xrpl.HTTPClient, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ledgerIndex, err := ledger.FetchValidatedLedgerIndex(client)
+		if err != nil {
+			log.Printf("❌ Erro ao obter o ledger validado para snapshots de emissores: %v", err)
+			continue
+		}
+
+		issuers, err := GetIssuers()
+		if err != nil {
+			log.Printf("❌ Erro ao carregar emissores monitorados: %v", err)
+			continue
+		}
+
+		for _, issuer := range issuers {
+			if ledgerIndex-issuer.LastSnapshotLedger < issuer.IntervalLedgers {
+				continue
+			}
+			if _, err := Snapshot(client, issuer, ledgerIndex); err != nil {
+				log.Printf("❌ Erro no snapshot de gateway_balances de %s: %v", issuer.Account, err)
+			}
+		}
+	}
+}
+
+// Snapshot reads gateway_balances of an issuer at a validated ledger and stores its obligations
+// and hot wallet balances
+func Snapshot(client *xrpl.HTTPClient, issuer IssuerSchema, ledgerIndex int) (*SnapshotSchema, error) {
+	response, err := accounts.FetchGatewayBalances(client, issuer.Account, issuer.HotWallets, strconv.Itoa(ledgerIndex), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var balances struct {
+		Result struct {
+			Obligations map[string]string `json:"obligations"`
+			Balances    map[string][]struct {
+				Currency string `json:"currency"`
+				Value    string `json:"value"`
+			} `json:"balances"`
+			Assets map[string][]struct {
+				Currency string `json:"currency"`
+				Value    string `json:"value"`
+			} `json:"assets"`
+			LedgerIndex  int    `json:"ledger_index"`
+			Error        string `json:"error"`
+			ErrorMessage string `json:"error_message"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(response, &balances); err != nil {
+		return nil, err
+	}
+	if balances.Result.Error != "" {
+		return nil, &transactions.RPCError{Code: balances.Result.Error, Message: balances.Result.ErrorMessage}
+	}
+
+	snapshot := &SnapshotSchema{
+		Issuer:      issuer.Account,
+		LedgerIndex: ledgerIndex,
+		Obligations: balances.Result.Obligations,
+		HotWallets:  map[string]map[string]string{},
+		Assets:      map[string]map[string]string{},
+		CreatedAt:   time.Now(),
+	}
+	if snapshot.Obligations == nil {
+		snapshot.Obligations = map[string]string{}
+	}
+	for wallet, lines := range balances.Result.Balances {
+		snapshot.HotWallets[wallet] = map[string]string{}
+		for _, line := range lines {
+			snapshot.HotWallets[wallet][line.Currency] = line.Value
+		}
+	}
+	for counterparty, lines := range balances.Result.Assets {
+		snapshot.Assets[counterparty] = map[string]string{}
+		for _, line := range lines {
+			snapshot.Assets[counterparty][line.Currency] = line.Value
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := database.GetIssuerSnapshotCollection().InsertOne(ctx, snapshot); err != nil && !mongo.IsDuplicateKeyError(err) {
+		return nil, err
+	}
+	_, err = database.GetIssuerCollection().UpdateOne(ctx,
+		bson.M{"account": issuer.Account},
+		bson.M{"$set": bson.M{"last_snapshot_ledger": ledgerIndex}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("📸 Snapshot de obrigações de %s no ledger %d: %v", issuer.Account, ledgerIndex, snapshot.Obligations)
+	return snapshot, nil
+}
@@ -0,0 +1,219 @@
+package issuers
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// HandleTransaction stores the obligation changes a validated transaction caused to the monitored issuers
+func HandleTransaction(tx *transactions.Transaction) {
+	if !tx.Validated || tx.Meta == nil {
+		return
+	}
+
+	changes := ObligationChanges(tx)
+	if len(changes) == 0 {
+		return
+	}
+
+	documents := make([]interface{}, len(changes))
+	for i := range changes {
+		documents[i] = changes[i]
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := database.GetObligationChangeCollection().InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		log.Printf("❌ Erro ao salvar variações de obrigações da transação %s: %v", tx.Hash, err)
+	}
+}
+
+// ObligationChanges derives the obligation changes of the monitored issuers from the trust lines a
+// transaction changed. Obligations are what the issuer owes to holders other than its hot wallets,
+// as in gateway_balances, so moves between the issuer and its hot wallets are not counted, nor
+// lines where the issuer holds a positive balance (assets, not obligations)
+func ObligationChanges(tx *transactions.Transaction) []ObligationChangeSchema {
+	monitoredMu.RLock()
+	defer monitoredMu.RUnlock()
+
+	changes := []ObligationChangeSchema{}
+	if len(monitored) == 0 || tx.Meta == nil {
+		return changes
+	}
+
+	now := time.Now()
+	for _, change := range transactions.BalanceChanges(tx.Meta) {
+		hotWallets, ok := monitored[change.Account]
+		if !ok || change.Currency == "XRP" || hotWallets[change.Issuer] || xrpl.ParseValue(change.Balance).Sign() > 0 {
+			continue
+		}
+
+		// The issuer's side of the line goes down when it owes more
+		delta := new(big.Float).SetPrec(128).Neg(xrpl.ParseValue(change.Value))
+		kind := KindIssuance
+		if delta.Sign() < 0 {
+			kind = KindRedemption
+		}
+
+		changes = append(changes, ObligationChangeSchema{
+			Issuer:          change.Account,
+			Currency:        change.Currency,
+			Holder:          change.Issuer,
+			Delta:           xrpl.FormatValue(delta),
+			Kind:            kind,
+			TxHash:          tx.Hash,
+			TransactionType: tx.TransactionType,
+			LedgerIndex:     tx.LedgerIndex,
+			Date:            tx.Date,
+			CreatedAt:       now,
+		})
+	}
+	return changes
+}
+
+// GetObligationChanges returns the obligation changes of an issuer in a currency between two ledgers (0 = open)
+func GetObligationChanges(issuer, currency string, fromLedger, toLedger int, limit int64) ([]ObligationChangeSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"issuer": issuer}
+	if currency != "" {
+		filter["currency"] = currency
+	}
+	if ledgerRange := rangeFilter(fromLedger, toLedger); len(ledgerRange) > 0 {
+		filter["ledger_index"] = ledgerRange
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetObligationChangeCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []ObligationChangeSchema{}
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// GetSnapshots returns the snapshots of an issuer between two ledgers (0 = open), oldest first
+func GetSnapshots(issuer string, fromLedger, toLedger int, limit int64) ([]SnapshotSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"issuer": issuer}
+	if ledgerRange := rangeFilter(fromLedger, toLedger); len(ledgerRange) > 0 {
+		filter["ledger_index"] = ledgerRange
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetIssuerSnapshotCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []SnapshotSchema{}
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// GetSeries builds the obligation time series of a currency from the snapshots of an issuer. Each
+// point splits the change since the previous snapshot into issuance and redemption attributed to
+// transactions; what the indexed transactions do not explain is reported as unattributed
+func GetSeries(issuer, currency string, fromLedger, toLedger int, limit int64) ([]SeriesPoint, error) {
+	snapshots, err := GetSnapshots(issuer, fromLedger, toLedger, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	series := []SeriesPoint{}
+	if len(snapshots) == 0 {
+		return series, nil
+	}
+
+	changes, err := GetObligationChanges(issuer, currency, snapshots[0].LedgerIndex+1, snapshots[len(snapshots)-1].LedgerIndex, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	next := 0
+	for i, snapshot := range snapshots {
+		point := SeriesPoint{
+			LedgerIndex: snapshot.LedgerIndex,
+			Date:        snapshot.CreatedAt,
+			Obligation:  valueOrZero(snapshot.Obligations[currency]),
+			HotWallets:  map[string]string{},
+		}
+		for wallet, balances := range snapshot.HotWallets {
+			if balance, ok := balances[currency]; ok {
+				point.HotWallets[wallet] = balance
+			}
+		}
+
+		if i > 0 {
+			issued := new(big.Float).SetPrec(128)
+			redeemed := new(big.Float).SetPrec(128)
+			point.Transactions = []string{}
+			seen := map[string]bool{}
+			for ; next < len(changes) && changes[next].LedgerIndex <= snapshot.LedgerIndex; next++ {
+				delta := xrpl.ParseValue(changes[next].Delta)
+				if delta.Sign() > 0 {
+					issued.Add(issued, delta)
+				} else {
+					redeemed.Sub(redeemed, delta)
+				}
+				if !seen[changes[next].TxHash] {
+					seen[changes[next].TxHash] = true
+					point.Transactions = append(point.Transactions, changes[next].TxHash)
+				}
+			}
+
+			delta := new(big.Float).SetPrec(128).Sub(xrpl.ParseValue(point.Obligation), xrpl.ParseValue(series[i-1].Obligation))
+			attributed := new(big.Float).SetPrec(128).Sub(issued, redeemed)
+			point.Delta = xrpl.FormatValue(delta)
+			point.Issued = xrpl.FormatValue(issued)
+			point.Redeemed = xrpl.FormatValue(redeemed)
+			point.Unattributed = xrpl.FormatValue(new(big.Float).SetPrec(128).Sub(delta, attributed))
+		}
+		series = append(series, point)
+	}
+	return series, nil
+}
+
+func rangeFilter(fromLedger, toLedger int) bson.M {
+	ledgerRange := bson.M{}
+	if fromLedger > 0 {
+		ledgerRange["$gte"] = fromLedger
+	}
+	if toLedger > 0 {
+		ledgerRange["$lte"] = toLedger
+	}
+	return ledgerRange
+}
+
+func valueOrZero(value string) string {
+	if value == "" {
+		return "0"
+	}
+	return value
+}
@@ -0,0 +1,65 @@
+package issuers
+
+import (
+	"testing"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions/txtest"
+)
+
+func TestObligationChanges(t *testing.T) {
+	monitoredMu.Lock()
+	monitored = map[string]map[string]bool{"rIssuer": {"rHot": true}}
+	monitoredMu.Unlock()
+	defer func() {
+		monitoredMu.Lock()
+		monitored = map[string]map[string]bool{}
+		monitoredMu.Unlock()
+	}()
+
+	tests := []struct {
+		name  string
+		nodes []string
+		want  []ObligationChangeSchema
+	}{
+		{
+			name: "hot wallet pays a holder",
+			nodes: []string{
+				txtest.RippleStateNode("rHot", "rIssuer", "USD", "100", "40"),
+				txtest.RippleStateNode("rHolder", "rIssuer", "USD", "0", "60"),
+			},
+			want: []ObligationChangeSchema{{Issuer: "rIssuer", Currency: "USD", Holder: "rHolder", Delta: "60", Kind: KindIssuance}},
+		},
+		{
+			name:  "holder redeems to the issuer",
+			nodes: []string{txtest.RippleStateNode("rHolder", "rIssuer", "USD", "60", "20")},
+			want:  []ObligationChangeSchema{{Issuer: "rIssuer", Currency: "USD", Holder: "rHolder", Delta: "-40", Kind: KindRedemption}},
+		},
+		{
+			name:  "issuer as high account with a negative balance for the holder",
+			nodes: []string{txtest.RippleStateNode("rIssuer", "rZHolder", "EUR", "-5", "-12.5")},
+			want:  []ObligationChangeSchema{{Issuer: "rIssuer", Currency: "EUR", Holder: "rZHolder", Delta: "7.5", Kind: KindIssuance}},
+		},
+		{
+			name:  "issuer holding another issuer's token is an asset",
+			nodes: []string{txtest.RippleStateNode("rIssuer", "rOther", "BTC", "10", "5")},
+			want:  []ObligationChangeSchema{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := ObligationChanges(&transactions.Transaction{Hash: "H", Validated: true, Meta: txtest.Meta(t, tt.nodes...)})
+			if len(changes) != len(tt.want) {
+				t.Fatalf("got %d changes %+v, want %d", len(changes), changes, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := changes[i]
+				if got.Issuer != want.Issuer || got.Currency != want.Currency || got.Holder != want.Holder ||
+					got.Delta != want.Delta || got.Kind != want.Kind {
+					t.Errorf("change %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package issuers
+
+import "time"
+
+// DefaultIntervalLedgers is the snapshot interval when an issuer does not set one
+const DefaultIntervalLedgers = 256
+
+// Kinds of obligation changes
+const (
+	KindIssuance   = "issuance"
+	KindRedemption = "redemption"
+)
+
+// IssuerSchema define um emissor monitorado, suas hot wallets e o intervalo de snapshots em ledgers
+type IssuerSchema struct {
+	Account            string    `bson:"account" json:"account"`
+	HotWallets         []string  `bson:"hot_wallets" json:"hot_wallets"`
+	IntervalLedgers    int       `bson:"interval_ledgers" json:"interval_ledgers"`
+	LastSnapshotLedger int       `bson:"last_snapshot_ledger" json:"last_snapshot_ledger"`
+	CreatedAt          time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt          time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// SnapshotSchema define um snapshot de gateway_balances de um emissor em um ledger validado
+type SnapshotSchema struct {
+	Issuer      string            `bson:"issuer" json:"issuer"`
+	LedgerIndex int               `bson:"ledger_index" json:"ledger_index"`
+	Obligations map[string]string `bson:"obligations" json:"obligations"` // Por moeda
+	// HotWallets holds the balances of each hot wallet per currency
+	HotWallets map[string]map[string]string `bson:"hot_wallets,omitempty" json:"hot_wallets,omitempty"`
+	Assets     map[string]map[string]string `bson:"assets,omitempty" json:"assets,omitempty"`
+	CreatedAt  time.Time                    `bson:"created_at" json:"created_at"`
+}
+
+// ObligationChangeSchema define a variação das obrigações de um emissor causada por uma transação,
+// por moeda e contraparte. Delta positivo é emissão; negativo, resgate
+type ObligationChangeSchema struct {
+	Issuer          string    `bson:"issuer" json:"issuer"`
+	Currency        string    `bson:"currency" json:"currency"`
+	Holder          string    `bson:"holder" json:"holder"`
+	Delta           string    `bson:"delta" json:"delta"`
+	Kind            string    `bson:"kind" json:"kind"`
+	TxHash          string    `bson:"tx_hash" json:"tx_hash"`
+	TransactionType string    `bson:"transaction_type" json:"transaction_type"`
+	LedgerIndex     int       `bson:"ledger_index" json:"ledger_index"`
+	Date            time.Time `bson:"date" json:"date"`
+	CreatedAt       time.Time `bson:"created_at" json:"created_at"`
+}
+
+// SeriesPoint is the obligation of one currency at a snapshot, with the change since the previous
+// snapshot and the part of it attributed to transactions
+type SeriesPoint struct {
+	LedgerIndex int               `json:"ledger_index"`
+	Date        time.Time         `json:"date"`
+	Obligation  string            `json:"obligation"`
+	HotWallets  map[string]string `json:"hot_wallets,omitempty"`
+	// Delta, Issued, Redeemed and Unattributed are relative to the previous point (absent on the first)
+	Delta        string   `json:"delta,omitempty"`
+	Issued       string   `json:"issued,omitempty"`
+	Redeemed     string   `json:"redeemed,omitempty"`
+	Unattributed string   `json:"unattributed,omitempty"`
+	Transactions []string `json:"transactions,omitempty"`
+}
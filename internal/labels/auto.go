@@ -0,0 +1,179 @@
+package labels
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/accounts"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// issuerConfidence is lower than the AMM one: gateway_balances only proves the account has obligations
+const issuerConfidence = 0.8
+
+var (
+	pending   = map[string]bool{}
+	pendingMu sync.Mutex
+)
+
+// Start labels the AMM accounts touched by every transaction stored through Ingest
+func Start(client *xrpl.HTTPClient) {
+	transactions.RegisterHandler(func(tx *transactions.Transaction) {
+		if tx.Meta == nil {
+			return
+		}
+		for account := range transactions.AMMAccounts(tx.Meta) {
+			if _, ok := Get(account); ok || !markPending(account) {
+				continue
+			}
+			go func(account string) {
+				defer unmarkPending(account)
+				if _, err := LabelAMM(client, account); err != nil {
+					log.Printf("⚠️ Não foi possível rotular a AMM %s: %v", account, err)
+				}
+			}(account)
+		}
+	})
+}
+
+func markPending(account string) bool {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	if pending[account] {
+		return false
+	}
+	pending[account] = true
+	return true
+}
+
+func unmarkPending(account string) {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	delete(pending, account)
+}
+
+// AutoLabel labels an account as an AMM (amm_info) or, failing that, as an issuer (gateway_balances).
+// It returns nil when neither applies
+func AutoLabel(client *xrpl.HTTPClient, account string) (*LabelSchema, error) {
+	label, err := LabelAMM(client, account)
+	if label != nil || (err != nil && !isRPCError(err)) {
+		return label, err
+	}
+	return LabelIssuer(client, account)
+}
+
+// LabelAMM labels an AMM account with its pool assets
+func LabelAMM(client *xrpl.HTTPClient, account string) (*LabelSchema, error) {
+	response, err := client.Post("", accounts.AMMInfoByAccountRequest{
+		Method: "amm_info",
+		Params: []accounts.AMMInfoByAccountParam{{AMMAccount: account, LedgerIndex: "validated"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var info accounts.AMMInfoByAccountResponse
+	if err := json.Unmarshal(response, &info); err != nil {
+		return nil, err
+	}
+	if info.Result.Error != "" {
+		return nil, &transactions.RPCError{Code: info.Result.Error, Message: info.Result.ErrorMessage}
+	}
+
+	pool := info.Result.AMM
+	return upsertAuto(LabelSchema{
+		Account:    account,
+		Name:       "AMM " + assetName(pool.Amount) + "/" + assetName(pool.Amount2),
+		Category:   CategoryAMM,
+		Source:     SourceAMMInfo,
+		Confidence: 1,
+		Details: map[string]interface{}{
+			"asset":       pool.Amount.Key(),
+			"asset2":      pool.Amount2.Key(),
+			"trading_fee": pool.TradingFee,
+		},
+	})
+}
+
+// LabelIssuer labels an account with outstanding obligations as an issuer of those currencies
+func LabelIssuer(client *xrpl.HTTPClient, account string) (*LabelSchema, error) {
+	response, err := accounts.FetchGatewayBalances(client, account, nil, "validated", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var balances struct {
+		Result struct {
+			Obligations  map[string]string `json:"obligations"`
+			Error        string            `json:"error"`
+			ErrorMessage string            `json:"error_message"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(response, &balances); err != nil {
+		return nil, err
+	}
+	if balances.Result.Error != "" {
+		return nil, &transactions.RPCError{Code: balances.Result.Error, Message: balances.Result.ErrorMessage}
+	}
+	if len(balances.Result.Obligations) == 0 {
+		return nil, nil
+	}
+
+	currencies := []string{}
+	for currency := range balances.Result.Obligations {
+		currencies = append(currencies, currencyName(currency))
+	}
+	sort.Strings(currencies)
+
+	return upsertAuto(LabelSchema{
+		Account:    account,
+		Name:       "Issuer " + strings.Join(currencies, ", "),
+		Category:   CategoryIssuer,
+		Source:     SourceGatewayBalances,
+		Confidence: issuerConfidence,
+		Details:    map[string]interface{}{"obligations": balances.Result.Obligations},
+	})
+}
+
+func isRPCError(err error) bool {
+	var rpcErr *transactions.RPCError
+	return errors.As(err, &rpcErr)
+}
+
+func assetName(amount xrpl.Amount) string {
+	if amount.IsXRP() {
+		return "XRP"
+	}
+	if amount.MPTIssuanceID != "" {
+		return amount.MPTIssuanceID
+	}
+	return currencyName(amount.Currency)
+}
+
+// currencyName decodes 40-hex currency codes holding ASCII text (e.g. "534F4C4F0000..." is "SOLO")
+func currencyName(currency string) string {
+	if len(currency) != 40 || strings.HasPrefix(currency, "03") {
+		return currency
+	}
+
+	decoded, err := hex.DecodeString(currency)
+	if err != nil {
+		return currency
+	}
+	name := strings.TrimRight(string(decoded), "\x00")
+	for _, c := range name {
+		if c < 0x20 || c > 0x7e {
+			return currency
+		}
+	}
+	if name == "" {
+		return currency
+	}
+	return name
+}
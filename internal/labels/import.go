@@ -0,0 +1,85 @@
+package labels
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ImportCSV imports labels from a CSV with a header row. The account and name columns are required;
+// category, confidence and source are optional (source defaults to "import")
+func ImportCSV(reader io.Reader) (*ImportResult, error) {
+	rows := csv.NewReader(reader)
+	rows.TrimLeadingSpace = true
+	rows.FieldsPerRecord = -1
+
+	header, err := rows.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"account", "name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	labels := []LabelSchema{}
+	result := &ImportResult{Errors: []string{}}
+	for line := 2; ; line++ {
+		record, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+
+		label := LabelSchema{
+			Account:  field(record, "account"),
+			Name:     field(record, "name"),
+			Category: field(record, "category"),
+			Source:   field(record, "source"),
+		}
+		if confidence := field(record, "confidence"); confidence != "" {
+			if label.Confidence, err = strconv.ParseFloat(confidence, 64); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("line %d: invalid confidence %q", line, confidence))
+				continue
+			}
+		}
+		labels = append(labels, label)
+	}
+
+	imported := ImportJSON(labels)
+	imported.Errors = append(result.Errors, imported.Errors...)
+	return imported, nil
+}
+
+// ImportJSON imports a list of labels; invalid entries are reported and skipped
+func ImportJSON(labels []LabelSchema) *ImportResult {
+	result := &ImportResult{Errors: []string{}}
+	for _, label := range labels {
+		if label.Source == "" {
+			label.Source = SourceImport
+		}
+		if _, err := Upsert(label); err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		result.Imported++
+	}
+	return result
+}
@@ -0,0 +1,204 @@
+package labels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrNotFound is returned when an account has no label
+var ErrNotFound = errors.New("label not found")
+
+var (
+	cache   = map[string]LabelSchema{}
+	cacheMu sync.RWMutex
+)
+
+// Load reads every label into memory, so responses can be annotated without a query per account
+func Load() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := database.GetLabelCollection().Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+
+	loaded := []LabelSchema{}
+	if err := cursor.All(ctx, &loaded); err != nil {
+		return err
+	}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cache = make(map[string]LabelSchema, len(loaded))
+	for _, label := range loaded {
+		cache[label.Account] = label
+	}
+	log.Printf("🏷️ %d rótulos de contas carregados", len(loaded))
+	return nil
+}
+
+// Validate checks the account, name, category and confidence of a label, applying defaults
+func Validate(label *LabelSchema) error {
+	label.Account = strings.TrimSpace(label.Account)
+	label.Name = strings.TrimSpace(label.Name)
+	label.Category = strings.ToLower(strings.TrimSpace(label.Category))
+
+	if !IsAddress(label.Account) {
+		return fmt.Errorf("invalid account %q", label.Account)
+	}
+	if label.Name == "" {
+		return fmt.Errorf("missing name for %s", label.Account)
+	}
+	if label.Category == "" {
+		label.Category = CategoryOther
+	}
+	if !Categories[label.Category] {
+		return fmt.Errorf("unknown category %q for %s", label.Category, label.Account)
+	}
+	if label.Confidence == 0 {
+		label.Confidence = 1
+	}
+	if label.Confidence < 0 || label.Confidence > 1 {
+		return fmt.Errorf("confidence of %s must be between 0 and 1", label.Account)
+	}
+	return nil
+}
+
+// Upsert creates or replaces the label of an account
+func Upsert(label LabelSchema) (*LabelSchema, error) {
+	if err := Validate(&label); err != nil {
+		return nil, err
+	}
+	if label.Source == "" {
+		label.Source = SourceManual
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	label.UpdatedAt = now
+	update := bson.M{
+		"$set": bson.M{
+			"name":       label.Name,
+			"category":   label.Category,
+			"source":     label.Source,
+			"confidence": label.Confidence,
+			"details":    label.Details,
+			"updated_at": now,
+		},
+		"$setOnInsert": bson.M{"account": label.Account, "created_at": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved LabelSchema
+	err := database.GetLabelCollection().FindOneAndUpdate(ctx, bson.M{"account": label.Account}, update, opts).Decode(&saved)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheMu.Lock()
+	cache[saved.Account] = saved
+	cacheMu.Unlock()
+	return &saved, nil
+}
+
+// upsertAuto saves an automatic label unless the account already has a manual or imported one
+func upsertAuto(label LabelSchema) (*LabelSchema, error) {
+	if existing, ok := Get(label.Account); ok && !isAutomatic(existing.Source) {
+		return &existing, nil
+	}
+	return Upsert(label)
+}
+
+func isAutomatic(source string) bool {
+	return source == SourceAMMInfo || source == SourceGatewayBalances
+}
+
+// Delete removes the label of an account
+func Delete(account string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := database.GetLabelCollection().DeleteOne(ctx, bson.M{"account": account})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+
+	cacheMu.Lock()
+	delete(cache, account)
+	cacheMu.Unlock()
+	return nil
+}
+
+// Get returns the label of an account from memory
+func Get(account string) (LabelSchema, bool) {
+	cacheMu.RLock()
+	defer cacheMu.RUnlock()
+	label, ok := cache[account]
+	return label, ok
+}
+
+// List returns the labels, optionally of one category, sorted by name
+func List(category string, limit int64) ([]LabelSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if category != "" {
+		filter["category"] = category
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetLabelCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := []LabelSchema{}
+	if err := cursor.All(ctx, &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// Annotate returns the labels of the given accounts that are known, keyed by account
+func Annotate(accounts []string) map[string]Label {
+	cacheMu.RLock()
+	defer cacheMu.RUnlock()
+
+	annotated := map[string]Label{}
+	for _, account := range accounts {
+		if label, ok := cache[account]; ok {
+			annotated[account] = Label{Name: label.Name, Category: label.Category, Source: label.Source, Confidence: label.Confidence}
+		}
+	}
+	return annotated
+}
+
+// IsAddress reports whether a string has the shape of a classic XRPL address
+func IsAddress(value string) bool {
+	return len(value) >= 25 && len(value) <= 35 && value[0] == 'r'
+}
+
+// IsNotFound reports whether err means the label or the document does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, mongo.ErrNoDocuments)
+}
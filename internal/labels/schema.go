@@ -0,0 +1,58 @@
+package labels
+
+import "time"
+
+// Categories of an entity
+const (
+	CategoryExchange    = "exchange"
+	CategoryIssuer      = "issuer"
+	CategoryAMM         = "amm"
+	CategoryMarketMaker = "market_maker"
+	CategoryOther       = "other"
+)
+
+// Sources of a label. Automatic labels never replace a manual or imported one
+const (
+	SourceManual          = "manual"
+	SourceImport          = "import"
+	SourceAMMInfo         = "amm_info"
+	SourceGatewayBalances = "gateway_balances"
+)
+
+// Categories lists the accepted categories
+var Categories = map[string]bool{
+	CategoryExchange:    true,
+	CategoryIssuer:      true,
+	CategoryAMM:         true,
+	CategoryMarketMaker: true,
+	CategoryOther:       true,
+}
+
+// LabelSchema define o rótulo (entidade) associado a uma conta
+type LabelSchema struct {
+	Account    string  `bson:"account" json:"account"`
+	Name       string  `bson:"name" json:"name"`
+	Category   string  `bson:"category" json:"category"`
+	Source     string  `bson:"source" json:"source"`
+	Confidence float64 `bson:"confidence" json:"confidence"` // Entre 0 e 1
+
+	// Details keeps what the automatic labelers found (AMM assets, issued currencies)
+	Details map[string]interface{} `bson:"details,omitempty" json:"details,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Label is the short form of a label attached to API responses
+type Label struct {
+	Name       string  `json:"name"`
+	Category   string  `json:"category"`
+	Source     string  `json:"source"`
+	Confidence float64 `json:"confidence"`
+}
+
+// ImportResult summarizes a bulk import
+type ImportResult struct {
+	Imported int      `json:"imported"`
+	Errors   []string `json:"errors"`
+}
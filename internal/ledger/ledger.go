@@ -6,6 +6,8 @@ import (
 	"time"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"sync"
 	
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
@@ -47,6 +49,51 @@ func FetchLedgerInfo(client *xrpl.HTTPClient, ledgerIndex string) (*LedgerRespon
 	return &ledgerResponse, nil
 }
 
+// FetchValidatedLedgerIndex returns the index of the latest validated ledger
+func FetchValidatedLedgerIndex(client *xrpl.HTTPClient) (int, error) {
+	request := LedgerRequest{
+		Method: "ledger",
+		Params: []LedgerParam{{LedgerIndex: "validated"}},
+	}
+
+	responseData, err := client.Post("", request)
+	if err != nil {
+		return 0, err
+	}
+
+	var response LedgerResponse
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(response.Result.Ledger.LedgerIndex)
+}
+
+// FetchLedgerTransactions fetches a ledger with all of its transactions expanded, including metadata
+func FetchLedgerTransactions(client *xrpl.HTTPClient, ledgerIndex string) (*LedgerTransactionsResponse, error) {
+	request := LedgerRequest{
+		Method: "ledger",
+		Params: []LedgerParam{{
+			LedgerIndex:  ledgerIndex,
+			Transactions: true,
+			Expand:       true,
+		}},
+	}
+
+	responseData, err := client.Post("", request)
+	if err != nil {
+		log.Printf("❌ Failed to fetch ledger transactions: %v", err)
+		return nil, err
+	}
+
+	var response LedgerTransactionsResponse
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		log.Printf("❌ Error unmarshalling ledger transactions: %v", err)
+		return nil, err
+	}
+
+	return &response, nil
+}
+
 func StreamLedger(wsClient *xrpl.WebSocketClient, httpClient *xrpl.HTTPClient, callback func(*LedgerSubscribeClosedResponse), stopChan chan struct{}) error {
 	request := map[string]interface{}{
 		"id":      "1",
@@ -59,52 +106,94 @@ func StreamLedger(wsClient *xrpl.WebSocketClient, httpClient *xrpl.HTTPClient, c
 		return err
 	}
 
-	go func() {
-		for {
-			select {
-			case <-stopChan:
-				log.Println("⛔ Encerrando o streaming de ledgers.")
-				wsClient.Connection.Close()
-				return
-			default:
-				wsClient.ReadMessages(func(msg []byte) {
-					var closedResponse LedgerSubscribeClosedResponse
-
-					// Interpretar como mensagem de ledger fechado
-					if err := json.Unmarshal(msg, &closedResponse); err == nil && closedResponse.Type == "ledgerClosed" {
-						log.Printf("✅ Novo ledger recebido: %+v", closedResponse)
-
-						// Chamar FetchLedgerInfo para obter totalCoins
-						ledgerIndex := fmt.Sprintf("%d", closedResponse.LedgerIndex)
-						ledgerInfo, err := FetchLedgerInfo(httpClient, ledgerIndex)
-						if err != nil {
-							log.Printf("❌ Erro ao buscar informações adicionais do ledger: %v", err)
-						} else {
-							// Extraindo o campo totalCoins
-							totalCoins := ledgerInfo.Result.Ledger.TotalCoins
-							log.Printf("✅ TotalCoins extraído: %s", totalCoins)
-
-							// Adicionar totalCoins aos dados WebSocket
-							closedResponse.TotalCoins = totalCoins
-						}
-
-						// Salvar no banco de dados
-						if err := SaveLedgerToDB(&closedResponse); err != nil {
-							log.Printf("❌ Erro ao salvar no banco de dados: %v", err)
-						}
-						
-						// Invocar o callback com os dados atualizados
-						callback(&closedResponse)
-					} else {
-						log.Printf("⚠️ Mensagem desconhecida recebida: %s", string(msg))
-					}
-				})
-			}
+	remove := wsClient.AddHandler(func(msg []byte) {
+		var closedResponse LedgerSubscribeClosedResponse
+
+		// Interpretar como mensagem de ledger fechado
+		if err := json.Unmarshal(msg, &closedResponse); err != nil || closedResponse.Type != "ledgerClosed" {
+			return
 		}
+		log.Printf("✅ Novo ledger recebido: %+v", closedResponse)
+		go enrichAndSave(httpClient, closedResponse, callback)
+	})
+
+	// The connection is shared with the other streams: stopping removes this handler and releases
+	// this viewer's share of the ledger subscription
+	go func() {
+		<-stopChan
+		log.Println("⛔ Encerrando o streaming de ledgers.")
+		remove()
+		unsubscribeLedgers(wsClient)
 	}()
 	return nil
 }
 
+// enrichAndSave runs off the shared read loop, since it waits on an HTTP request
+func enrichAndSave(httpClient *xrpl.HTTPClient, closedResponse LedgerSubscribeClosedResponse, callback func(*LedgerSubscribeClosedResponse)) {
+	// Chamar FetchLedgerInfo para obter totalCoins
+	ledgerIndex := fmt.Sprintf("%d", closedResponse.LedgerIndex)
+	ledgerInfo, err := FetchLedgerInfo(httpClient, ledgerIndex)
+	if err != nil {
+		log.Printf("❌ Erro ao buscar informações adicionais do ledger: %v", err)
+	} else {
+		// Extraindo o campo totalCoins
+		totalCoins := ledgerInfo.Result.Ledger.TotalCoins
+		log.Printf("✅ TotalCoins extraído: %s", totalCoins)
+
+		// Adicionar totalCoins aos dados WebSocket
+		closedResponse.TotalCoins = totalCoins
+	}
+
+	// Salvar no banco de dados
+	if err := SaveLedgerToDB(&closedResponse); err != nil {
+		log.Printf("❌ Erro ao salvar no banco de dados: %v", err)
+	}
+
+	// Invocar o callback com os dados atualizados
+	callback(&closedResponse)
+}
+
+// SubscribeValidatedLedgers subscribes to the ledger stream and calls handler for every
+// ledgerClosed message. The returned function removes the handler and releases the subscription,
+// which stays upstream while other consumers hold it
+func SubscribeValidatedLedgers(wsClient *xrpl.WebSocketClient, handler func(*LedgerSubscribeClosedResponse)) (func(), error) {
+	request := map[string]interface{}{
+		"id":      "subscribe_validated_ledgers",
+		"command": "subscribe",
+		"streams": []string{"ledger"},
+	}
+	if err := wsClient.Subscribe(request); err != nil {
+		return nil, err
+	}
+
+	remove := wsClient.AddHandler(func(msg []byte) {
+		var closed LedgerSubscribeClosedResponse
+		if err := json.Unmarshal(msg, &closed); err != nil || closed.Type != "ledgerClosed" {
+			return
+		}
+		handler(&closed)
+	})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			remove()
+			unsubscribeLedgers(wsClient)
+		})
+	}, nil
+}
+
+func unsubscribeLedgers(wsClient *xrpl.WebSocketClient) {
+	request := map[string]interface{}{
+		"id":      "unsubscribe_ledgers",
+		"command": "unsubscribe",
+		"streams": []string{"ledger"},
+	}
+	if err := wsClient.Subscribe(request); err != nil {
+		log.Printf("⚠️ Erro ao cancelar a inscrição de ledgers: %v", err)
+	}
+}
+
 
 
 func SaveLedgerToDB(data *LedgerSubscribeClosedResponse) error {
@@ -189,49 +278,39 @@ func FetchLedgerData(client *xrpl.HTTPClient, ledgerHash string, binary bool, li
 }
 
 // StreamLedgerClosed fetches the most recent closed ledger via WebSocket
-func StreamLedgerClosed(wsClient *xrpl.WebSocketClient, callback func(*LedgerClosedWSResponse)) error {
+func StreamLedgerClosed(wsClient *xrpl.WebSocketClient, callback func(*LedgerClosedWSResponse)) (func(), error) {
 	request := LedgerClosedWSRequest{
 		ID:      2,
 		Command: "ledger_closed",
 	}
 
-	err := wsClient.Subscribe(request)
-	if err != nil {
-		return err
-	}
-
-	wsClient.ReadMessages(func(msg []byte) {
+	// Send the command and read its response
+	return wsClient.Request(request, func(msg []byte) {
 		var response LedgerClosedWSResponse
 		if err := json.Unmarshal(msg, &response); err == nil {
 			callback(&response)
 		}
 	})
-	return nil
 }
 
 // StreamLedgerCurrent fetches the current ledger index via WebSocket
-func StreamLedgerCurrent(wsClient *xrpl.WebSocketClient, callback func(*LedgerCurrentWSResponse)) error {
+func StreamLedgerCurrent(wsClient *xrpl.WebSocketClient, callback func(*LedgerCurrentWSResponse)) (func(), error) {
 	request := LedgerCurrentWSRequest{
 		ID:      3,
 		Command: "ledger_current",
 	}
 
-	err := wsClient.Subscribe(request)
-	if err != nil {
-		return err
-	}
-
-	wsClient.ReadMessages(func(msg []byte) {
+	// Send the command and read its response
+	return wsClient.Request(request, func(msg []byte) {
 		var response LedgerCurrentWSResponse
 		if err := json.Unmarshal(msg, &response); err == nil {
 			callback(&response)
 		}
 	})
-	return nil
 }
 
 // StreamLedgerData fetches ledger data via WebSocket
-func StreamLedgerData(wsClient *xrpl.WebSocketClient, ledgerHash string, binary bool, limit int, marker string, callback func(*LedgerDataWSResponse)) error {
+func StreamLedgerData(wsClient *xrpl.WebSocketClient, ledgerHash string, binary bool, limit int, marker string, callback func(*LedgerDataWSResponse)) (func(), error) {
 	request := LedgerDataWSRequest{
 		ID:          4, // Unique ID for the request
 		Command:     "ledger_data",
@@ -241,17 +320,12 @@ func StreamLedgerData(wsClient *xrpl.WebSocketClient, ledgerHash string, binary
 		Marker:      marker,
 	}
 
-	err := wsClient.Subscribe(request)
-	if err != nil {
-		return err
-	}
-
-	wsClient.ReadMessages(func(msg []byte) {
+	// Send the command and read its response
+	return wsClient.Request(request, func(msg []byte) {
 		var response LedgerDataWSResponse
 		if err := json.Unmarshal(msg, &response); err == nil {
 			callback(&response)
 		}
 	})
-	return nil
 }
 
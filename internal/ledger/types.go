@@ -1,5 +1,7 @@
 package ledger
 
+import "encoding/json"
+
 // ---------- HTTP Types ----------
 
 // LedgerRequest defines the structure for HTTP /ledger requests
@@ -31,6 +33,20 @@ type LedgerResponse struct {
 	} `json:"result"`
 }
 
+// LedgerTransactionsResponse defines the HTTP response for /ledger with expanded transactions.
+// Each transaction is kept raw (tx fields plus "hash" and "metaData") so callers can decode what they need
+type LedgerTransactionsResponse struct {
+	Result struct {
+		Ledger struct {
+			CloseTime    int64             `json:"close_time"`
+			LedgerHash   string            `json:"ledger_hash"`
+			LedgerIndex  string            `json:"ledger_index"`
+			Transactions []json.RawMessage `json:"transactions"`
+		} `json:"ledger"`
+		Validated bool `json:"validated"`
+	} `json:"result"`
+}
+
 type LedgerClosedRequest struct {
 	Method string   `json:"method"`
 	Params []struct{} `json:"params"`
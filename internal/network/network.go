@@ -0,0 +1,310 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/ledger"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Flag ledgers happen every 256 ledgers; amendment and fee pseudo-transactions land in the ledger right after
+const FlagLedgerInterval = 256
+
+var (
+	current   Settings
+	currentMu sync.RWMutex
+)
+
+// FetchAmendments fetches the Amendments ledger object via HTTP
+func FetchAmendments(client *xrpl.HTTPClient, ledgerIndex string) (*AmendmentsResponse, error) {
+	responseData, err := fetchLedgerEntry(client, AmendmentsObjectID, ledgerIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AmendmentsResponse
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// FetchFeeSettings fetches the FeeSettings ledger object via HTTP
+func FetchFeeSettings(client *xrpl.HTTPClient, ledgerIndex string) (*FeeSettingsResponse, error) {
+	responseData, err := fetchLedgerEntry(client, FeeSettingsObjectID, ledgerIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	var response FeeSettingsResponse
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func fetchLedgerEntry(client *xrpl.HTTPClient, index string, ledgerIndex string) ([]byte, error) {
+	if ledgerIndex == "" {
+		ledgerIndex = "validated"
+	}
+
+	request := LedgerEntryRequest{
+		Method: "ledger_entry",
+		Params: []LedgerEntryParam{{Index: index, LedgerIndex: ledgerIndex}},
+	}
+
+	return client.Post("", request)
+}
+
+// CurrentSettings returns the latest fee and reserve values seen by the service
+func CurrentSettings() Settings {
+	currentMu.RLock()
+	defer currentMu.RUnlock()
+	return current
+}
+
+// Start keeps the current settings up to date and records amendment and fee votes from the
+// ledger stream, independently of any ledger viewer
+func Start(wsClient *xrpl.WebSocketClient, httpClient *xrpl.HTTPClient) error {
+	_, err := ledger.SubscribeValidatedLedgers(wsClient, func(closed *ledger.LedgerSubscribeClosedResponse) {
+		UpdateFromLedger(closed)
+		go func(ledgerIndex int) {
+			if err := ProcessLedger(httpClient, ledgerIndex); err != nil {
+				log.Printf("❌ Erro ao processar configurações da rede no ledger %d: %v", ledgerIndex, err)
+			}
+		}(closed.LedgerIndex)
+	})
+	return err
+}
+
+// UpdateFromLedger refreshes the current settings from a ledgerClosed stream message
+func UpdateFromLedger(data *ledger.LedgerSubscribeClosedResponse) {
+	if data.ReserveBase == 0 {
+		return
+	}
+
+	currentMu.Lock()
+	defer currentMu.Unlock()
+
+	if data.LedgerIndex < current.LedgerIndex {
+		return
+	}
+	current.LedgerIndex = data.LedgerIndex
+	current.BaseFeeDrops = int64(data.FeeBase)
+	current.ReserveBaseDrops = int64(data.ReserveBase)
+	current.ReserveIncDrops = int64(data.ReserveInc)
+}
+
+// SyncFeeSettings loads the FeeSettings object and uses it as the current settings
+func SyncFeeSettings(client *xrpl.HTTPClient) error {
+	response, err := FetchFeeSettings(client, "validated")
+	if err != nil {
+		return err
+	}
+
+	fees, err := parseFeeSettings(response.Result.Node)
+	if err != nil {
+		return err
+	}
+
+	currentMu.Lock()
+	defer currentMu.Unlock()
+
+	if response.Result.LedgerIndex >= current.LedgerIndex {
+		current = Settings{
+			LedgerIndex:       response.Result.LedgerIndex,
+			BaseFeeDrops:      fees.BaseFeeDrops,
+			ReserveBaseDrops:  fees.ReserveBaseDrops,
+			ReserveIncDrops:   fees.ReserveIncDrops,
+			ReferenceFeeUnits: fees.ReferenceFeeUnits,
+		}
+	}
+	return nil
+}
+
+// ProcessLedger records EnableAmendment and SetFee pseudo-transactions of a voting ledger
+func ProcessLedger(client *xrpl.HTTPClient, ledgerIndex int) error {
+	// Only the ledger following a flag ledger carries pseudo-transactions
+	if ledgerIndex%FlagLedgerInterval != 1 {
+		return nil
+	}
+
+	response, err := ledger.FetchLedgerTransactions(client, fmt.Sprintf("%d", ledgerIndex))
+	if err != nil {
+		return err
+	}
+	closeTime := xrpl.RippleTimeToTime(response.Result.Ledger.CloseTime)
+
+	for _, raw := range response.Result.Ledger.Transactions {
+		var tx PseudoTransaction
+		if err := json.Unmarshal(raw, &tx); err != nil {
+			log.Printf("⚠️ Erro ao interpretar transação do ledger %d: %v", ledgerIndex, err)
+			continue
+		}
+
+		switch tx.TransactionType {
+		case "EnableAmendment":
+			event := AmendmentEventSchema{
+				Amendment:   tx.Amendment,
+				Event:       amendmentEvent(tx.Flags),
+				LedgerIndex: ledgerIndex,
+				TxHash:      tx.Hash,
+				CloseTime:   closeTime,
+				CreatedAt:   time.Now(),
+			}
+			if err := SaveAmendmentEvent(&event); err != nil {
+				log.Printf("❌ Erro ao salvar evento de amendment: %v", err)
+			}
+		case "SetFee":
+			fees, err := parseFeeSettings(tx.FeeSettingsNode)
+			if err != nil {
+				log.Printf("⚠️ Erro ao interpretar SetFee %s: %v", tx.Hash, err)
+				continue
+			}
+			fees.LedgerIndex = ledgerIndex
+			fees.TxHash = tx.Hash
+			fees.CloseTime = closeTime
+			fees.CreatedAt = time.Now()
+
+			if err := SaveFeeSettings(fees); err != nil {
+				log.Printf("❌ Erro ao salvar mudança de fee: %v", err)
+			}
+
+			currentMu.Lock()
+			if ledgerIndex >= current.LedgerIndex {
+				current = Settings{
+					LedgerIndex:       ledgerIndex,
+					BaseFeeDrops:      fees.BaseFeeDrops,
+					ReserveBaseDrops:  fees.ReserveBaseDrops,
+					ReserveIncDrops:   fees.ReserveIncDrops,
+					ReferenceFeeUnits: fees.ReferenceFeeUnits,
+				}
+			}
+			currentMu.Unlock()
+		}
+	}
+	return nil
+}
+
+func amendmentEvent(flags uint32) string {
+	switch {
+	case flags&TfGotMajority != 0:
+		return AmendmentGotMajority
+	case flags&TfLostMajority != 0:
+		return AmendmentLostMajority
+	default:
+		return AmendmentEnabled
+	}
+}
+
+// parseFeeSettings normalizes the legacy (hex/fee units) and XRPFees (drops) representations
+func parseFeeSettings(node FeeSettingsNode) (*FeeSettingsSchema, error) {
+	fees := &FeeSettingsSchema{ReferenceFeeUnits: node.ReferenceFeeUnits}
+
+	if node.BaseFeeDrops != "" {
+		var err error
+		if fees.BaseFeeDrops, err = strconv.ParseInt(node.BaseFeeDrops, 10, 64); err != nil {
+			return nil, err
+		}
+		if fees.ReserveBaseDrops, err = strconv.ParseInt(node.ReserveBaseDrops, 10, 64); err != nil {
+			return nil, err
+		}
+		if fees.ReserveIncDrops, err = strconv.ParseInt(node.ReserveIncrementDrops, 10, 64); err != nil {
+			return nil, err
+		}
+		return fees, nil
+	}
+
+	baseFee, err := strconv.ParseInt(node.BaseFee, 16, 64) // UInt64 fields are hex encoded
+	if err != nil {
+		return nil, err
+	}
+	fees.BaseFeeDrops = baseFee
+	fees.ReserveBaseDrops = node.ReserveBase
+	fees.ReserveIncDrops = node.ReserveIncrement
+	return fees, nil
+}
+
+// SaveAmendmentEvent salva um evento de amendment no banco de dados
+func SaveAmendmentEvent(event *AmendmentEventSchema) error {
+	collection := database.GetAmendmentCollection()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"amendment": event.Amendment, "event": event.Event, "ledger_index": event.LedgerIndex}
+	_, err := collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": event}, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ Evento de amendment salvo: %s %s (ledger %d)", event.Amendment, event.Event, event.LedgerIndex)
+	return nil
+}
+
+// SaveFeeSettings salva uma mudança de fee/reserve no banco de dados
+func SaveFeeSettings(fees *FeeSettingsSchema) error {
+	collection := database.GetFeeSettingsCollection()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"tx_hash": fees.TxHash}
+	_, err := collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": fees}, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ Mudança de fee salva: %+v", fees)
+	return nil
+}
+
+// GetAmendmentHistory returns amendment events, newest first, optionally filtered by amendment ID
+func GetAmendmentHistory(amendment string, limit int64) ([]AmendmentEventSchema, error) {
+	filter := bson.M{}
+	if amendment != "" {
+		filter["amendment"] = amendment
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: -1}}).SetLimit(limit)
+	cursor, err := database.GetAmendmentCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []AmendmentEventSchema{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetFeeHistory returns fee and reserve changes, newest first
+func GetFeeHistory(limit int64) ([]FeeSettingsSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: -1}}).SetLimit(limit)
+	cursor, err := database.GetFeeSettingsCollection().Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []FeeSettingsSchema{}
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,25 @@
+package network
+
+import "time"
+
+// AmendmentEventSchema define os campos de um evento de amendment salvo no MongoDB
+type AmendmentEventSchema struct {
+	Amendment   string    `bson:"amendment" json:"amendment"`
+	Event       string    `bson:"event" json:"event"`
+	LedgerIndex int       `bson:"ledger_index" json:"ledger_index"`
+	TxHash      string    `bson:"tx_hash" json:"tx_hash"`
+	CloseTime   time.Time `bson:"close_time" json:"close_time"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+}
+
+// FeeSettingsSchema define os campos de uma mudança de fee/reserve salva no MongoDB
+type FeeSettingsSchema struct {
+	BaseFeeDrops      int64     `bson:"base_fee_drops" json:"base_fee_drops"`
+	ReserveBaseDrops  int64     `bson:"reserve_base_drops" json:"reserve_base_drops"`
+	ReserveIncDrops   int64     `bson:"reserve_inc_drops" json:"reserve_inc_drops"`
+	ReferenceFeeUnits int       `bson:"reference_fee_units,omitempty" json:"reference_fee_units,omitempty"`
+	LedgerIndex       int       `bson:"ledger_index" json:"ledger_index"`
+	TxHash            string    `bson:"tx_hash" json:"tx_hash"`
+	CloseTime         time.Time `bson:"close_time" json:"close_time"`
+	CreatedAt         time.Time `bson:"created_at" json:"created_at"`
+}
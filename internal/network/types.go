@@ -0,0 +1,93 @@
+package network
+
+// Well-known ledger entry IDs of the singleton network settings objects
+const (
+	AmendmentsObjectID  = "7DB0788C020F02780A673DC74757F23823FA3014C1866E72CC4CD8B226CD6EF4"
+	FeeSettingsObjectID = "4BC50C9B0D8515D3EAAE1E74B29A95804346C491EE1A95BF25E4AAB854A6A651"
+)
+
+// EnableAmendment pseudo-transaction flags
+const (
+	TfGotMajority  = 0x00010000
+	TfLostMajority = 0x00020000
+)
+
+// Amendment lifecycle events recorded in the database
+const (
+	AmendmentGotMajority  = "got_majority"
+	AmendmentLostMajority = "lost_majority"
+	AmendmentEnabled      = "enabled"
+)
+
+// ---------- HTTP Types ----------
+
+// LedgerEntryRequest defines the structure for HTTP /ledger_entry requests
+type LedgerEntryRequest struct {
+	Method string             `json:"method"`
+	Params []LedgerEntryParam `json:"params"`
+}
+
+type LedgerEntryParam struct {
+	Index       string `json:"index"`
+	LedgerIndex string `json:"ledger_index,omitempty"`
+}
+
+// AmendmentsResponse defines the ledger_entry response for the Amendments object
+type AmendmentsResponse struct {
+	Result struct {
+		Index       string         `json:"index"`
+		LedgerIndex int            `json:"ledger_index"`
+		Node        AmendmentsNode `json:"node"`
+		Validated   bool           `json:"validated"`
+	} `json:"result"`
+}
+
+type AmendmentsNode struct {
+	Amendments []string `json:"Amendments"`
+	Majorities []struct {
+		Majority struct {
+			Amendment string `json:"Amendment"`
+			CloseTime int64  `json:"CloseTime"`
+		} `json:"Majority"`
+	} `json:"Majorities"`
+}
+
+// FeeSettingsResponse defines the ledger_entry response for the FeeSettings object
+type FeeSettingsResponse struct {
+	Result struct {
+		Index       string          `json:"index"`
+		LedgerIndex int             `json:"ledger_index"`
+		Node        FeeSettingsNode `json:"node"`
+		Validated   bool            `json:"validated"`
+	} `json:"result"`
+}
+
+// FeeSettingsNode covers both the legacy fields and the XRPFees amendment fields
+type FeeSettingsNode struct {
+	BaseFee               string `json:"BaseFee,omitempty"`
+	ReferenceFeeUnits     int    `json:"ReferenceFeeUnits,omitempty"`
+	ReserveBase           int64  `json:"ReserveBase,omitempty"`
+	ReserveIncrement      int64  `json:"ReserveIncrement,omitempty"`
+	BaseFeeDrops          string `json:"BaseFeeDrops,omitempty"`
+	ReserveBaseDrops      string `json:"ReserveBaseDrops,omitempty"`
+	ReserveIncrementDrops string `json:"ReserveIncrementDrops,omitempty"`
+}
+
+// PseudoTransaction holds the fields of EnableAmendment and SetFee pseudo-transactions
+type PseudoTransaction struct {
+	TransactionType string `json:"TransactionType"`
+	Hash            string `json:"hash"`
+	Amendment       string `json:"Amendment,omitempty"`
+	Flags           uint32 `json:"Flags"`
+	LedgerSequence  int    `json:"LedgerSequence"`
+	FeeSettingsNode
+}
+
+// Settings represents the current network settings known to the service
+type Settings struct {
+	LedgerIndex       int   `json:"ledger_index"`
+	BaseFeeDrops      int64 `json:"base_fee_drops"`
+	ReserveBaseDrops  int64 `json:"reserve_base_drops"`
+	ReserveIncDrops   int64 `json:"reserve_inc_drops"`
+	ReferenceFeeUnits int   `json:"reference_fee_units,omitempty"`
+}
@@ -19,7 +19,7 @@ func FetchAggregatePrice(client *xrpl.HTTPClient, params GetAggregatePriceParams
 }
 
 // StreamAggregatePrice streams aggregate price data using WebSocket.
-func StreamAggregatePrice(wsClient *xrpl.WebSocketClient, params GetAggregatePriceParams, callback func(*GetAggregatePriceResponse)) error {
+func StreamAggregatePrice(wsClient *xrpl.WebSocketClient, params GetAggregatePriceParams, callback func(*GetAggregatePriceResponse)) (func(), error) {
 	request := GetAggregatePriceParams{
 		LedgerIndex: params.LedgerIndex,
 		BaseAsset:   params.BaseAsset,
@@ -28,15 +28,11 @@ func StreamAggregatePrice(wsClient *xrpl.WebSocketClient, params GetAggregatePri
 		Oracles:     params.Oracles,
 	}
 
-	if err := wsClient.Subscribe(request); err != nil {
-		return err
-	}
-
-	wsClient.ReadMessages(func(msg []byte) {
+	// Send the command and read its response
+	return wsClient.Request(request, func(msg []byte) {
 		var response GetAggregatePriceResponse
 		if err := json.Unmarshal(msg, &response); err == nil {
 			callback(&response)
 		}
 	})
-	return nil
 }
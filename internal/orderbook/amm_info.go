@@ -22,7 +22,7 @@ func FetchAMMInfo(client *xrpl.HTTPClient, ammAccount string, asset, asset2 Asse
 }
 
 // StreamAMMInfo streams AMM information via WebSocket
-func StreamAMMInfo(wsClient *xrpl.WebSocketClient, ammAccount string, asset, asset2 AssetParam, callback func(*AMMInfoWSResponse)) error {
+func StreamAMMInfo(wsClient *xrpl.WebSocketClient, ammAccount string, asset, asset2 AssetParam, callback func(*AMMInfoWSResponse)) (func(), error) {
 	request := AMMInfoWSRequest{
 		ID:         1,
 		Command:    "amm_info",
@@ -31,16 +31,11 @@ func StreamAMMInfo(wsClient *xrpl.WebSocketClient, ammAccount string, asset, ass
 		Asset2:     asset2,
 	}
 
-	err := wsClient.Subscribe(request)
-	if err != nil {
-		return err
-	}
-
-	wsClient.ReadMessages(func(msg []byte) {
+	// Send the command and read its response
+	return wsClient.Request(request, func(msg []byte) {
 		var response AMMInfoWSResponse
 		if err := json.Unmarshal(msg, &response); err == nil {
 			callback(&response)
 		}
 	})
-	return nil
 }
@@ -20,23 +20,18 @@ func FetchBookChanges(client *xrpl.HTTPClient, ledgerIndex int) ([]byte, error)
 }
 
 // StreamBookChanges streams book changes via WebSocket
-func StreamBookChanges(wsClient *xrpl.WebSocketClient, ledgerIndex int, callback func(*BookChangesWSResponse)) error {
+func StreamBookChanges(wsClient *xrpl.WebSocketClient, ledgerIndex int, callback func(*BookChangesWSResponse)) (func(), error) {
 	request := BookChangesWSRequest{
 		ID:          2,
 		Command:     "book_changes",
 		LedgerIndex: ledgerIndex,
 	}
 
-	err := wsClient.Subscribe(request)
-	if err != nil {
-		return err
-	}
-
-	wsClient.ReadMessages(func(msg []byte) {
+	// Send the command and read its response
+	return wsClient.Request(request, func(msg []byte) {
 		var response BookChangesWSResponse
 		if err := json.Unmarshal(msg, &response); err == nil {
 			callback(&response)
 		}
 	})
-	return nil
 }
@@ -19,7 +19,7 @@ func FetchBookOffers(client *xrpl.HTTPClient, params BookOffersParams) ([]byte,
 }
 
 // StreamBookOffers streams book offers using WebSocket.
-func StreamBookOffers(wsClient *xrpl.WebSocketClient, params BookOffersParams, callback func(*BookOffersWSResponse)) error {
+func StreamBookOffers(wsClient *xrpl.WebSocketClient, params BookOffersParams, callback func(*BookOffersWSResponse)) (func(), error) {
 	request := BookOffersWSRequest{
 		ID:        4,
 		Command:   "book_offers",
@@ -29,15 +29,11 @@ func StreamBookOffers(wsClient *xrpl.WebSocketClient, params BookOffersParams, c
 		Limit:     params.Limit,
 	}
 
-	if err := wsClient.Subscribe(request); err != nil {
-		return err
-	}
-
-	wsClient.ReadMessages(func(msg []byte) {
+	// Send the command and read its response
+	return wsClient.Request(request, func(msg []byte) {
 		var response BookOffersWSResponse
 		if err := json.Unmarshal(msg, &response); err == nil {
 			callback(&response)
 		}
 	})
-	return nil
 }
@@ -0,0 +1,138 @@
+package paths
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// FetchRipplePathFind runs a one-shot path search via HTTP against the current ledger
+func FetchRipplePathFind(client *xrpl.HTTPClient, request QuoteRequest) ([]byte, error) {
+	params := RipplePathFindParam{
+		SourceAccount:      request.SourceAccount,
+		DestinationAccount: request.DestinationAccount,
+		DestinationAmount:  request.DestinationAmount.RPCValue(),
+		SourceCurrencies:   request.SourceCurrencies,
+		LedgerIndex:        "current",
+	}
+	if request.SendMax != nil {
+		params.SendMax = request.SendMax.RPCValue()
+	}
+
+	payload := RipplePathFindRequest{
+		Method: "ripple_path_find",
+		Params: []RipplePathFindParam{params},
+	}
+
+	return client.Post("", payload)
+}
+
+// FindPaths quotes a payment with ripple_path_find
+func FindPaths(client *xrpl.HTTPClient, request QuoteRequest) (*Quote, error) {
+	if err := validate(&request); err != nil {
+		return nil, err
+	}
+
+	response, err := FetchRipplePathFind(client, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded RipplePathFindResponse
+	if err := json.Unmarshal(response, &decoded); err != nil {
+		return nil, err
+	}
+	if decoded.Result.Error != "" {
+		return nil, &transactions.RPCError{Code: decoded.Result.Error, Message: decoded.Result.ErrorMessage}
+	}
+
+	quote := buildQuote(request, &decoded.Result.PathFindResult)
+	quote.FullReply = true
+	return quote, nil
+}
+
+// validate checks a quote request and applies the default slippage
+func validate(request *QuoteRequest) error {
+	if request.SourceAccount == "" || request.DestinationAccount == "" {
+		return errors.New("source_account and destination_account are required")
+	}
+	if request.DestinationAmount.Currency == "" || request.DestinationAmount.Value == "" {
+		return errors.New("destination_amount with currency and value is required")
+	}
+	if !request.DestinationAmount.IsXRP() && request.DestinationAmount.Issuer == "" {
+		return errors.New("issuer is required for token amounts")
+	}
+	if request.SlippagePercent < 0 {
+		return errors.New("slippage_percent cannot be negative")
+	}
+	if request.SlippagePercent == 0 {
+		request.SlippagePercent = DefaultSlippagePercent
+	}
+	return nil
+}
+
+func buildQuote(request QuoteRequest, result *PathFindResult) *Quote {
+	quote := &Quote{
+		SourceAccount:      request.SourceAccount,
+		DestinationAccount: request.DestinationAccount,
+		DestinationAmount:  request.DestinationAmount,
+		SlippagePercent:    request.SlippagePercent,
+		Alternatives:       []QuoteAlternative{},
+		FullReply:          result.FullReply,
+		LedgerIndex:        result.LedgerCurrentIndex,
+	}
+
+	for _, alternative := range result.Alternatives {
+		delivered := request.DestinationAmount
+		if alternative.DestinationAmount != nil {
+			delivered = *alternative.DestinationAmount
+		}
+
+		paths := alternative.PathsComputed
+		if paths == nil {
+			paths = [][]PathStep{}
+		}
+
+		entry := QuoteAlternative{
+			SourceAmount:      alternative.SourceAmount,
+			SendMax:           SendMax(alternative.SourceAmount, request.SlippagePercent),
+			Paths:             paths,
+			DestinationAmount: alternative.DestinationAmount,
+		}
+		if spent := alternative.SourceAmount.Float(); spent > 0 {
+			entry.Rate = delivered.Float() / spent
+		}
+		quote.Alternatives = append(quote.Alternatives, entry)
+	}
+	return quote
+}
+
+// SendMax adds a slippage margin to a source cost. XRP is rounded up to whole drops and
+// tokens are kept to the 15 significant digits the ledger stores
+func SendMax(cost xrpl.Amount, slippagePercent float64) xrpl.Amount {
+	value, ok := new(big.Rat).SetString(cost.Value)
+	if !ok {
+		return cost
+	}
+
+	// Rationals keep the margin exact, so 1% over 20 XRP is exactly 20.2 XRP
+	factor, _ := new(big.Rat).SetString(strconv.FormatFloat(1+slippagePercent/100, 'f', -1, 64))
+	value.Mul(value, factor)
+
+	sendMax := cost
+	if cost.IsXRP() {
+		drops, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+		if remainder.Sign() > 0 {
+			drops.Add(drops, big.NewInt(1))
+		}
+		sendMax.Value = drops.String()
+		return sendMax
+	}
+
+	sendMax.Value = new(big.Float).SetPrec(128).SetRat(value).Text('g', 15)
+	return sendMax
+}
@@ -0,0 +1,126 @@
+package paths
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// sessionSeq numbers the sessions in the logs and in their command IDs
+var sessionSeq atomic.Int64
+
+// session is a path_find session. rippled allows a single session per connection, so each
+// session dials its own WebSocket connection and closes it when it ends
+type session struct {
+	id      string
+	request QuoteRequest
+	client  *xrpl.WebSocketClient
+	remove  func()
+
+	mu      sync.Mutex
+	updates chan *Quote
+	closed  bool
+}
+
+// Open creates a path_find session on a new connection to url and returns a channel with its
+// updated quotes and a function to close it. The channel is closed when the session ends
+func Open(url string, request QuoteRequest) (<-chan *Quote, func(), error) {
+	if err := validate(&request); err != nil {
+		return nil, nil, err
+	}
+
+	client, err := xrpl.NewWebSocketClient(url)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s := &session{
+		id:      "path_find_" + strconv.FormatInt(sessionSeq.Add(1), 10),
+		request: request,
+		client:  client,
+		updates: make(chan *Quote, 8),
+	}
+
+	command := PathFindWSRequest{
+		ID:                 s.id,
+		Command:            "path_find",
+		Subcommand:         "create",
+		SourceAccount:      request.SourceAccount,
+		DestinationAccount: request.DestinationAccount,
+		DestinationAmount:  request.DestinationAmount.RPCValue(),
+		SourceCurrencies:   request.SourceCurrencies,
+	}
+	if request.SendMax != nil {
+		command.SendMax = request.SendMax.RPCValue()
+	}
+
+	// Registered before the command is sent so its response is not missed
+	s.remove = client.AddHandler(s.HandleMessage)
+	if err := client.Subscribe(command); err != nil {
+		s.close()
+		return nil, nil, err
+	}
+	return s.updates, s.close, nil
+}
+
+// close ends the session; closing its connection also ends it upstream
+func (s *session) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.end()
+}
+
+func (s *session) end() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.updates)
+	s.remove()
+	if err := s.client.Close(); err != nil {
+		log.Printf("⚠️ Erro ao encerrar a sessão de path_find %s: %v", s.id, err)
+	}
+}
+
+// HandleMessage delivers the path_find responses and updates of the session's connection
+func (s *session) HandleMessage(msg []byte) {
+	var message PathFindMessage
+	if err := json.Unmarshal(msg, &message); err != nil || message.ID != s.id {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+
+	if message.Error != "" {
+		log.Printf("❌ Erro na sessão de path_find %s: %s %s", s.id, message.Error, message.ErrorMessage)
+		s.end()
+		return
+	}
+
+	result := &message.PathFindResult
+	if message.Type == "response" {
+		if message.Result == nil {
+			return
+		}
+		result = message.Result
+	} else if message.Type != "path_find" {
+		return
+	}
+	if result.Closed {
+		return
+	}
+
+	// Slow clients miss intermediate updates, not the session
+	select {
+	case s.updates <- buildQuote(s.request, result):
+	default:
+	}
+}
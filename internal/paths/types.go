@@ -0,0 +1,119 @@
+package paths
+
+import "github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+
+// DefaultSlippagePercent is the margin added to the source cost to build SendMax
+const DefaultSlippagePercent = 1.0
+
+// QuoteRequest defines the payment to quote. Amounts follow the ledger format (XRP in drops)
+type QuoteRequest struct {
+	SourceAccount      string       `json:"source_account"`
+	DestinationAccount string       `json:"destination_account"`
+	DestinationAmount  xrpl.Amount  `json:"destination_amount"`
+	SendMax            *xrpl.Amount `json:"send_max,omitempty"`
+	SourceCurrencies   []xrpl.Issue `json:"source_currencies,omitempty"`
+	SlippagePercent    float64      `json:"slippage_percent"`
+}
+
+// Quote lists the ways to deliver a payment, each with its cost in the source currency
+type Quote struct {
+	SourceAccount      string             `json:"source_account"`
+	DestinationAccount string             `json:"destination_account"`
+	DestinationAmount  xrpl.Amount        `json:"destination_amount"`
+	SlippagePercent    float64            `json:"slippage_percent"`
+	Alternatives       []QuoteAlternative `json:"alternatives"`
+	// FullReply is false while a path_find session is still refining its alternatives
+	FullReply   bool `json:"full_reply"`
+	LedgerIndex int  `json:"ledger_current_index,omitempty"`
+}
+
+// QuoteAlternative is one way to pay: what it costs, the SendMax to sign and the paths to use
+type QuoteAlternative struct {
+	SourceAmount xrpl.Amount  `json:"source_amount"`
+	SendMax      xrpl.Amount  `json:"send_max"`
+	Paths        [][]PathStep `json:"paths"`
+	// DestinationAmount is set when the request uses send_max
+	DestinationAmount *xrpl.Amount `json:"destination_amount,omitempty"`
+	// Rate is delivered per unit spent, with XRP in XRP (not drops)
+	Rate float64 `json:"rate"`
+}
+
+// PathStep is one step of a payment path
+type PathStep struct {
+	Account  string `json:"account,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	Issuer   string `json:"issuer,omitempty"`
+	Type     int    `json:"type,omitempty"`
+	TypeHex  string `json:"type_hex,omitempty"`
+}
+
+// ---------- HTTP Request/Response Types ----------
+
+// RipplePathFindRequest defines the structure for the ripple_path_find HTTP request
+type RipplePathFindRequest struct {
+	Method string                `json:"method"`
+	Params []RipplePathFindParam `json:"params"`
+}
+
+type RipplePathFindParam struct {
+	SourceAccount      string       `json:"source_account"`
+	DestinationAccount string       `json:"destination_account"`
+	DestinationAmount  interface{}  `json:"destination_amount"`
+	SendMax            interface{}  `json:"send_max,omitempty"`
+	SourceCurrencies   []xrpl.Issue `json:"source_currencies,omitempty"`
+	LedgerIndex        string       `json:"ledger_index,omitempty"`
+}
+
+// RipplePathFindResponse defines the response for ripple_path_find
+type RipplePathFindResponse struct {
+	Result struct {
+		PathFindResult
+		Status       string `json:"status"`
+		Error        string `json:"error"`
+		ErrorMessage string `json:"error_message"`
+	} `json:"result"`
+}
+
+// PathFindResult holds the alternatives returned by ripple_path_find and path_find
+type PathFindResult struct {
+	Alternatives       []PathAlternative `json:"alternatives"`
+	DestinationAccount string            `json:"destination_account"`
+	DestinationAmount  xrpl.Amount       `json:"destination_amount"`
+	SourceAccount      string            `json:"source_account"`
+	FullReply          bool              `json:"full_reply"`
+	LedgerCurrentIndex int               `json:"ledger_current_index"`
+	Closed             bool              `json:"closed"`
+}
+
+// PathAlternative is one alternative as returned by rippled
+type PathAlternative struct {
+	PathsComputed     [][]PathStep `json:"paths_computed"`
+	SourceAmount      xrpl.Amount  `json:"source_amount"`
+	DestinationAmount *xrpl.Amount `json:"destination_amount,omitempty"`
+}
+
+// ---------- WebSocket Request/Response Types ----------
+
+// PathFindWSRequest defines the path_find create/close command
+type PathFindWSRequest struct {
+	ID                 string       `json:"id"`
+	Command            string       `json:"command"`
+	Subcommand         string       `json:"subcommand"`
+	SourceAccount      string       `json:"source_account,omitempty"`
+	DestinationAccount string       `json:"destination_account,omitempty"`
+	DestinationAmount  interface{}  `json:"destination_amount,omitempty"`
+	SendMax            interface{}  `json:"send_max,omitempty"`
+	SourceCurrencies   []xrpl.Issue `json:"source_currencies,omitempty"`
+}
+
+// PathFindMessage is either the response to a path_find command (Result set) or an
+// asynchronous path_find update (fields at the top level)
+type PathFindMessage struct {
+	ID           string          `json:"id"`
+	Type         string          `json:"type"`
+	Status       string          `json:"status"`
+	Error        string          `json:"error"`
+	ErrorMessage string          `json:"error_message"`
+	Result       *PathFindResult `json:"result"`
+	PathFindResult
+}
@@ -0,0 +1,68 @@
+package pending
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/ledger"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// Start expires the pool's transactions on every validated ledger, independently of the
+// transactions_proposed stream and of any ledger viewer
+func Start(wsClient *xrpl.WebSocketClient) error {
+	_, err := ledger.SubscribeValidatedLedgers(wsClient, func(closed *ledger.LedgerSubscribeClosedResponse) {
+		DefaultPool.OnValidatedLedger(closed.LedgerIndex)
+	})
+	return err
+}
+
+// StreamPending subscribes to the transactions_proposed stream and feeds the default pool
+func StreamPending(wsClient *xrpl.WebSocketClient, stopChan chan struct{}) error {
+	request := SubscribeProposedRequest{
+		ID:      "subscribe_pending",
+		Command: "subscribe",
+		Streams: []string{"transactions_proposed"},
+	}
+
+	if err := wsClient.Subscribe(request); err != nil {
+		log.Printf("❌ Erro ao enviar o comando subscribe de transações propostas: %v", err)
+		return err
+	}
+
+	remove := wsClient.AddHandler(HandleMessage)
+	go func() {
+		<-stopChan
+		log.Println("⛔ Encerrando o streaming de transações propostas.")
+		remove()
+
+		// The client only unsubscribes upstream when no other consumer (the ingester) holds the stream
+		request.ID = "unsubscribe_pending"
+		request.Command = "unsubscribe"
+		if err := wsClient.Subscribe(request); err != nil {
+			log.Printf("⚠️ Erro ao cancelar a inscrição de transações propostas: %v", err)
+		}
+	}()
+	return nil
+}
+
+// HandleMessage adds proposed transactions to the pool and confirms validated ones
+func HandleMessage(msg []byte) {
+	var message transactions.StreamTransactionMessage
+	if err := json.Unmarshal(msg, &message); err != nil || message.Type != "transaction" {
+		return
+	}
+
+	tx, err := transactions.Normalize(message.Raw())
+	if err != nil {
+		log.Printf("⚠️ Erro ao interpretar transação proposta: %v", err)
+		return
+	}
+
+	if message.Validated {
+		DefaultPool.Confirm(tx.Hash, tx.Result, tx.LedgerIndex)
+		return
+	}
+	DefaultPool.Add(tx, message.EngineResult, message.LedgerCurrentIndex)
+}
@@ -0,0 +1,180 @@
+package pending
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+)
+
+// MaxPendingLedgers expires transactions without LastLedgerSequence that stay pending this many ledgers
+const MaxPendingLedgers = 20
+
+// RetainLedgers keeps confirmed and expired entries visible for this many ledgers before pruning
+const RetainLedgers = 10
+
+type entry struct {
+	Entry
+	resolvedLedger int
+}
+
+// Pool keeps an in-memory index of unvalidated transactions keyed by hash
+type Pool struct {
+	mu      sync.RWMutex
+	entries map[string]*entry
+	latest  int
+}
+
+// DefaultPool is the pool fed by the transactions_proposed stream and the ledger stream
+var DefaultPool = NewPool()
+
+func NewPool() *Pool {
+	return &Pool{entries: make(map[string]*entry)}
+}
+
+// Add tracks an unvalidated transaction seen while ledger currentLedger was open
+func (p *Pool) Add(tx *transactions.Transaction, engineResult string, currentLedger int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if existing, ok := p.entries[tx.Hash]; ok {
+		if existing.Status == StatusPending {
+			existing.EngineResult = engineResult
+		}
+		return
+	}
+
+	p.entries[tx.Hash] = &entry{Entry: Entry{
+		Hash:               tx.Hash,
+		TransactionType:    tx.TransactionType,
+		Account:            tx.Account,
+		Accounts:           tx.Accounts,
+		Sequence:           tx.Sequence,
+		Fee:                tx.Fee,
+		LastLedgerSequence: tx.LastLedgerSequence,
+		EngineResult:       engineResult,
+		SeenLedger:         currentLedger,
+		FirstSeen:          time.Now(),
+		Status:             StatusPending,
+	}}
+}
+
+// Confirm moves a tracked transaction to confirmed when it appears in a validated ledger.
+// Transactions in ledger L imply every transaction with LastLedgerSequence below L is final
+func (p *Pool) Confirm(hash string, result string, ledgerIndex int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e, ok := p.entries[hash]; ok && e.Status == StatusPending {
+		now := time.Now()
+		e.Status = StatusConfirmed
+		e.Result = result
+		e.LedgerIndex = ledgerIndex
+		e.ResolvedAt = &now
+		e.resolvedLedger = ledgerIndex
+	}
+	p.advance(ledgerIndex - 1)
+}
+
+// OnValidatedLedger expires the transactions that can no longer be included after a validated ledger.
+// The stream publishes a ledger's transactions before its ledgerClosed message
+func (p *Pool) OnValidatedLedger(ledgerIndex int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.advance(ledgerIndex)
+}
+
+// advance expires and prunes entries up to a final ledger; the caller holds mu
+func (p *Pool) advance(ledgerIndex int) {
+	if ledgerIndex <= p.latest {
+		return
+	}
+	p.latest = ledgerIndex
+	now := time.Now()
+
+	for hash, e := range p.entries {
+		switch e.Status {
+		case StatusPending:
+			expired := e.LastLedgerSequence > 0 && int(e.LastLedgerSequence) <= ledgerIndex
+			if e.LastLedgerSequence == 0 && e.SeenLedger > 0 && ledgerIndex-e.SeenLedger >= MaxPendingLedgers {
+				expired = true
+			}
+			if expired {
+				e.Status = StatusExpired
+				e.ResolvedAt = &now
+				e.resolvedLedger = ledgerIndex
+			}
+		default:
+			if ledgerIndex-e.resolvedLedger >= RetainLedgers {
+				delete(p.entries, hash)
+			}
+		}
+	}
+}
+
+// Get returns a tracked transaction by hash
+func (p *Pool) Get(hash string) (Entry, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	e, ok := p.entries[hash]
+	if !ok {
+		return Entry{}, false
+	}
+	return e.Entry, true
+}
+
+// List returns the tracked transactions with a status, optionally involving an account, oldest first
+func (p *Pool) List(status string, account string) []Entry {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	entries := []Entry{}
+	for _, e := range p.entries {
+		if status != "" && e.Status != status {
+			continue
+		}
+		if account != "" && !involves(e.Accounts, account) {
+			continue
+		}
+		entries = append(entries, e.Entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].FirstSeen.Before(entries[j].FirstSeen) })
+	return entries
+}
+
+// AccountCounts returns the number of pending transactions per sending account, largest first
+func (p *Pool) AccountCounts() []AccountCount {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	counts := map[string]int{}
+	for _, e := range p.entries {
+		if e.Status == StatusPending {
+			counts[e.Account]++
+		}
+	}
+
+	result := make([]AccountCount, 0, len(counts))
+	for account, count := range counts {
+		result = append(result, AccountCount{Account: account, Pending: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Pending != result[j].Pending {
+			return result[i].Pending > result[j].Pending
+		}
+		return result[i].Account < result[j].Account
+	})
+	return result
+}
+
+func involves(accounts []string, account string) bool {
+	for _, candidate := range accounts {
+		if candidate == account {
+			return true
+		}
+	}
+	return false
+}
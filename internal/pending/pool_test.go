@@ -0,0 +1,68 @@
+package pending
+
+import (
+	"testing"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+)
+
+func TestPoolAdvance(t *testing.T) {
+	tests := []struct {
+		name               string
+		lastLedgerSequence uint32
+		seenLedger         int
+		validated          int
+		wantStatus         string
+		wantPruned         bool
+	}{
+		{name: "before LastLedgerSequence", lastLedgerSequence: 105, seenLedger: 100, validated: 104, wantStatus: StatusPending},
+		{name: "at LastLedgerSequence", lastLedgerSequence: 105, seenLedger: 100, validated: 105, wantStatus: StatusExpired},
+		{name: "without LastLedgerSequence, recent", seenLedger: 100, validated: 100 + MaxPendingLedgers - 1, wantStatus: StatusPending},
+		{name: "without LastLedgerSequence, too old", seenLedger: 100, validated: 100 + MaxPendingLedgers, wantStatus: StatusExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewPool()
+			pool.Add(&transactions.Transaction{Hash: "H", Account: "rA", LastLedgerSequence: tt.lastLedgerSequence}, "tesSUCCESS", tt.seenLedger)
+			pool.OnValidatedLedger(tt.validated)
+
+			e, ok := pool.Get("H")
+			if !ok {
+				t.Fatal("entry pruned too early")
+			}
+			if e.Status != tt.wantStatus {
+				t.Fatalf("status = %s, want %s", e.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPoolConfirmAndPrune(t *testing.T) {
+	pool := NewPool()
+	pool.Add(&transactions.Transaction{Hash: "A", Account: "rA", LastLedgerSequence: 110}, "tesSUCCESS", 100)
+	pool.Add(&transactions.Transaction{Hash: "B", Account: "rB", LastLedgerSequence: 102}, "tesSUCCESS", 100)
+
+	// A validated in ledger 103 makes ledger 102 final, so B can no longer be included
+	pool.Confirm("A", "tesSUCCESS", 103)
+	if e, _ := pool.Get("A"); e.Status != StatusConfirmed || e.LedgerIndex != 103 {
+		t.Fatalf("A = %+v, want confirmed in 103", e)
+	}
+	if e, _ := pool.Get("B"); e.Status != StatusExpired {
+		t.Fatalf("B status = %s, want expired", e.Status)
+	}
+
+	// an older ledger never moves the pool backwards
+	pool.OnValidatedLedger(90)
+	if _, ok := pool.Get("B"); !ok {
+		t.Fatal("B pruned by an older ledger")
+	}
+
+	pool.OnValidatedLedger(103 + RetainLedgers)
+	if _, ok := pool.Get("A"); ok {
+		t.Fatal("A should be pruned after RetainLedgers")
+	}
+	if _, ok := pool.Get("B"); ok {
+		t.Fatal("B should be pruned after RetainLedgers")
+	}
+}
@@ -0,0 +1,41 @@
+package pending
+
+import "time"
+
+// Status of a tracked transaction
+const (
+	StatusPending   = "pending"
+	StatusConfirmed = "confirmed"
+	StatusExpired   = "expired"
+)
+
+// Entry is an unvalidated transaction seen on the transactions_proposed stream
+type Entry struct {
+	Hash               string     `json:"hash"`
+	TransactionType    string     `json:"transaction_type"`
+	Account            string     `json:"account"`
+	Accounts           []string   `json:"accounts"`
+	Sequence           uint32     `json:"sequence"`
+	Fee                string     `json:"fee"`
+	LastLedgerSequence uint32     `json:"last_ledger_sequence,omitempty"`
+	EngineResult       string     `json:"engine_result"`
+	SeenLedger         int        `json:"seen_ledger"`
+	FirstSeen          time.Time  `json:"first_seen"`
+	Status             string     `json:"status"`
+	Result             string     `json:"result,omitempty"`
+	LedgerIndex        int        `json:"ledger_index,omitempty"`
+	ResolvedAt         *time.Time `json:"resolved_at,omitempty"`
+}
+
+// AccountCount is the number of pending transactions sent by an account
+type AccountCount struct {
+	Account string `json:"account"`
+	Pending int    `json:"pending"`
+}
+
+// SubscribeProposedRequest defines the subscribe command for the transactions_proposed stream
+type SubscribeProposedRequest struct {
+	ID      string   `json:"id"`
+	Command string   `json:"command"`
+	Streams []string `json:"streams"`
+}
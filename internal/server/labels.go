@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/labels"
+	"github.com/gofiber/fiber/v2"
+)
+
+// LabelsMiddleware attaches the labels of the accounts found in a JSON object response
+// (transactions, book offers, portfolios...) as a top-level "labels" field. Array responses
+// have no place for the field, so they are only labeled when the request asks for it with
+// ?labels=true, which wraps them as {"data": [...], "labels": {...}}
+func LabelsMiddleware(c *fiber.Ctx) error {
+	if err := c.Next(); err != nil {
+		return err
+	}
+
+	response := c.Response()
+	// Streams (SSE) must not be buffered
+	if response.IsBodyStream() || !strings.HasPrefix(string(response.Header.ContentType()), fiber.MIMEApplicationJSON) {
+		return nil
+	}
+
+	body := bytes.TrimSpace(response.Body())
+	if len(body) < 2 {
+		return nil
+	}
+	isArray := body[0] == '['
+	if !(body[0] == '{' || isArray && c.QueryBool("labels")) {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil
+	}
+	object, isObject := decoded.(map[string]interface{})
+	if _, exists := object["labels"]; isObject && exists {
+		return nil
+	}
+
+	found := map[string]bool{}
+	collectAddresses(decoded, found)
+	accounts := make([]string, 0, len(found))
+	for value := range found {
+		accounts = append(accounts, value)
+	}
+
+	// An array response asked for labels is always wrapped, so its shape does not depend on the data
+	annotated := labels.Annotate(accounts)
+	if len(annotated) == 0 && !isArray {
+		return nil
+	}
+	encoded, err := json.Marshal(annotated)
+	if err != nil {
+		return nil
+	}
+
+	// Append the field instead of re-encoding the body, so the original payload is kept byte for byte
+	annotatedBody := make([]byte, 0, len(body)+len(encoded)+22)
+	if isArray {
+		annotatedBody = append(annotatedBody, `{"data":`...)
+		annotatedBody = append(annotatedBody, body...)
+		annotatedBody = append(annotatedBody, ',')
+	} else {
+		annotatedBody = append(annotatedBody, body[:len(body)-1]...)
+		if len(object) > 0 {
+			annotatedBody = append(annotatedBody, ',')
+		}
+	}
+	annotatedBody = append(annotatedBody, `"labels":`...)
+	annotatedBody = append(annotatedBody, encoded...)
+	annotatedBody = append(annotatedBody, '}')
+	response.SetBodyRaw(annotatedBody)
+	return nil
+}
+
+// collectAddresses gathers the string values of a decoded JSON document that look like addresses
+func collectAddresses(value interface{}, found map[string]bool) {
+	switch typed := value.(type) {
+	case string:
+		if labels.IsAddress(typed) {
+			found[typed] = true
+		}
+	case map[string]interface{}:
+		for _, item := range typed {
+			collectAddresses(item, found)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			collectAddresses(item, found)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"io"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCollectAddresses(t *testing.T) {
+	document := map[string]interface{}{
+		"account":  "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		"hash":     "E3FE6EA3D48F0C2B639448020EA4F03D4F4F8FFDB243A852A0F59177921B4879",
+		"currency": "USD",
+		"offers": []interface{}{
+			map[string]interface{}{"owner": "rPEPPER7kfTD9w2To4CQk6UCfuHM9c6GDY", "memo": "short text"},
+			"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		},
+	}
+
+	found := map[string]bool{}
+	collectAddresses(document, found)
+
+	got := make([]string, 0, len(found))
+	for account := range found {
+		got = append(got, account)
+	}
+	sort.Strings(got)
+	want := []string{"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "rPEPPER7kfTD9w2To4CQk6UCfuHM9c6GDY"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("collectAddresses = %v, want %v", got, want)
+	}
+}
+
+func TestLabelsMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(LabelsMiddleware)
+	app.Get("/object", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"account": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"})
+	})
+	app.Get("/array", func(c *fiber.Ctx) error {
+		return c.JSON([]string{"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"})
+	})
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		// no label is known for the account, so object bodies are left as they are
+		{path: "/object", want: `{"account":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"}`},
+		{path: "/array", want: `["rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"]`},
+		{path: "/array?labels=true", want: `{"data":["rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"],"labels":{}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest("GET", tt.path, nil))
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tt.want {
+				t.Fatalf("body = %s, want %s", body, tt.want)
+			}
+		})
+	}
+}
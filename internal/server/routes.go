@@ -1,17 +1,38 @@
 package server
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"log" 
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 	"encoding/json" //for json operations
 
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/accounts"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/activations"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/alerts"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/balances"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/deposits"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/ingest"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/invoices"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/issuers"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/labels"
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/ledger"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/network"
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/orderbook"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/paths"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/pending"
 	"github.com/Panorama-Block/xrpl-data-extraction/internal/states"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/tracker"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/validators"
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // Controle de conexões WebSocket ativas
@@ -42,36 +63,293 @@ app.Post("/accounts/subscribe", func(c *fiber.Ctx) error {
 		})
 	}
 
-	// Criar canal para gerenciar o stream
-	mu.Lock()
-	stopChan := make(chan struct{})
-	stopChans["accounts_subscribe"] = stopChan
-	mu.Unlock()
-
-	// Iniciar o streaming de contas
-	go accounts.SubscribeAccounts(wsClient, payload.Accounts, stopChan)
+	// As contas entram na watchlist padrão, persistida e restaurada no startup
+	watchlist, err := accounts.AddWatchlistAccounts(wsClient, accounts.DefaultWatchlist, payload.Accounts)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		watchlist, err = accounts.CreateWatchlist(wsClient, accounts.DefaultWatchlist, payload.Accounts)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
 
 	return c.JSON(fiber.Map{
 		"message":  "Subscription to accounts started",
-		"accounts": payload.Accounts,
+		"accounts": watchlist.Accounts,
 	})
 })
 
-// Cancelar inscrição no stream de contas
+// Cancelar inscrição no stream de contas (remove a watchlist padrão)
 app.Delete("/accounts/subscribe", func(c *fiber.Ctx) error {
-	mu.Lock()
-	stopChan, exists := stopChans["accounts_subscribe"]
-	if exists {
-		close(stopChan) // Enviar sinal de parada
-		delete(stopChans, "accounts_subscribe")
-		mu.Unlock()
-		return c.JSON(fiber.Map{"message": "Subscription to accounts stopped"})
+	err := accounts.DeleteWatchlist(wsClient, accounts.DefaultWatchlist)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "No active subscription to accounts",
+		})
 	}
-	mu.Unlock()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Subscription to accounts stopped"})
+})
 
-	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-		"error": "No active subscription to accounts",
-	})
+// Watchlists nomeadas de contas, persistidas e restauradas no startup
+app.Post("/watchlists", func(c *fiber.Ctx) error {
+	var payload struct {
+		Name     string   `json:"name"`
+		Accounts []string `json:"accounts"`
+	}
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	watchlist, err := accounts.CreateWatchlist(wsClient, payload.Name, payload.Accounts)
+	if errors.Is(err, accounts.ErrWatchlistExists) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(watchlist)
+})
+
+app.Get("/watchlists", func(c *fiber.Ctx) error {
+	watchlists, err := accounts.GetWatchlists()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"watchlists": watchlists, "followed_accounts": accounts.FollowedAccounts()})
+})
+
+app.Get("/watchlists/:name", func(c *fiber.Ctx) error {
+	watchlist, err := accounts.GetWatchlist(c.Params("name"))
+	return watchlistResponse(c, watchlist, err)
+})
+
+app.Post("/watchlists/:name/accounts", func(c *fiber.Ctx) error {
+	var payload struct {
+		Accounts []string `json:"accounts"`
+	}
+	if err := c.BodyParser(&payload); err != nil || len(payload.Accounts) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Accounts list cannot be empty"})
+	}
+	watchlist, err := accounts.AddWatchlistAccounts(wsClient, c.Params("name"), payload.Accounts)
+	return watchlistResponse(c, watchlist, err)
+})
+
+app.Delete("/watchlists/:name/accounts", func(c *fiber.Ctx) error {
+	var payload struct {
+		Accounts []string `json:"accounts"`
+	}
+	if err := c.BodyParser(&payload); err != nil || len(payload.Accounts) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Accounts list cannot be empty"})
+	}
+	watchlist, err := accounts.RemoveWatchlistAccounts(wsClient, c.Params("name"), payload.Accounts)
+	return watchlistResponse(c, watchlist, err)
+})
+
+app.Post("/watchlists/:name/pause", func(c *fiber.Ctx) error {
+	watchlist, err := accounts.PauseWatchlist(wsClient, c.Params("name"))
+	return watchlistResponse(c, watchlist, err)
+})
+
+app.Post("/watchlists/:name/resume", func(c *fiber.Ctx) error {
+	watchlist, err := accounts.ResumeWatchlist(wsClient, c.Params("name"))
+	return watchlistResponse(c, watchlist, err)
+})
+
+app.Delete("/watchlists/:name", func(c *fiber.Ctx) error {
+	err := accounts.DeleteWatchlist(wsClient, c.Params("name"))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Watchlist not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Watchlist deleted"})
+})
+
+// Regras de alerta de transferências grandes (pagamentos, ofertas executadas, trades em AMM)
+app.Post("/alerts/rules", func(c *fiber.Ctx) error {
+	var rule alerts.RuleSchema
+	if err := c.BodyParser(&rule); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	saved, err := alerts.SaveRule(rule)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(saved)
+})
+
+app.Get("/alerts/rules", func(c *fiber.Ctx) error {
+	return c.JSON(alerts.GetRules())
+})
+
+app.Delete("/alerts/rules/:name", func(c *fiber.Ctx) error {
+	err := alerts.DeleteRule(c.Params("name"))
+	if alerts.IsNotFound(err) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Alert rule not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Alert rule deleted"})
+})
+
+// Feed de alertas (?rule=&since=RFC3339&limit=)
+app.Get("/alerts", func(c *fiber.Ctx) error {
+	var since time.Time
+	if value := c.Query("since"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "since must be RFC3339"})
+		}
+		since = parsed
+	}
+
+	feed, err := alerts.GetAlerts(c.Query("rule", ""), since, int64(c.QueryInt("limit", 100)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(feed)
+})
+
+// Emissores monitorados: snapshots de gateway_balances a cada N ledgers (interval_ledgers)
+app.Post("/issuers", func(c *fiber.Ctx) error {
+	var issuer issuers.IssuerSchema
+	if err := c.BodyParser(&issuer); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	saved, err := issuers.SaveIssuer(wsClient, issuer)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(saved)
+})
+
+app.Get("/issuers", func(c *fiber.Ctx) error {
+	list, err := issuers.GetIssuers()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(list)
+})
+
+app.Delete("/issuers/:account", func(c *fiber.Ctx) error {
+	err := issuers.DeleteIssuer(wsClient, c.Params("account"))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Issuer not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Issuer removed"})
+})
+
+// Snapshots brutos de gateway_balances de um emissor (?from_ledger=&to_ledger=&limit=)
+app.Get("/issuers/:account/snapshots", func(c *fiber.Ctx) error {
+	snapshots, err := issuers.GetSnapshots(c.Params("account"), c.QueryInt("from_ledger", 0), c.QueryInt("to_ledger", 0), int64(c.QueryInt("limit", 500)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"issuer": c.Params("account"), "snapshots": snapshots})
+})
+
+// Série temporal de obrigações de uma moeda, com emissão e resgate atribuídos a transações
+app.Get("/issuers/:account/obligations", func(c *fiber.Ctx) error {
+	currency := c.Query("currency")
+	if currency == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "currency is required"})
+	}
+
+	series, err := issuers.GetSeries(c.Params("account"), currency, c.QueryInt("from_ledger", 0), c.QueryInt("to_ledger", 0), int64(c.QueryInt("limit", 500)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"issuer": c.Params("account"), "currency": currency, "series": series})
+})
+
+// Emissões e resgates por transação (?currency=&from_ledger=&to_ledger=&limit=)
+app.Get("/issuers/:account/obligations/changes", func(c *fiber.Ctx) error {
+	changes, err := issuers.GetObligationChanges(c.Params("account"), c.Query("currency", ""), c.QueryInt("from_ledger", 0), c.QueryInt("to_ledger", 0), int64(c.QueryInt("limit", 1000)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"issuer": c.Params("account"), "changes": changes})
+})
+
+// Registro de entidades: rótulos de contas (exchange, issuer, amm, market_maker, other)
+app.Get("/labels", func(c *fiber.Ctx) error {
+	list, err := labels.List(c.Query("category", ""), int64(c.QueryInt("limit", 1000)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(list)
+})
+
+app.Get("/labels/:account", func(c *fiber.Ctx) error {
+	label, ok := labels.Get(c.Params("account"))
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Label not found"})
+	}
+	return c.JSON(label)
+})
+
+app.Put("/labels/:account", func(c *fiber.Ctx) error {
+	var label labels.LabelSchema
+	if err := c.BodyParser(&label); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+	label.Account = c.Params("account")
+	label.Source = labels.SourceManual
+
+	saved, err := labels.Upsert(label)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(saved)
+})
+
+app.Delete("/labels/:account", func(c *fiber.Ctx) error {
+	err := labels.Delete(c.Params("account"))
+	if labels.IsNotFound(err) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Label not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Label deleted"})
+})
+
+// Importação em lote: text/csv (colunas account,name,category,confidence,source) ou um array JSON
+app.Post("/labels/import", func(c *fiber.Ctx) error {
+	if strings.HasPrefix(string(c.Request().Header.ContentType()), "text/csv") {
+		result, err := labels.ImportCSV(bytes.NewReader(c.Body()))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(result)
+	}
+
+	var list []labels.LabelSchema
+	if err := json.Unmarshal(c.Body(), &list); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+	return c.JSON(labels.ImportJSON(list))
+})
+
+// Rotulagem automática de uma conta: AMM (amm_info) ou emissor (gateway_balances)
+app.Post("/labels/:account/auto", func(c *fiber.Ctx) error {
+	label, err := labels.AutoLabel(httpClient, c.Params("account"))
+	if err != nil {
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": err.Error()})
+	}
+	if label == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Account is neither an AMM nor an issuer"})
+	}
+	return c.JSON(label)
 })
 
 
@@ -110,6 +388,72 @@ app.Delete("/accounts/subscribe", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{"message": "Subscribed to account_channels"}) // return message
 	})
 
+	// Historical account objects endpoint (?type=escrow&ledger_index=&limit=&marker=)
+	app.Get("/accounts/:account/objects/historical", func(c *fiber.Ctx) error {
+		if _, ok := accounts.ObjectTypes[c.Query("type")]; c.Query("type") != "" && !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Unknown object type"})
+		}
+
+		var marker interface{}
+		if value := c.Query("marker"); value != "" {
+			marker = value
+		}
+
+		page, err := accounts.FetchAccountObjectsPage(httpClient, c.Params("account"), c.Query("ledger_index", "validated"), c.Query("type"), c.QueryInt("limit", 200), marker)
+		var rpcErr *transactions.RPCError
+		if errors.As(err, &rpcErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": rpcErr.Error()})
+		}
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(page)
+	})
+
+	// WS account objects endpoint
+	app.Get("/accounts/:account/objects/realtime", func(c *fiber.Ctx) error {
+		account := c.Params("account")
+		objectType := c.Query("type")
+		if _, ok := accounts.ObjectTypes[objectType]; objectType != "" && !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Unknown object type"})
+		}
+
+		go accounts.StreamAccountObjects(wsClient, account, "validated", objectType, c.QueryInt("limit", 200), func(data *accounts.AccountObjectsPage) {
+			log.Printf("📦 %d objetos da conta %s no ledger %d", len(data.Objects), data.Account, data.LedgerIndex)
+		})
+
+		return c.JSON(fiber.Map{"message": "Subscribed to account_objects"})
+	})
+
+	// Historical account offers endpoint (?ledger_index=&limit=&marker=)
+	app.Get("/accounts/:account/offers/historical", func(c *fiber.Ctx) error {
+		var marker interface{}
+		if value := c.Query("marker"); value != "" {
+			marker = value
+		}
+
+		page, err := accounts.FetchAccountOffersPage(httpClient, c.Params("account"), c.Query("ledger_index", "validated"), c.QueryInt("limit", 200), marker)
+		var rpcErr *transactions.RPCError
+		if errors.As(err, &rpcErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": rpcErr.Error()})
+		}
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(page)
+	})
+
+	// WS account offers endpoint
+	app.Get("/accounts/:account/offers/realtime", func(c *fiber.Ctx) error {
+		account := c.Params("account")
+
+		go accounts.StreamAccountOffers(wsClient, account, "validated", c.QueryInt("limit", 200), func(data *accounts.AccountOffersPage) {
+			log.Printf("📈 %d ofertas da conta %s no ledger %d", len(data.Offers), data.Account, data.LedgerIndex)
+		})
+
+		return c.JSON(fiber.Map{"message": "Subscribed to account_offers"})
+	})
+
 	
 	// Account Currencies - Historical
 	app.Post("/accounts/currencies/historical", func(c *fiber.Ctx) error {
@@ -161,6 +505,8 @@ app.Delete("/accounts/subscribe", func(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to decode JSON response"})
 		}
 
+		accounts.AddAccountSettings(decodedResponse)
+		accounts.AddAccountReserves(httpClient, decodedResponse)
 		return c.JSON(decodedResponse)
 	})
 
@@ -206,169 +552,954 @@ app.Delete("/accounts/subscribe", func(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
 		}
 
-		err := wsClient.Subscribe(payload)
-		if err != nil {
+		_, err := wsClient.Request(payload, func(message []byte) {
+			var response accounts.AccountLinesWSResponse
+			err := json.Unmarshal(message, &response)
+			if err != nil {
+				return
+			}
+			log.Printf("Real-time data: %+v", response)
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		return c.JSON(fiber.Map{"message": "Subscribed to account_lines"})
+	})
+
+	app.Post("/accounts/nfts/historical", func(c *fiber.Ctx) error {
+		var payload accounts.AccountNFTsRequest
+		if err := c.BodyParser(&payload); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+
+		response, err := httpClient.Post("", payload)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		var decodedResponse map[string]interface{}
+		err = json.Unmarshal(response, &decodedResponse)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to decode JSON response"})
+		}
+
+		return c.JSON(decodedResponse)
+	})
+
+	app.Get("/accounts/:account/nfts/realtime", func(c *fiber.Ctx) error {
+		account := c.Params("account")
+		ledgerIndex := c.Query("ledger_index", "validated")
+		limit := c.QueryInt("limit", 100)
+
+		go accounts.StreamAccountNFTs(wsClient, account, ledgerIndex, limit, func(data *accounts.AccountNFTsResponse) {
+			log.Printf("Real-time data: %+v", data)
+		})
+
+		return c.JSON(fiber.Map{"message": "Subscribed to account_nfts"})
+	})
+
+	// Similarly add routes for gateway balances
+	app.Post("/accounts/balances/historical", func(c *fiber.Ctx) error {
+		var payload accounts.GatewayBalancesRequest
+		if err := c.BodyParser(&payload); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+
+		response, err := httpClient.Post("", payload)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		var decodedResponse map[string]interface{}
+		err = json.Unmarshal(response, &decodedResponse)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to decode JSON response"})
+		}
+
+		return c.JSON(decodedResponse)
+	})
+
+	// Gateway Balances - Real-time
+	app.Get("/accounts/:account/balances/realtime", func(c *fiber.Ctx) error {
+		account := c.Params("account")
+		ledgerIndex := c.Query("ledger_index", "validated")
+		hotWallet := c.Query("hotwallet", "") // Example: "wallet1,wallet2"
+		strict := c.QueryBool("strict", false)
+
+		hotWallets := []string{}
+		if hotWallet != "" {
+			hotWallets = append(hotWallets, hotWallet)
+		}
+
+		go accounts.StreamGatewayBalances(wsClient, account, hotWallets, ledgerIndex, strict, func(data *accounts.GatewayBalancesResponse) {
+			log.Printf("Real-time data: %+v", data)
+		})
+
+		return c.JSON(fiber.Map{"message": "Subscribed to gateway_balances"})
+	})
+
+	
+	
+	// ==================================================================================================LEDGER DATA===============================================================================================================
+app.Post("/ledger", func(c *fiber.Ctx) error {
+	var payload ledger.LedgerParam
+	if err := c.BodyParser(&payload); err != nil {
+		log.Printf("❌ Erro ao interpretar o payload: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+	if payload.LedgerIndex == "" {
+    return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "LedgerIndex é obrigatório"})
+	}
+	response, err := ledger.FetchLedgerInfo(httpClient, payload.LedgerIndex)
+	if err != nil {
+		log.Printf("❌ Erro ao buscar dados do ledger: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Error fetching ledger data"})
+	}
+	return c.JSON(response)
+})
+	
+var stopChan chan struct{}
+	
+app.Get("/ledger/realtime", func(c *fiber.Ctx) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if stopChan != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Ledger stream already running"})
+	}
+	stopChan = make(chan struct{})
+	// Viewer only: network settings, state changes, pending expiry, validator reports and
+	// deposits are driven by the ledger stream consumers started in main
+	go ledger.StreamLedger(wsClient, httpClient, func(data *ledger.LedgerSubscribeClosedResponse) {
+		log.Printf("📡 Ledger %d fechado com %d transações", data.LedgerIndex, data.TxnCount)
+	}, stopChan)
+	
+	return c.JSON(fiber.Map{"message": "📡 Streaming de ledgers iniciado!"})
+})
+
+app.Get("/ledger/stop", func(c *fiber.Ctx) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if stopChan == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Ledger stream not running"})
+	}
+	close(stopChan)
+	stopChan = nil
+	return c.JSON(fiber.Map{"message": "⛔ Streaming de ledgers encerrado!"})
+})
+
+
+// ==================================================================================================PENDING POOL===============================================================================================================
+// Iniciar o acompanhamento de transações não validadas (transactions_proposed)
+app.Post("/pending/stream", func(c *fiber.Ctx) error {
+	mu.Lock()
+	if _, exists := stopChans["pending_stream"]; exists {
+		mu.Unlock()
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Pending stream already running"})
+	}
+	pendingStop := make(chan struct{})
+	stopChans["pending_stream"] = pendingStop
+	mu.Unlock()
+
+	go pending.StreamPending(wsClient, pendingStop)
+
+	return c.JSON(fiber.Map{"message": "Pending stream started"})
+})
+
+// Encerrar o acompanhamento de transações não validadas
+app.Delete("/pending/stream", func(c *fiber.Ctx) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	pendingStop, exists := stopChans["pending_stream"]
+	if !exists {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "No active pending stream"})
+	}
+	close(pendingStop)
+	delete(stopChans, "pending_stream")
+
+	return c.JSON(fiber.Map{"message": "Pending stream stopped"})
+})
+
+// Transações pendentes (ou confirmadas/expiradas recentemente com ?status=)
+app.Get("/pending", func(c *fiber.Ctx) error {
+	return c.JSON(pending.DefaultPool.List(c.Query("status", pending.StatusPending), c.Query("account", "")))
+})
+
+// Quantidade de transações pendentes por conta
+app.Get("/pending/accounts", func(c *fiber.Ctx) error {
+	return c.JSON(pending.DefaultPool.AccountCounts())
+})
+
+// Estado de uma transação acompanhada
+app.Get("/pending/:hash", func(c *fiber.Ctx) error {
+	entry, ok := pending.DefaultPool.Get(c.Params("hash"))
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Transaction not tracked"})
+	}
+	return c.JSON(entry)
+})
+
+
+// ==================================================================================================DEPOSITS===============================================================================================================
+// Registrar uma conta recebedora para monitoramento de depósitos por destination tag
+app.Post("/deposits/accounts", func(c *fiber.Ctx) error {
+	var payload deposits.DepositAccountSchema
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	account, err := deposits.RegisterAccount(httpClient, wsClient, payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(account)
+})
+
+// Contas monitoradas para depósitos
+app.Get("/deposits/accounts", func(c *fiber.Ctx) error {
+	return c.JSON(deposits.ListAccounts())
+})
+
+// Adicionar destination tags conhecidas (clientes) a uma conta monitorada
+app.Post("/deposits/accounts/:account/tags", func(c *fiber.Ctx) error {
+	var payload struct {
+		Tags []uint32 `json:"tags"`
+	}
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	account, err := deposits.AddTags(c.Params("account"), payload.Tags)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Deposit account not registered"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(account)
+})
+
+// Depósitos recebidos (?account=&status=&tag=)
+app.Get("/deposits", func(c *fiber.Ctx) error {
+	var tag *uint32
+	if c.Query("tag") != "" {
+		parsed, err := strconv.ParseUint(c.Query("tag"), 10, 32)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid tag"})
+		}
+		value := uint32(parsed)
+		tag = &value
+	}
+
+	result, err := deposits.GetDeposits(c.Query("account", ""), c.Query("status", ""), tag, int64(c.QueryInt("limit", 100)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(result)
+})
+
+// Resolver um depósito em revisão manual ({"approve": true} credita, false rejeita)
+app.Post("/deposits/:tx_hash/resolve", func(c *fiber.Ctx) error {
+	var payload struct {
+		Approve bool `json:"approve"`
+	}
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	deposit, err := deposits.Resolve(c.Params("tx_hash"), payload.Approve)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Deposit not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(deposit)
+})
+
+
+// ==================================================================================================INVOICES===============================================================================================================
+// Criar uma cobrança (valor esperado, moeda, destino, tag e expiração)
+app.Post("/invoices", func(c *fiber.Ctx) error {
+	var payload invoices.CreateInvoiceRequest
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invoice, err := invoices.CreateInvoice(wsClient, payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(invoice)
+})
+
+// Listar cobranças (?destination=&status=)
+app.Get("/invoices", func(c *fiber.Ctx) error {
+	result, err := invoices.GetInvoices(c.Query("destination", ""), c.Query("status", ""), int64(c.QueryInt("limit", 100)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(result)
+})
+
+// Relatório de conciliação por status e ativo (?from=&to= em RFC3339, padrão últimos 30 dias)
+app.Get("/invoices/report", func(c *fiber.Ctx) error {
+	to := time.Now()
+	from := to.AddDate(0, 0, -30)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid from"})
+		}
+		from = parsed
+	}
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid to"})
+		}
+		to = parsed
+	}
+
+	report, err := invoices.Report(from, to, c.Query("destination", ""))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"from": from, "to": to, "entries": report})
+})
+
+// Detalhes de uma cobrança e pagamentos aplicados
+app.Get("/invoices/:id", func(c *fiber.Ctx) error {
+	invoice, err := invoices.GetInvoice(c.Params("id"))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Invoice not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(invoice)
+})
+
+
+// ==================================================================================================STATE CHANGES===============================================================================================================
+// Histórico de mudanças de um objeto do ledger
+app.Get("/state/objects/:object_key/history", func(c *fiber.Ctx) error {
+	changes, err := transactions.GetObjectHistory(c.Params("object_key"), int64(c.QueryInt("limit", 100)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(changes)
+})
+
+// Mudanças de estado de um ledger
+app.Get("/state/ledgers/:ledger_index", func(c *fiber.Ctx) error {
+	ledgerIndex, err := c.ParamsInt("ledger_index")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ledger_index"})
+	}
+
+	changes, err := transactions.GetLedgerStateChanges(ledgerIndex)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(changes)
+})
+
+// Extrair (ou reprocessar) as mudanças de estado de um ledger histórico
+app.Post("/state/ledgers/:ledger_index", func(c *fiber.Ctx) error {
+	ledgerIndex, err := c.ParamsInt("ledger_index")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ledger_index"})
+	}
+
+	if err := transactions.IngestLedgerStateChanges(httpClient, ledgerIndex); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "State changes ingested", "ledger_index": ledgerIndex})
+})
+
+// Mudanças de estado de objetos que referenciam uma conta
+app.Get("/state/accounts/:account", func(c *fiber.Ctx) error {
+	changes, err := transactions.GetAccountStateChanges(c.Params("account"), c.Query("object_type", ""), int64(c.QueryInt("limit", 100)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(changes)
+})
+
+// ==================================================================================================NETWORK SETTINGS===============================================================================================================
+// Current network settings: fees, reserves and amendment status
+app.Get("/network/settings", func(c *fiber.Ctx) error {
+	feeSettings, err := network.FetchFeeSettings(httpClient, c.Query("ledger_index", "validated"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	amendments, err := network.FetchAmendments(httpClient, c.Query("ledger_index", "validated"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"current":      network.CurrentSettings(),
+		"fee_settings": feeSettings.Result.Node,
+		"amendments":   amendments.Result.Node,
+		"ledger_index": amendments.Result.LedgerIndex,
+	})
+})
+
+// Amendment lifecycle history (gained majority, lost majority, enabled)
+app.Get("/network/amendments/history", func(c *fiber.Ctx) error {
+	events, err := network.GetAmendmentHistory(c.Query("amendment", ""), int64(c.QueryInt("limit", 100)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(events)
+})
+
+// Fee and reserve change history
+app.Get("/network/fees/history", func(c *fiber.Ctx) error {
+	changes, err := network.GetFeeHistory(int64(c.QueryInt("limit", 100)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(changes)
+})
+
+// ==================================================================================================VALIDATORS===============================================================================================================
+// Iniciar coleta dos streams de validations e manifests
+app.Post("/validators/stream", func(c *fiber.Ctx) error {
+	mu.Lock()
+	if _, exists := stopChans["validators"]; exists {
+		mu.Unlock()
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Validators stream already running"})
+	}
+	validatorsStop := make(chan struct{})
+	stopChans["validators"] = validatorsStop
+	mu.Unlock()
+
+	go validators.StreamValidators(wsClient, validatorsStop)
+
+	return c.JSON(fiber.Map{"message": "Subscribed to validations and manifests streams"})
+})
+
+// Encerrar coleta de validações
+app.Delete("/validators/stream", func(c *fiber.Ctx) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	validatorsStop, exists := stopChans["validators"]
+	if !exists {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "No active validators stream"})
+	}
+	close(validatorsStop)
+	delete(stopChans, "validators")
+	return c.JSON(fiber.Map{"message": "Validators stream stopped"})
+})
+
+// Relatório de concordância por validador em janelas móveis (ex: ?windows=256,1024)
+app.Get("/validators/report", func(c *fiber.Ctx) error {
+	windows := []int{}
+	for _, value := range strings.Split(c.Query("windows", "256"), ",") {
+		window, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || window <= 0 || window > validators.MaxTrackedLedgers {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid window: " + value})
+		}
+		windows = append(windows, window)
+	}
+
+	reports := fiber.Map{}
+	for _, window := range windows {
+		reports[strconv.Itoa(window)] = validators.DefaultCollector.Report(window)
+	}
+	return c.JSON(reports)
+})
+
+// Mapeamento de chaves efêmeras para chaves mestras
+app.Get("/validators/manifests", func(c *fiber.Ctx) error {
+	manifests, err := validators.GetManifests()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(manifests)
+})
+
+// ==================================================================================================TRANSACTIONS===============================================================================================================
+app.Post("/transactions/entry", func(c *fiber.Ctx) error {
+	var payload transactions.TransactionEntryParam
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+	
+	response, err := transactions.FetchTransactionEntry(httpClient, payload.TxHash, payload.LedgerIndex)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	
+	var result map[string]interface{}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to parse response"})
+	}
+	
+	return c.JSON(result)
+})
+
+// Transaction Details - HTTP POST
+app.Post("/transactions", func(c *fiber.Ctx) error {
+	var payload transactions.TransactionParam
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+	
+	response, err := transactions.FetchTransaction(httpClient, payload.Transaction, payload.Binary)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	
+	var result map[string]interface{}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to parse response"})
+	}
+	return c.JSON(result)
+})
+
+// Transação normalizada (envelope comum + corpo tipado)
+app.Get("/transactions/normalized/:hash", func(c *fiber.Ctx) error {
+	tx, err := transactions.FetchNormalizedTransaction(httpClient, c.Params("hash"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if c.QueryBool("save", false) {
+		if err := transactions.SaveTransaction(tx); err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 		}
+	}
+	return c.JSON(tx)
+})
 
-		wsClient.ReadMessages(func(message []byte) {
-			var response accounts.AccountLinesWSResponse
-			err := json.Unmarshal(message, &response)
-			if err != nil {
-				return
-			}
-			log.Printf("Real-time data: %+v", response)
-		})
+// Tipos de transação com corpo tipado registrado
+app.Get("/transactions/types", func(c *fiber.Ctx) error {
+	types := transactions.RegisteredTypes()
+	sort.Strings(types)
+	return c.JSON(types)
+})
 
-		return c.JSON(fiber.Map{"message": "Subscribed to account_lines"})
-	})
+// Ingestão global do stream "transactions" com filtros e sinks declarativos
+app.Post("/transactions/ingest", func(c *fiber.Ctx) error {
+	var cfg ingest.Config
+	if err := c.BodyParser(&cfg); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
 
-	app.Post("/accounts/nfts/historical", func(c *fiber.Ctx) error {
-		var payload accounts.AccountNFTsRequest
-		if err := c.BodyParser(&payload); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
-		}
+	if err := ingest.Start(wsClient, &cfg); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Transactions ingestion started", "config": cfg})
+})
 
-		response, err := httpClient.Post("", payload)
+// Parar a ingestão global de transações
+app.Delete("/transactions/ingest", func(c *fiber.Ctx) error {
+	if err := ingest.Stop(wsClient); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Transactions ingestion stopped"})
+})
+
+// Configuração e contadores da ingestão global
+app.Get("/transactions/ingest", func(c *fiber.Ctx) error {
+	return c.JSON(ingest.CurrentStatus())
+})
+
+// Taxa de falhas por código de resultado (?account=&type=&group_by=&from=&to=, padrão últimas 24h)
+app.Get("/transactions/results/stats", func(c *fiber.Ctx) error {
+	filter := transactions.ResultStatsFilter{
+		Account:         c.Query("account", ""),
+		TransactionType: c.Query("type", ""),
+		GroupBy:         c.Query("group_by", transactions.GroupByType),
+		To:              time.Now(),
+	}
+	filter.From = filter.To.Add(-24 * time.Hour)
+
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid from"})
 		}
-
-		var decodedResponse map[string]interface{}
-		err = json.Unmarshal(response, &decodedResponse)
+		filter.From = parsed
+	}
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to decode JSON response"})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid to"})
 		}
+		filter.To = parsed
+	}
 
-		return c.JSON(decodedResponse)
-	})
+	stats, err := transactions.GetResultStats(filter)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"from": filter.From, "to": filter.To, "group_by": filter.GroupBy, "groups": stats})
+})
 
-	app.Get("/accounts/:account/nfts/realtime", func(c *fiber.Ctx) error {
-		account := c.Params("account")
-		ledgerIndex := c.Query("ledger_index", "validated")
-		limit := c.QueryInt("limit", 100)
+// Buscar transações pelo conteúdo decodificado dos memos (ex.: referência do cliente)
+app.Get("/transactions/search", func(c *fiber.Ctx) error {
+	memo := c.Query("memo")
+	if memo == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "memo query parameter is required"})
+	}
 
-		go accounts.StreamAccountNFTs(wsClient, account, ledgerIndex, limit, func(data *accounts.AccountNFTsResponse) {
-			log.Printf("Real-time data: %+v", data)
-		})
+	documents, err := transactions.SearchMemos(memo, c.Query("account", ""), int64(c.QueryInt("limit", 100)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(documents)
+})
 
-		return c.JSON(fiber.Map{"message": "Subscribed to account_nfts"})
-	})
+// Decodificar os memos de transações salvas antes da decodificação
+app.Post("/transactions/memos/backfill", func(c *fiber.Ctx) error {
+	updated, err := transactions.BackfillMemos(int64(c.QueryInt("limit", 1000)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"updated": updated})
+})
 
-	// Similarly add routes for gateway balances
-	app.Post("/accounts/balances/historical", func(c *fiber.Ctx) error {
-		var payload accounts.GatewayBalancesRequest
+// Acompanhar o ciclo de vida de uma transação por hash (callback_url opcional)
+app.Post("/transactions/:hash/track", func(c *fiber.Ctx) error {
+	var payload struct {
+		CallbackURL string `json:"callback_url"`
+	}
+	if len(c.Body()) > 0 {
 		if err := c.BodyParser(&payload); err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
 		}
+	}
 
-		response, err := httpClient.Post("", payload)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
-		}
+	tracked, err := tracker.DefaultTracker.Track(httpClient, c.Params("hash"), payload.CallbackURL)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusAccepted).JSON(tracked)
+})
 
-		var decodedResponse map[string]interface{}
-		err = json.Unmarshal(response, &decodedResponse)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to decode JSON response"})
+// Estado atual de uma transação acompanhada
+app.Get("/transactions/:hash/track", func(c *fiber.Ctx) error {
+	tracked, err := tracker.DefaultTracker.Get(c.Params("hash"))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Transaction not tracked"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(tracked)
+})
+
+// Parar de acompanhar uma transação
+app.Delete("/transactions/:hash/track", func(c *fiber.Ctx) error {
+	err := tracker.DefaultTracker.Untrack(c.Params("hash"))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Transaction not tracked"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Transaction tracking stopped"})
+})
+
+// Mudanças de estado de uma transação acompanhada via Server-Sent Events
+app.Get("/transactions/:hash/track/stream", func(c *fiber.Ctx) error {
+	hash := c.Params("hash")
+	tracked, err := tracker.DefaultTracker.Get(hash)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Transaction not tracked"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	events, stop := tracker.DefaultTracker.Listen(hash)
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer stop()
+
+		if !writeSSE(w, "status", tracked) || tracked.Final() {
+			return
 		}
 
-		return c.JSON(decodedResponse)
+		keepAlive := time.NewTicker(15 * time.Second)
+		defer keepAlive.Stop()
+
+		for {
+			select {
+			case event := <-events:
+				if !writeSSE(w, "status", event) || event.Final {
+					return
+				}
+			case <-keepAlive.C:
+				if _, err := w.WriteString(": keep-alive\n\n"); err != nil || w.Flush() != nil {
+					return
+				}
+			}
+		}
 	})
+	return nil
+})
 
-	// Gateway Balances - Real-time
-	app.Get("/accounts/:account/balances/realtime", func(c *fiber.Ctx) error {
-		account := c.Params("account")
-		ledgerIndex := c.Query("ledger_index", "validated")
-		hotWallet := c.Query("hotwallet", "") // Example: "wallet1,wallet2"
-		strict := c.QueryBool("strict", false)
+// Transações salvas de uma conta
+app.Get("/accounts/:account/transactions", func(c *fiber.Ctx) error {
+	documents, err := transactions.GetAccountTransactions(c.Params("account"), c.Query("type", ""), int64(c.QueryInt("limit", 100)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(documents)
+})
 
-		hotWallets := []string{}
-		if hotWallet != "" {
-			hotWallets = append(hotWallets, hotWallet)
+// Sincronizar o histórico completo de uma conta (account_tx) e mantê-lo atualizado pelo stream
+app.Post("/accounts/:account/sync", func(c *fiber.Ctx) error {
+	account := c.Params("account")
+	if err := accounts.StartAccountSync(httpClient, wsClient, account); err != nil {
+		if errors.Is(err, accounts.ErrSyncRunning) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
 		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"message": "Account sync started", "account": account})
+})
 
-		go accounts.StreamGatewayBalances(wsClient, account, hotWallets, ledgerIndex, strict, func(data *accounts.GatewayBalancesResponse) {
-			log.Printf("Real-time data: %+v", data)
-		})
+// Progresso da sincronização de uma conta
+app.Get("/accounts/:account/sync", func(c *fiber.Ctx) error {
+	progress, err := accounts.GetAccountSync(c.Params("account"))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No sync found for account"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(progress)
+})
 
-		return c.JSON(fiber.Map{"message": "Subscribed to gateway_balances"})
-	})
+// Portfólio completo de uma conta em um único ledger validado
+app.Get("/accounts/:account/portfolio", func(c *fiber.Ctx) error {
+	portfolio, err := accounts.GetPortfolio(httpClient, c.Params("account"))
+	var rpcErr *transactions.RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == "actNotFound" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Account not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(portfolio)
+})
 
-	
-	
-	// ==================================================================================================LEDGER DATA===============================================================================================================
-app.Post("/ledger", func(c *fiber.Ctx) error {
-	var payload ledger.LedgerParam
-	if err := c.BodyParser(&payload); err != nil {
-		log.Printf("❌ Erro ao interpretar o payload: %v", err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+// Flags e configurações decodificadas de uma conta (?ledger_index=, padrão validated)
+app.Get("/accounts/:account/settings", func(c *fiber.Ctx) error {
+	settings, ledgerIndex, err := accounts.FetchAccountSettings(httpClient, c.Params("account"), c.Query("ledger_index", "validated"))
+	var rpcErr *transactions.RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == "actNotFound" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Account not found"})
 	}
-	if payload.LedgerIndex == "" {
-    return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "LedgerIndex é obrigatório"})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"account": c.Params("account"), "ledger_index": ledgerIndex, "settings": settings})
+})
+
+// Reservas e saldo gastável de uma conta, com a reserva por tipo de objeto (?ledger_index=, padrão validated)
+app.Get("/accounts/:account/reserves", func(c *fiber.Ctx) error {
+	reserves, err := accounts.GetReserves(httpClient, c.Params("account"), c.Query("ledger_index", "validated"))
+	var rpcErr *transactions.RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == "actNotFound" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Account not found"})
 	}
-	response, err := ledger.FetchLedgerInfo(httpClient, payload.LedgerIndex)
 	if err != nil {
-		log.Printf("❌ Erro ao buscar dados do ledger: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Error fetching ledger data"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
-	return c.JSON(response)
+	return c.JSON(reserves)
 })
-	
-var stopChan chan struct{}
-	
-app.Get("/ledger/realtime", func(c *fiber.Ctx) error {
-	stopChan = make(chan struct{})
-	go ledger.StreamLedger(wsClient, httpClient, func(data *ledger.LedgerSubscribeClosedResponse) {
-		
-	}, stopChan)
-	
-	return c.JSON(fiber.Map{"message": "📡 Streaming de ledgers iniciado!"})
+
+// Histórico de alterações de flags e configurações de uma conta (AccountSet, SetRegularKey...)
+app.Get("/accounts/:account/settings/history", func(c *fiber.Ctx) error {
+	history, err := accounts.GetSettingsHistory(c.Params("account"), int64(c.QueryInt("limit", 100)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"account": c.Params("account"), "changes": history})
 })
 
-app.Get("/ledger/stop", func(c *fiber.Ctx) error {
-	close(stopChan)
-	return c.JSON(fiber.Map{"message": "⛔ Streaming de ledgers encerrado!"})
+// Cadeia de ativadores de uma conta (quem a financiou, quem financiou o ativador...)
+app.Get("/accounts/:account/activation", func(c *fiber.Ctx) error {
+	chain, err := activations.GetChain(c.Params("account"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"account": c.Params("account"), "chain": chain})
+})
+
+// Contas ativadas (financiadas na criação) por uma conta
+app.Get("/accounts/:account/activated", func(c *fiber.Ctx) error {
+	activated, err := activations.GetActivated(c.Params("account"), int64(c.QueryInt("limit", 1000)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"account": c.Params("account"), "activated": activated})
 })
 
+// Série temporal de saldos de uma conta (?currency=&issuer=&from_ledger=&to_ledger=)
+app.Get("/accounts/:account/balances/history", func(c *fiber.Ctx) error {
+	asset := ""
+	if currency := c.Query("currency"); currency != "" {
+		asset = xrpl.Issue{Currency: currency, Issuer: c.Query("issuer")}.Key()
+	}
 
-// ==================================================================================================TRANSACTIONS===============================================================================================================
-app.Post("/transactions/entry", func(c *fiber.Ctx) error {
-	var payload transactions.TransactionEntryParam
-	if err := c.BodyParser(&payload); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	events, err := balances.GetHistory(c.Params("account"), asset, c.QueryInt("from_ledger", 0), c.QueryInt("to_ledger", 0), int64(c.QueryInt("limit", 1000)))
+	if errors.Is(err, balances.ErrNotSynced) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
 	}
-	
-	response, err := transactions.FetchTransactionEntry(httpClient, payload.TxHash, payload.LedgerIndex)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
-	
-	var result map[string]interface{}
-	if err := json.Unmarshal(response, &result); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to parse response"})
+	return c.JSON(fiber.Map{"account": c.Params("account"), "events": events})
+})
+
+// Saldos de uma conta em um ledger (?ledger_index=) ou instante (?time=RFC3339)
+app.Get("/accounts/:account/balances/at", func(c *fiber.Ctx) error {
+	account := c.Params("account")
+
+	var result []balances.Balance
+	var err error
+	switch {
+	case c.Query("ledger_index") != "":
+		ledgerIndex, parseErr := strconv.Atoi(c.Query("ledger_index"))
+		if parseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ledger_index"})
+		}
+		result, err = balances.GetBalancesAt(account, ledgerIndex)
+	case c.Query("time") != "":
+		at, parseErr := time.Parse(time.RFC3339, c.Query("time"))
+		if parseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid time"})
+		}
+		result, err = balances.GetBalancesAtTime(account, at)
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ledger_index or time is required"})
 	}
-	
-	return c.JSON(result)
+	if errors.Is(err, balances.ErrNotSynced) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"account": account, "balances": result})
 })
 
-// Transaction Details - HTTP POST
-app.Post("/transactions", func(c *fiber.Ctx) error {
-	var payload transactions.TransactionParam
-	if err := c.BodyParser(&payload); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+// Corredores de pagamentos cross-currency (?from=&to=&asset=, padrão últimos 7 dias)
+app.Get("/payments/corridors", func(c *fiber.Ctx) error {
+	to := time.Now()
+	from := to.AddDate(0, 0, -7)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid from"})
+		}
+		from = parsed
 	}
-	
-	response, err := transactions.FetchTransaction(httpClient, payload.Transaction, payload.Binary)
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid to"})
+		}
+		to = parsed
+	}
+
+	corridors, err := transactions.GetCorridors(from, to, c.Query("asset", ""), int64(c.QueryInt("limit", 50)))
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
-	
-	var result map[string]interface{}
-	if err := json.Unmarshal(response, &result); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to parse response"})
+	return c.JSON(fiber.Map{"from": from, "to": to, "corridors": corridors})
+})
+
+// Cotação de pagamento cross-currency com ripple_path_find
+app.Post("/paths/find", func(c *fiber.Ctx) error {
+	var request paths.QuoteRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
 	}
-	return c.JSON(result)
+
+	quote, err := paths.FindPaths(httpClient, request)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(quote)
+})
+
+// Cotações atualizadas via sessão path_find (SSE)
+// ?source_account=&destination_account=&currency=&issuer=&value=&slippage_percent=
+app.Get("/paths/find/stream", func(c *fiber.Ctx) error {
+	request := paths.QuoteRequest{
+		SourceAccount:      c.Query("source_account"),
+		DestinationAccount: c.Query("destination_account"),
+		DestinationAmount: xrpl.Amount{
+			Currency: c.Query("currency", "XRP"),
+			Issuer:   c.Query("issuer"),
+			Value:    c.Query("value"),
+		},
+	}
+	if value := c.Query("slippage_percent"); value != "" {
+		slippage, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid slippage_percent"})
+		}
+		request.SlippagePercent = slippage
+	}
+
+	updates, closeSession, err := paths.Open(wsClient.URL, request)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer closeSession()
+
+		keepAlive := time.NewTicker(15 * time.Second)
+		defer keepAlive.Stop()
+
+		for {
+			select {
+			case quote, ok := <-updates:
+				if !ok {
+					writeSSE(w, "closed", fiber.Map{"message": "Path find session closed"})
+					return
+				}
+				if !writeSSE(w, "quote", quote) {
+					return
+				}
+			case <-keepAlive.C:
+				if _, err := w.WriteString(": keep-alive\n\n"); err != nil || w.Flush() != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+})
+
+// Pagamentos recebidos por uma conta, sempre com o valor efetivamente entregue (delivered_amount)
+app.Get("/accounts/:account/payments/incoming", func(c *fiber.Ctx) error {
+	payments, err := transactions.GetIncomingPayments(c.Params("account"), int64(c.QueryInt("limit", 100)))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(payments)
 })
 
 // Transaction Entry - WebSocket
@@ -572,14 +1703,15 @@ app.Get("/transactions/entry/realtime", func(c *fiber.Ctx) error {
 			LedgerIndex: c.Query("ledger_index", "validated"),
 		}
 		
-		go wsClient.Subscribe(params)
-		
-		wsClient.ReadMessages(func(message []byte) {
+		_, err := wsClient.Request(params, func(message []byte) {
 			var response orderbook.NFTBuyOffersWSResponse
 			if err := json.Unmarshal(message, &response); err == nil {
 				log.Printf("NFT Buy Offers Real-Time: %+v", response)
 			}
 		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
 
 		return c.JSON(fiber.Map{"message": "Subscribed to nft_buy_offers"})
 	})
@@ -613,14 +1745,15 @@ app.Get("/transactions/entry/realtime", func(c *fiber.Ctx) error {
 			LedgerIndex: c.Query("ledger_index", "validated"),
 		}
 
-		go wsClient.Subscribe(params)
-		
-		wsClient.ReadMessages(func(message []byte) {
+		_, err := wsClient.Request(params, func(message []byte) {
 			var response orderbook.NFTSellOffersWSResponse
 			if err := json.Unmarshal(message, &response); err == nil {
 				log.Printf("NFT Sell Offers Real-Time: %+v", response)
 			}
 		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
 
 		return c.JSON(fiber.Map{"message": "Subscribed to nft_sell_offers"})
 	})
@@ -673,3 +1806,14 @@ app.Get("/transactions/entry/realtime", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{"message": "Subscribed to server_state stream"})
 	})
 }
+
+// watchlistResponse maps the result of a watchlist operation to its HTTP response
+func watchlistResponse(c *fiber.Ctx, watchlist *accounts.WatchlistSchema, err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Watchlist not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(watchlist)
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+)
+
+// writeSSE writes one Server-Sent Event with a JSON payload and flushes it.
+// It returns false when the client is gone
+func writeSSE(w *bufio.Writer, event string, data interface{}) bool {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return false
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return false
+	}
+	return w.Flush() == nil
+}
@@ -19,7 +19,7 @@ func FetchFee(client *xrpl.HTTPClient) ([]byte, error) {
 }
 
 // StreamFee streams the current state of fees via WebSocket
-func StreamFee(wsClient *xrpl.WebSocketClient, callback func(*FeeWSResponse)) error {
+func StreamFee(wsClient *xrpl.WebSocketClient, callback func(*FeeWSResponse)) (func(), error) {
 	request := struct {
 		ID      string `json:"id"`
 		Command string `json:"command"`
@@ -28,18 +28,13 @@ func StreamFee(wsClient *xrpl.WebSocketClient, callback func(*FeeWSResponse)) er
 		Command: "fee",
 	}
 
-	err := wsClient.Subscribe(request)
-	if err != nil {
-		return err
-	}
-
-	wsClient.ReadMessages(func(msg []byte) {
+	// Send the command and read its response
+	return wsClient.Request(request, func(msg []byte) {
 		var response FeeWSResponse
 		if err := json.Unmarshal(msg, &response); err == nil {
 			callback(&response)
 		}
 	})
-	return nil
 }
 
 // FeeWSResponse represents the WebSocket response for the fee command
@@ -23,7 +23,7 @@ func FetchServerState(client *xrpl.HTTPClient) ([]byte, error) {
 }
 
 // StreamServerState streams the server state via WebSocket
-func StreamServerState(wsClient *xrpl.WebSocketClient, callback func(*ServerStateWSResponse)) error {
+func StreamServerState(wsClient *xrpl.WebSocketClient, callback func(*ServerStateWSResponse)) (func(), error) {
 	request := struct {
 		ID          int    `json:"id"`
 		Command     string `json:"command"`
@@ -34,18 +34,13 @@ func StreamServerState(wsClient *xrpl.WebSocketClient, callback func(*ServerStat
 		LedgerIndex: "current",
 	}
 
-	err := wsClient.Subscribe(request)
-	if err != nil {
-		return err
-	}
-
-	wsClient.ReadMessages(func(msg []byte) {
+	// Send the command and read its response
+	return wsClient.Request(request, func(msg []byte) {
 		var response ServerStateWSResponse
 		if err := json.Unmarshal(msg, &response); err == nil {
 			callback(&response)
 		}
 	})
-	return nil
 }
 
 // ServerStateWSResponse represents the WebSocket response for the server_state command
@@ -0,0 +1,404 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/ledger"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/pending"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CallbackAttempts is the number of times a status change is posted to a callback URL
+const CallbackAttempts = 3
+
+// MaxNotFoundLedgers stops tracking a hash without LastLedgerSequence that the network has not
+// seen for this many validated ledgers (about 15 minutes)
+const MaxNotFoundLedgers = 256
+
+// Tracker follows the lifecycle of transactions by hash until they are validated or expire
+type Tracker struct {
+	mu        sync.Mutex
+	tracked   map[string]*TrackedTransactionSchema
+	listeners map[string]map[chan StatusEvent]struct{}
+	latest    int
+
+	checkMu sync.Mutex
+	client  *http.Client
+}
+
+// DefaultTracker is the tracker used by the API
+var DefaultTracker = NewTracker()
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		tracked:   make(map[string]*TrackedTransactionSchema),
+		listeners: make(map[string]map[chan StatusEvent]struct{}),
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Track registers a hash and checks its status right away. Tracking an already
+// tracked hash only updates its callback URL
+func (t *Tracker) Track(client *xrpl.HTTPClient, hash string, callbackURL string) (*TrackedTransactionSchema, error) {
+	t.mu.Lock()
+	tracked, ok := t.tracked[hash]
+	if !ok {
+		stored, err := GetTracked(hash)
+		switch {
+		case err == nil:
+			tracked = stored
+		case errors.Is(err, mongo.ErrNoDocuments):
+			tracked = &TrackedTransactionSchema{Hash: hash, Status: StatusNotFound, CreatedAt: time.Now()}
+		default:
+			t.mu.Unlock()
+			return nil, err
+		}
+	}
+	if callbackURL != "" {
+		tracked.CallbackURL = callbackURL
+	}
+	// Tracking a hash given up as not found starts over
+	if tracked.Status == StatusNotFound && tracked.ResolvedAt != nil {
+		tracked.ResolvedAt = nil
+		tracked.NotFoundLedger = 0
+	}
+	if !tracked.Final() {
+		t.tracked[hash] = tracked
+	}
+	copied := *tracked
+	t.mu.Unlock()
+
+	if err := saveTracked(&copied); err != nil {
+		return nil, err
+	}
+
+	if !copied.Final() {
+		go t.check(client, hash, t.latestLedger())
+	}
+	return &copied, nil
+}
+
+// Untrack stops tracking a hash and removes it from the database
+func (t *Tracker) Untrack(hash string) error {
+	t.mu.Lock()
+	delete(t.tracked, hash)
+	t.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := database.GetTrackedTransactionCollection().DeleteOne(ctx, bson.M{"hash": hash})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Get returns the current status of a tracked hash
+func (t *Tracker) Get(hash string) (*TrackedTransactionSchema, error) {
+	t.mu.Lock()
+	if tracked, ok := t.tracked[hash]; ok {
+		copied := *tracked
+		t.mu.Unlock()
+		return &copied, nil
+	}
+	t.mu.Unlock()
+
+	return GetTracked(hash)
+}
+
+// Listen returns a channel with the status changes of a hash and a function to stop listening
+func (t *Tracker) Listen(hash string) (chan StatusEvent, func()) {
+	events := make(chan StatusEvent, 8)
+
+	t.mu.Lock()
+	if t.listeners[hash] == nil {
+		t.listeners[hash] = make(map[chan StatusEvent]struct{})
+	}
+	t.listeners[hash][events] = struct{}{}
+	t.mu.Unlock()
+
+	return events, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.listeners[hash], events)
+		if len(t.listeners[hash]) == 0 {
+			delete(t.listeners, hash)
+		}
+	}
+}
+
+// Restore loads the tracked hashes that are not final yet
+func (t *Tracker) Restore() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": bson.M{"$nin": []string{StatusValidated, StatusExpired}}, "resolved_at": bson.M{"$exists": false}}
+	cursor, err := database.GetTrackedTransactionCollection().Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	restored := []TrackedTransactionSchema{}
+	if err := cursor.All(ctx, &restored); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	for i := range restored {
+		t.tracked[restored[i].Hash] = &restored[i]
+	}
+	t.mu.Unlock()
+
+	log.Printf("✅ %d transações acompanhadas restauradas", len(restored))
+	return nil
+}
+
+// OnValidatedLedger rechecks every tracked hash after a validated ledger
+func (t *Tracker) OnValidatedLedger(client *xrpl.HTTPClient, ledgerIndex int) {
+	t.mu.Lock()
+	if ledgerIndex > t.latest {
+		t.latest = ledgerIndex
+	}
+	t.mu.Unlock()
+
+	t.CheckAll(client)
+}
+
+// Run polls the tracked hashes at a fixed interval against the latest validated ledger
+func (t *Tracker) Run(client *xrpl.HTTPClient, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if len(t.hashes()) == 0 {
+			continue
+		}
+
+		ledgerIndex, err := ledger.FetchValidatedLedgerIndex(client)
+		if err != nil {
+			log.Printf("⚠️ Erro ao buscar o último ledger validado: %v", err)
+			continue
+		}
+		t.OnValidatedLedger(client, ledgerIndex)
+	}
+}
+
+// CheckAll refreshes the status of every tracked hash
+func (t *Tracker) CheckAll(client *xrpl.HTTPClient) {
+	t.checkMu.Lock()
+	defer t.checkMu.Unlock()
+
+	validatedLedger := t.latestLedger()
+	for _, hash := range t.hashes() {
+		t.check(client, hash, validatedLedger)
+	}
+}
+
+// check looks the hash up and applies the resulting status. validatedLedger is read before
+// the lookup, so a transaction missing from it can no longer be included once
+// validatedLedger reaches its LastLedgerSequence
+func (t *Tracker) check(client *xrpl.HTTPClient, hash string, validatedLedger int) {
+	status := StatusNotFound
+	var result string
+	var ledgerIndex int
+	var lastLedgerSequence uint32
+
+	tx, err := transactions.FetchNormalizedTransaction(client, hash)
+	var rpcErr *transactions.RPCError
+	switch {
+	case err == nil:
+		lastLedgerSequence = tx.LastLedgerSequence
+		status = StatusPending
+		if tx.Validated {
+			status = StatusValidated
+			result = tx.Result
+			ledgerIndex = tx.LedgerIndex
+		}
+	case errors.As(err, &rpcErr) && rpcErr.Code == "txnNotFound":
+		if entry, ok := pending.DefaultPool.Get(hash); ok && entry.Status == pending.StatusPending {
+			status = StatusPending
+			lastLedgerSequence = entry.LastLedgerSequence
+		}
+	default:
+		log.Printf("⚠️ Erro ao consultar a transação %s: %v", hash, err)
+		return
+	}
+
+	t.update(hash, status, result, ledgerIndex, lastLedgerSequence, validatedLedger)
+}
+
+// reconcileStatus decides the status of a tracked hash from a lookup. A transaction that is not
+// validated expires once the validated ledger reaches its LastLedgerSequence; without one, a hash
+// that stays not found for MaxNotFoundLedgers is given up as not_found. It also returns the
+// ledger the hash was first missing at
+func reconcileStatus(tracked *TrackedTransactionSchema, status string, lastLedgerSequence uint32, validatedLedger int) (string, int, bool) {
+	notFoundLedger := 0
+	if status == StatusNotFound {
+		notFoundLedger = tracked.NotFoundLedger
+		if notFoundLedger == 0 {
+			notFoundLedger = validatedLedger
+		}
+	}
+
+	switch {
+	case status == StatusValidated:
+		return status, 0, true
+	case lastLedgerSequence > 0 && validatedLedger >= int(lastLedgerSequence):
+		return StatusExpired, 0, true
+	case status == StatusNotFound && lastLedgerSequence == 0 && notFoundLedger > 0 && validatedLedger-notFoundLedger >= MaxNotFoundLedgers:
+		return status, notFoundLedger, true
+	}
+	return status, notFoundLedger, false
+}
+
+func (t *Tracker) update(hash string, status string, result string, ledgerIndex int, lastLedgerSequence uint32, validatedLedger int) {
+	t.mu.Lock()
+	tracked, ok := t.tracked[hash]
+	if !ok {
+		t.mu.Unlock()
+		return
+	}
+
+	if lastLedgerSequence == 0 {
+		lastLedgerSequence = tracked.LastLedgerSequence
+	}
+	status, notFoundLedger, final := reconcileStatus(tracked, status, lastLedgerSequence, validatedLedger)
+	if status == tracked.Status && lastLedgerSequence == tracked.LastLedgerSequence && !final {
+		changed := notFoundLedger != tracked.NotFoundLedger
+		tracked.NotFoundLedger = notFoundLedger
+		copied := *tracked
+		t.mu.Unlock()
+
+		if changed {
+			if err := saveTracked(&copied); err != nil {
+				log.Printf("❌ Erro ao salvar transação acompanhada %s: %v", hash, err)
+			}
+		}
+		return
+	}
+
+	now := time.Now()
+	tracked.Status = status
+	tracked.Result = result
+	tracked.LedgerIndex = ledgerIndex
+	tracked.LastLedgerSequence = lastLedgerSequence
+	tracked.NotFoundLedger = notFoundLedger
+	tracked.UpdatedAt = now
+	if final {
+		tracked.ResolvedAt = &now
+		delete(t.tracked, hash)
+	}
+	copied := *tracked
+
+	event := StatusEvent{
+		Hash:               hash,
+		Status:             status,
+		Result:             result,
+		LedgerIndex:        ledgerIndex,
+		LastLedgerSequence: lastLedgerSequence,
+		ValidatedLedger:    validatedLedger,
+		Final:              final,
+		Time:               now,
+	}
+	for listener := range t.listeners[hash] {
+		select {
+		case listener <- event:
+		default:
+		}
+	}
+	t.mu.Unlock()
+
+	log.Printf("🔔 Transação %s: %s %s", hash, status, result)
+	if err := saveTracked(&copied); err != nil {
+		log.Printf("❌ Erro ao salvar transação acompanhada %s: %v", hash, err)
+	}
+	if copied.CallbackURL != "" {
+		go t.notify(copied.CallbackURL, event)
+	}
+}
+
+// notify posts a status change to a callback URL, retrying with backoff
+func (t *Tracker) notify(url string, event StatusEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	for attempt := 1; attempt <= CallbackAttempts; attempt++ {
+		err = t.post(url, body)
+		if err == nil {
+			return
+		}
+		time.Sleep(time.Duration(attempt) * 2 * time.Second)
+	}
+	log.Printf("❌ Erro ao enviar callback de %s para %s: %v", event.Hash, url, err)
+}
+
+func (t *Tracker) post(url string, body []byte) error {
+	resp, err := t.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (t *Tracker) latestLedger() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.latest
+}
+
+func (t *Tracker) hashes() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	hashes := make([]string, 0, len(t.tracked))
+	for hash := range t.tracked {
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+func saveTracked(tracked *TrackedTransactionSchema) error {
+	tracked.UpdatedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := database.GetTrackedTransactionCollection().ReplaceOne(ctx, bson.M{"hash": tracked.Hash}, tracked, options.Replace().SetUpsert(true))
+	return err
+}
+
+// GetTracked returns a tracked transaction from the database
+func GetTracked(hash string) (*TrackedTransactionSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var tracked TrackedTransactionSchema
+	if err := database.GetTrackedTransactionCollection().FindOne(ctx, bson.M{"hash": hash}).Decode(&tracked); err != nil {
+		return nil, err
+	}
+	return &tracked, nil
+}
@@ -0,0 +1,73 @@
+package tracker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReconcileStatus(t *testing.T) {
+	tests := []struct {
+		name               string
+		tracked            TrackedTransactionSchema
+		status             string
+		lastLedgerSequence uint32
+		validatedLedger    int
+		wantStatus         string
+		wantNotFound       int
+		wantFinal          bool
+	}{
+		{name: "validated", status: StatusValidated, lastLedgerSequence: 110, validatedLedger: 120, wantStatus: StatusValidated, wantFinal: true},
+		{name: "pending before LastLedgerSequence", status: StatusPending, lastLedgerSequence: 110, validatedLedger: 109, wantStatus: StatusPending},
+		{name: "pending reaches LastLedgerSequence", status: StatusPending, lastLedgerSequence: 110, validatedLedger: 110, wantStatus: StatusExpired, wantFinal: true},
+		{name: "not found past LastLedgerSequence", status: StatusNotFound, lastLedgerSequence: 110, validatedLedger: 111, wantStatus: StatusExpired, wantFinal: true},
+		{name: "first not found lookup", status: StatusNotFound, validatedLedger: 100, wantStatus: StatusNotFound, wantNotFound: 100},
+		{
+			name:    "not found within the limit",
+			tracked: TrackedTransactionSchema{Status: StatusNotFound, NotFoundLedger: 100},
+			status:  StatusNotFound, validatedLedger: 100 + MaxNotFoundLedgers - 1,
+			wantStatus: StatusNotFound, wantNotFound: 100,
+		},
+		{
+			name:    "not found for too long",
+			tracked: TrackedTransactionSchema{Status: StatusNotFound, NotFoundLedger: 100},
+			status:  StatusNotFound, validatedLedger: 100 + MaxNotFoundLedgers,
+			wantStatus: StatusNotFound, wantNotFound: 100, wantFinal: true,
+		},
+		{
+			name:    "seen again resets the not found ledger",
+			tracked: TrackedTransactionSchema{Status: StatusNotFound, NotFoundLedger: 100},
+			status:  StatusPending, validatedLedger: 100 + MaxNotFoundLedgers,
+			wantStatus: StatusPending,
+		},
+		{name: "no validated ledger yet", status: StatusNotFound, wantStatus: StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, notFound, final := reconcileStatus(&tt.tracked, tt.status, tt.lastLedgerSequence, tt.validatedLedger)
+			if status != tt.wantStatus || notFound != tt.wantNotFound || final != tt.wantFinal {
+				t.Fatalf("reconcileStatus = %s, %d, %v; want %s, %d, %v", status, notFound, final, tt.wantStatus, tt.wantNotFound, tt.wantFinal)
+			}
+		})
+	}
+}
+
+func TestFinal(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		tracked TrackedTransactionSchema
+		want    bool
+	}{
+		{tracked: TrackedTransactionSchema{Status: StatusPending}, want: false},
+		{tracked: TrackedTransactionSchema{Status: StatusNotFound}, want: false},
+		{tracked: TrackedTransactionSchema{Status: StatusNotFound, ResolvedAt: &now}, want: true},
+		{tracked: TrackedTransactionSchema{Status: StatusValidated}, want: true},
+		{tracked: TrackedTransactionSchema{Status: StatusExpired}, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.tracked.Final(); got != tt.want {
+			t.Errorf("Final(%+v) = %v, want %v", tt.tracked, got, tt.want)
+		}
+	}
+}
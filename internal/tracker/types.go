@@ -0,0 +1,43 @@
+package tracker
+
+import "time"
+
+// Lifecycle status of a tracked transaction
+const (
+	StatusNotFound  = "not_found"
+	StatusPending   = "pending"
+	StatusValidated = "validated"
+	StatusExpired   = "expired"
+)
+
+// TrackedTransactionSchema define uma transação acompanhada por hash, salva no MongoDB
+type TrackedTransactionSchema struct {
+	Hash               string     `bson:"hash" json:"hash"`
+	Status             string     `bson:"status" json:"status"`
+	Result             string     `bson:"result,omitempty" json:"result,omitempty"`
+	LedgerIndex        int        `bson:"ledger_index,omitempty" json:"ledger_index,omitempty"`
+	LastLedgerSequence uint32     `bson:"last_ledger_sequence,omitempty" json:"last_ledger_sequence,omitempty"`
+	NotFoundLedger     int        `bson:"not_found_ledger,omitempty" json:"not_found_ledger,omitempty"` // First validated ledger without the hash
+	CallbackURL        string     `bson:"callback_url,omitempty" json:"callback_url,omitempty"`
+	CreatedAt          time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt          time.Time  `bson:"updated_at" json:"updated_at"`
+	ResolvedAt         *time.Time `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+}
+
+// Final reports whether the status can no longer change. A not_found hash becomes final once
+// it stayed unknown for MaxNotFoundLedgers
+func (t *TrackedTransactionSchema) Final() bool {
+	return t.Status == StatusValidated || t.Status == StatusExpired || (t.Status == StatusNotFound && t.ResolvedAt != nil)
+}
+
+// StatusEvent is pushed to stream listeners and callback URLs when the status changes
+type StatusEvent struct {
+	Hash               string    `json:"hash"`
+	Status             string    `json:"status"`
+	Result             string    `json:"result,omitempty"`
+	LedgerIndex        int       `json:"ledger_index,omitempty"`
+	LastLedgerSequence uint32    `json:"last_ledger_sequence,omitempty"`
+	ValidatedLedger    int       `json:"validated_ledger"`
+	Final              bool      `json:"final"`
+	Time               time.Time `json:"time"`
+}
@@ -0,0 +1,60 @@
+package transactions
+
+import "github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+
+// Amounts returns the amounts moved or offered by a transaction. Payments and CheckCash
+// report the delivered amount when it is known
+func Amounts(tx *Transaction) []xrpl.Amount {
+	if tx.DeliveredAmount != nil {
+		return []xrpl.Amount{*tx.DeliveredAmount}
+	}
+
+	amounts := []xrpl.Amount{}
+	add := func(values ...*xrpl.Amount) {
+		for _, value := range values {
+			if value != nil && value.Value != "" {
+				amounts = append(amounts, *value)
+			}
+		}
+	}
+
+	switch body := tx.Body.(type) {
+	case *Payment:
+		add(&body.Amount)
+	case *OfferCreate:
+		add(&body.TakerGets, &body.TakerPays)
+	case *TrustSet:
+		add(&body.LimitAmount)
+	case *Clawback:
+		add(&body.Amount)
+	case *AMMCreate:
+		add(&body.Amount, &body.Amount2)
+	case *AMMDeposit:
+		add(body.Amount, body.Amount2)
+	case *AMMWithdraw:
+		add(body.Amount, body.Amount2)
+	case *AMMClawback:
+		add(body.Amount)
+	case *NFTokenCreateOffer:
+		add(&body.Amount)
+	case *EscrowCreate:
+		add(&body.Amount)
+	case *PaymentChannelCreate:
+		add(&body.Amount)
+	case *PaymentChannelFund:
+		add(&body.Amount)
+	case *PaymentChannelClaim:
+		add(body.Amount)
+	case *CheckCreate:
+		add(&body.SendMax)
+	case *CheckCash:
+		add(body.Amount, body.DeliverMin)
+	case *XChainCommit:
+		add(&body.Amount)
+	case *XChainClaim:
+		add(&body.Amount)
+	case *XChainAccountCreateCommit:
+		add(&body.Amount)
+	}
+	return amounts
+}
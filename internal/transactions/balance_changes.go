@@ -0,0 +1,90 @@
+package transactions
+
+import "github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+
+// BalanceChange defines the change of one account balance caused by a transaction.
+// Value is the signed delta: drops for XRP, token units otherwise. For tokens, Issuer
+// is the counterparty of the trust line from the account's point of view
+type BalanceChange struct {
+	Account  string `json:"account" bson:"account"`
+	Currency string `json:"currency" bson:"currency"`
+	Issuer   string `json:"issuer,omitempty" bson:"issuer,omitempty"`
+	Value    string `json:"value" bson:"value"`
+	Balance  string `json:"balance" bson:"balance"`
+}
+
+// BalanceChanges derives XRP and token balance changes from AccountRoot and RippleState nodes
+func BalanceChanges(meta *TransactionMeta) []BalanceChange {
+	changes := []BalanceChange{}
+	if meta == nil {
+		return changes
+	}
+
+	for _, affected := range meta.AffectedNodes {
+		nodeType, node := affected.Node()
+		if node == nil {
+			continue
+		}
+
+		switch node.LedgerEntryType {
+		case "AccountRoot":
+			if change, ok := accountRootChange(nodeType, node); ok {
+				changes = append(changes, change)
+			}
+		case "RippleState":
+			changes = append(changes, rippleStateChanges(nodeType, node)...)
+		}
+	}
+	return changes
+}
+
+func accountRootChange(nodeType string, node *LedgerNode) (BalanceChange, bool) {
+	fields := node.Fields()
+	account, _ := fields["Account"].(string)
+	after, _ := fields["Balance"].(string)
+
+	before := "0"
+	if nodeType != NodeCreated {
+		previous, ok := node.PreviousFields["Balance"].(string)
+		if !ok {
+			return BalanceChange{}, false
+		}
+		before = previous
+	}
+
+	delta := xrpl.SubtractValues(after, before)
+	if delta == "0" {
+		return BalanceChange{}, false
+	}
+	return BalanceChange{Account: account, Currency: "XRP", Value: delta, Balance: after}, true
+}
+
+// The RippleState balance is stored from the low account's point of view
+func rippleStateChanges(nodeType string, node *LedgerNode) []BalanceChange {
+	fields := node.Fields()
+	final, ok := xrpl.ParseAmount(fields["Balance"])
+	if !ok {
+		return nil
+	}
+
+	before := "0"
+	if nodeType != NodeCreated {
+		previous, ok := xrpl.ParseAmount(node.PreviousFields["Balance"])
+		if !ok {
+			return nil
+		}
+		before = previous.Value
+	}
+
+	delta := xrpl.SubtractValues(final.Value, before)
+	if delta == "0" {
+		return nil
+	}
+
+	low := amountIssuer(fields["LowLimit"])
+	high := amountIssuer(fields["HighLimit"])
+	return []BalanceChange{
+		{Account: low, Currency: final.Currency, Issuer: high, Value: delta, Balance: final.Value},
+		{Account: high, Currency: final.Currency, Issuer: low, Value: xrpl.NegateValue(delta), Balance: xrpl.NegateValue(final.Value)},
+	}
+}
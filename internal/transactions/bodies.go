@@ -0,0 +1,431 @@
+package transactions
+
+import "github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+
+// Typed transaction bodies. JSON tags follow rippled field names so bodies can be
+// decoded straight from tx_json; BSON tags follow the snake_case used in MongoDB.
+
+// PathStep defines one step of a payment path
+type PathStep struct {
+	Account  string `json:"account,omitempty" bson:"account,omitempty"`
+	Currency string `json:"currency,omitempty" bson:"currency,omitempty"`
+	Issuer   string `json:"issuer,omitempty" bson:"issuer,omitempty"`
+}
+
+// ---------- Payments and DEX ----------
+
+type Payment struct {
+	Destination    string       `json:"Destination" bson:"destination"`
+	DestinationTag *uint32      `json:"DestinationTag,omitempty" bson:"destination_tag,omitempty"`
+	Amount         xrpl.Amount  `json:"Amount" bson:"amount"`
+	DeliverMax     *xrpl.Amount `json:"DeliverMax,omitempty" bson:"-"` // API v2 name of Amount, copied into it by Normalize
+	SendMax        *xrpl.Amount `json:"SendMax,omitempty" bson:"send_max,omitempty"`
+	DeliverMin     *xrpl.Amount `json:"DeliverMin,omitempty" bson:"deliver_min,omitempty"`
+	InvoiceID      string       `json:"InvoiceID,omitempty" bson:"invoice_id,omitempty"`
+	Paths          [][]PathStep `json:"Paths,omitempty" bson:"paths,omitempty"`
+	CredentialIDs  []string     `json:"CredentialIDs,omitempty" bson:"credential_ids,omitempty"`
+}
+
+type OfferCreate struct {
+	TakerGets     xrpl.Amount `json:"TakerGets" bson:"taker_gets"`
+	TakerPays     xrpl.Amount `json:"TakerPays" bson:"taker_pays"`
+	Expiration    uint32      `json:"Expiration,omitempty" bson:"expiration,omitempty"`
+	OfferSequence uint32      `json:"OfferSequence,omitempty" bson:"offer_sequence,omitempty"`
+}
+
+type OfferCancel struct {
+	OfferSequence uint32 `json:"OfferSequence" bson:"offer_sequence"`
+}
+
+type TrustSet struct {
+	LimitAmount xrpl.Amount `json:"LimitAmount" bson:"limit_amount"`
+	QualityIn   uint32      `json:"QualityIn,omitempty" bson:"quality_in,omitempty"`
+	QualityOut  uint32      `json:"QualityOut,omitempty" bson:"quality_out,omitempty"`
+}
+
+type Clawback struct {
+	Amount xrpl.Amount `json:"Amount" bson:"amount"`
+	Holder string      `json:"Holder,omitempty" bson:"holder,omitempty"`
+}
+
+// ---------- AMM ----------
+
+type AMMCreate struct {
+	Amount     xrpl.Amount `json:"Amount" bson:"amount"`
+	Amount2    xrpl.Amount `json:"Amount2" bson:"amount2"`
+	TradingFee uint16      `json:"TradingFee" bson:"trading_fee"`
+}
+
+type AMMDeposit struct {
+	Asset      xrpl.Issue   `json:"Asset" bson:"asset"`
+	Asset2     xrpl.Issue   `json:"Asset2" bson:"asset2"`
+	Amount     *xrpl.Amount `json:"Amount,omitempty" bson:"amount,omitempty"`
+	Amount2    *xrpl.Amount `json:"Amount2,omitempty" bson:"amount2,omitempty"`
+	EPrice     *xrpl.Amount `json:"EPrice,omitempty" bson:"eprice,omitempty"`
+	LPTokenOut *xrpl.Amount `json:"LPTokenOut,omitempty" bson:"lp_token_out,omitempty"`
+	TradingFee uint16       `json:"TradingFee,omitempty" bson:"trading_fee,omitempty"`
+}
+
+type AMMWithdraw struct {
+	Asset     xrpl.Issue   `json:"Asset" bson:"asset"`
+	Asset2    xrpl.Issue   `json:"Asset2" bson:"asset2"`
+	Amount    *xrpl.Amount `json:"Amount,omitempty" bson:"amount,omitempty"`
+	Amount2   *xrpl.Amount `json:"Amount2,omitempty" bson:"amount2,omitempty"`
+	EPrice    *xrpl.Amount `json:"EPrice,omitempty" bson:"eprice,omitempty"`
+	LPTokenIn *xrpl.Amount `json:"LPTokenIn,omitempty" bson:"lp_token_in,omitempty"`
+}
+
+type AMMVote struct {
+	Asset      xrpl.Issue `json:"Asset" bson:"asset"`
+	Asset2     xrpl.Issue `json:"Asset2" bson:"asset2"`
+	TradingFee uint16     `json:"TradingFee" bson:"trading_fee"`
+}
+
+type AMMBid struct {
+	Asset        xrpl.Issue   `json:"Asset" bson:"asset"`
+	Asset2       xrpl.Issue   `json:"Asset2" bson:"asset2"`
+	BidMin       *xrpl.Amount `json:"BidMin,omitempty" bson:"bid_min,omitempty"`
+	BidMax       *xrpl.Amount `json:"BidMax,omitempty" bson:"bid_max,omitempty"`
+	AuthAccounts []struct {
+		AuthAccount struct {
+			Account string `json:"Account" bson:"account"`
+		} `json:"AuthAccount" bson:"auth_account"`
+	} `json:"AuthAccounts,omitempty" bson:"auth_accounts,omitempty"`
+}
+
+type AMMDelete struct {
+	Asset  xrpl.Issue `json:"Asset" bson:"asset"`
+	Asset2 xrpl.Issue `json:"Asset2" bson:"asset2"`
+}
+
+type AMMClawback struct {
+	Holder string       `json:"Holder" bson:"holder"`
+	Asset  xrpl.Issue   `json:"Asset" bson:"asset"`
+	Asset2 xrpl.Issue   `json:"Asset2" bson:"asset2"`
+	Amount *xrpl.Amount `json:"Amount,omitempty" bson:"amount,omitempty"`
+}
+
+// ---------- NFTokens ----------
+
+type NFTokenMint struct {
+	NFTokenTaxon uint32       `json:"NFTokenTaxon" bson:"nftoken_taxon"`
+	Issuer       string       `json:"Issuer,omitempty" bson:"issuer,omitempty"`
+	TransferFee  uint16       `json:"TransferFee,omitempty" bson:"transfer_fee,omitempty"`
+	URI          string       `json:"URI,omitempty" bson:"uri,omitempty"`
+	Amount       *xrpl.Amount `json:"Amount,omitempty" bson:"amount,omitempty"`
+	Destination  string       `json:"Destination,omitempty" bson:"destination,omitempty"`
+	Expiration   uint32       `json:"Expiration,omitempty" bson:"expiration,omitempty"`
+}
+
+type NFTokenBurn struct {
+	NFTokenID string `json:"NFTokenID" bson:"nftoken_id"`
+	Owner     string `json:"Owner,omitempty" bson:"owner,omitempty"`
+}
+
+type NFTokenCreateOffer struct {
+	NFTokenID   string      `json:"NFTokenID" bson:"nftoken_id"`
+	Amount      xrpl.Amount `json:"Amount" bson:"amount"`
+	Owner       string      `json:"Owner,omitempty" bson:"owner,omitempty"`
+	Destination string      `json:"Destination,omitempty" bson:"destination,omitempty"`
+	Expiration  uint32      `json:"Expiration,omitempty" bson:"expiration,omitempty"`
+}
+
+type NFTokenCancelOffer struct {
+	NFTokenOffers []string `json:"NFTokenOffers" bson:"nftoken_offers"`
+}
+
+type NFTokenAcceptOffer struct {
+	NFTokenSellOffer string       `json:"NFTokenSellOffer,omitempty" bson:"nftoken_sell_offer,omitempty"`
+	NFTokenBuyOffer  string       `json:"NFTokenBuyOffer,omitempty" bson:"nftoken_buy_offer,omitempty"`
+	NFTokenBrokerFee *xrpl.Amount `json:"NFTokenBrokerFee,omitempty" bson:"nftoken_broker_fee,omitempty"`
+}
+
+type NFTokenModify struct {
+	NFTokenID string `json:"NFTokenID" bson:"nftoken_id"`
+	Owner     string `json:"Owner,omitempty" bson:"owner,omitempty"`
+	URI       string `json:"URI,omitempty" bson:"uri,omitempty"`
+}
+
+// ---------- Escrows, channels and checks ----------
+
+type EscrowCreate struct {
+	Amount         xrpl.Amount `json:"Amount" bson:"amount"`
+	Destination    string      `json:"Destination" bson:"destination"`
+	DestinationTag *uint32     `json:"DestinationTag,omitempty" bson:"destination_tag,omitempty"`
+	CancelAfter    uint32      `json:"CancelAfter,omitempty" bson:"cancel_after,omitempty"`
+	FinishAfter    uint32      `json:"FinishAfter,omitempty" bson:"finish_after,omitempty"`
+	Condition      string      `json:"Condition,omitempty" bson:"condition,omitempty"`
+}
+
+type EscrowFinish struct {
+	Owner         string `json:"Owner" bson:"owner"`
+	OfferSequence uint32 `json:"OfferSequence" bson:"offer_sequence"`
+	Condition     string `json:"Condition,omitempty" bson:"condition,omitempty"`
+	Fulfillment   string `json:"Fulfillment,omitempty" bson:"fulfillment,omitempty"`
+}
+
+type EscrowCancel struct {
+	Owner         string `json:"Owner" bson:"owner"`
+	OfferSequence uint32 `json:"OfferSequence" bson:"offer_sequence"`
+}
+
+type PaymentChannelCreate struct {
+	Amount         xrpl.Amount `json:"Amount" bson:"amount"`
+	Destination    string      `json:"Destination" bson:"destination"`
+	DestinationTag *uint32     `json:"DestinationTag,omitempty" bson:"destination_tag,omitempty"`
+	SettleDelay    uint32      `json:"SettleDelay" bson:"settle_delay"`
+	PublicKey      string      `json:"PublicKey" bson:"public_key"`
+	CancelAfter    uint32      `json:"CancelAfter,omitempty" bson:"cancel_after,omitempty"`
+}
+
+type PaymentChannelFund struct {
+	Channel    string      `json:"Channel" bson:"channel"`
+	Amount     xrpl.Amount `json:"Amount" bson:"amount"`
+	Expiration uint32      `json:"Expiration,omitempty" bson:"expiration,omitempty"`
+}
+
+type PaymentChannelClaim struct {
+	Channel   string       `json:"Channel" bson:"channel"`
+	Balance   *xrpl.Amount `json:"Balance,omitempty" bson:"balance,omitempty"`
+	Amount    *xrpl.Amount `json:"Amount,omitempty" bson:"amount,omitempty"`
+	Signature string       `json:"Signature,omitempty" bson:"signature,omitempty"`
+	PublicKey string       `json:"PublicKey,omitempty" bson:"public_key,omitempty"`
+}
+
+type CheckCreate struct {
+	Destination    string      `json:"Destination" bson:"destination"`
+	DestinationTag *uint32     `json:"DestinationTag,omitempty" bson:"destination_tag,omitempty"`
+	SendMax        xrpl.Amount `json:"SendMax" bson:"send_max"`
+	Expiration     uint32      `json:"Expiration,omitempty" bson:"expiration,omitempty"`
+	InvoiceID      string      `json:"InvoiceID,omitempty" bson:"invoice_id,omitempty"`
+}
+
+type CheckCash struct {
+	CheckID    string       `json:"CheckID" bson:"check_id"`
+	Amount     *xrpl.Amount `json:"Amount,omitempty" bson:"amount,omitempty"`
+	DeliverMin *xrpl.Amount `json:"DeliverMin,omitempty" bson:"deliver_min,omitempty"`
+}
+
+type CheckCancel struct {
+	CheckID string `json:"CheckID" bson:"check_id"`
+}
+
+// ---------- Account settings ----------
+
+type AccountSet struct {
+	ClearFlag     uint32 `json:"ClearFlag,omitempty" bson:"clear_flag,omitempty"`
+	SetFlag       uint32 `json:"SetFlag,omitempty" bson:"set_flag,omitempty"`
+	Domain        string `json:"Domain,omitempty" bson:"domain,omitempty"`
+	EmailHash     string `json:"EmailHash,omitempty" bson:"email_hash,omitempty"`
+	MessageKey    string `json:"MessageKey,omitempty" bson:"message_key,omitempty"`
+	TransferRate  uint32 `json:"TransferRate,omitempty" bson:"transfer_rate,omitempty"`
+	TickSize      uint8  `json:"TickSize,omitempty" bson:"tick_size,omitempty"`
+	NFTokenMinter string `json:"NFTokenMinter,omitempty" bson:"nftoken_minter,omitempty"`
+}
+
+type AccountDelete struct {
+	Destination    string   `json:"Destination" bson:"destination"`
+	DestinationTag *uint32  `json:"DestinationTag,omitempty" bson:"destination_tag,omitempty"`
+	CredentialIDs  []string `json:"CredentialIDs,omitempty" bson:"credential_ids,omitempty"`
+}
+
+type SetRegularKey struct {
+	RegularKey string `json:"RegularKey,omitempty" bson:"regular_key,omitempty"`
+}
+
+type SignerListSet struct {
+	SignerQuorum  uint32 `json:"SignerQuorum" bson:"signer_quorum"`
+	SignerEntries []struct {
+		SignerEntry struct {
+			Account       string `json:"Account" bson:"account"`
+			SignerWeight  uint16 `json:"SignerWeight" bson:"signer_weight"`
+			WalletLocator string `json:"WalletLocator,omitempty" bson:"wallet_locator,omitempty"`
+		} `json:"SignerEntry" bson:"signer_entry"`
+	} `json:"SignerEntries,omitempty" bson:"signer_entries,omitempty"`
+}
+
+type TicketCreate struct {
+	TicketCount uint32 `json:"TicketCount" bson:"ticket_count"`
+}
+
+type DepositPreauth struct {
+	Authorize   string `json:"Authorize,omitempty" bson:"authorize,omitempty"`
+	Unauthorize string `json:"Unauthorize,omitempty" bson:"unauthorize,omitempty"`
+}
+
+// ---------- DIDs and oracles ----------
+
+type DIDSet struct {
+	DIDDocument string `json:"DIDDocument,omitempty" bson:"did_document,omitempty"`
+	Data        string `json:"Data,omitempty" bson:"data,omitempty"`
+	URI         string `json:"URI,omitempty" bson:"uri,omitempty"`
+}
+
+type DIDDelete struct{}
+
+type OracleSet struct {
+	OracleDocumentID uint32 `json:"OracleDocumentID" bson:"oracle_document_id"`
+	Provider         string `json:"Provider,omitempty" bson:"provider,omitempty"`
+	URI              string `json:"URI,omitempty" bson:"uri,omitempty"`
+	AssetClass       string `json:"AssetClass,omitempty" bson:"asset_class,omitempty"`
+	LastUpdateTime   uint32 `json:"LastUpdateTime" bson:"last_update_time"`
+	PriceDataSeries  []struct {
+		PriceData struct {
+			BaseAsset  string `json:"BaseAsset" bson:"base_asset"`
+			QuoteAsset string `json:"QuoteAsset" bson:"quote_asset"`
+			AssetPrice string `json:"AssetPrice,omitempty" bson:"asset_price,omitempty"`
+			Scale      uint8  `json:"Scale,omitempty" bson:"scale,omitempty"`
+		} `json:"PriceData" bson:"price_data"`
+	} `json:"PriceDataSeries" bson:"price_data_series"`
+}
+
+type OracleDelete struct {
+	OracleDocumentID uint32 `json:"OracleDocumentID" bson:"oracle_document_id"`
+}
+
+// ---------- Cross-chain bridges ----------
+
+// XChainBridge identifies a bridge between a locking chain and an issuing chain
+type XChainBridge struct {
+	LockingChainDoor  string     `json:"LockingChainDoor" bson:"locking_chain_door"`
+	LockingChainIssue xrpl.Issue `json:"LockingChainIssue" bson:"locking_chain_issue"`
+	IssuingChainDoor  string     `json:"IssuingChainDoor" bson:"issuing_chain_door"`
+	IssuingChainIssue xrpl.Issue `json:"IssuingChainIssue" bson:"issuing_chain_issue"`
+}
+
+type XChainCreateBridge struct {
+	XChainBridge           XChainBridge `json:"XChainBridge" bson:"xchain_bridge"`
+	SignatureReward        xrpl.Amount  `json:"SignatureReward" bson:"signature_reward"`
+	MinAccountCreateAmount *xrpl.Amount `json:"MinAccountCreateAmount,omitempty" bson:"min_account_create_amount,omitempty"`
+}
+
+type XChainModifyBridge struct {
+	XChainBridge           XChainBridge `json:"XChainBridge" bson:"xchain_bridge"`
+	SignatureReward        *xrpl.Amount `json:"SignatureReward,omitempty" bson:"signature_reward,omitempty"`
+	MinAccountCreateAmount *xrpl.Amount `json:"MinAccountCreateAmount,omitempty" bson:"min_account_create_amount,omitempty"`
+}
+
+type XChainCreateClaimID struct {
+	XChainBridge     XChainBridge `json:"XChainBridge" bson:"xchain_bridge"`
+	SignatureReward  xrpl.Amount  `json:"SignatureReward" bson:"signature_reward"`
+	OtherChainSource string       `json:"OtherChainSource" bson:"other_chain_source"`
+}
+
+type XChainCommit struct {
+	XChainBridge          XChainBridge `json:"XChainBridge" bson:"xchain_bridge"`
+	XChainClaimID         string       `json:"XChainClaimID" bson:"xchain_claim_id"`
+	Amount                xrpl.Amount  `json:"Amount" bson:"amount"`
+	OtherChainDestination string       `json:"OtherChainDestination,omitempty" bson:"other_chain_destination,omitempty"`
+}
+
+type XChainClaim struct {
+	XChainBridge   XChainBridge `json:"XChainBridge" bson:"xchain_bridge"`
+	XChainClaimID  string       `json:"XChainClaimID" bson:"xchain_claim_id"`
+	Destination    string       `json:"Destination" bson:"destination"`
+	DestinationTag *uint32      `json:"DestinationTag,omitempty" bson:"destination_tag,omitempty"`
+	Amount         xrpl.Amount  `json:"Amount" bson:"amount"`
+}
+
+type XChainAccountCreateCommit struct {
+	XChainBridge    XChainBridge `json:"XChainBridge" bson:"xchain_bridge"`
+	Destination     string       `json:"Destination" bson:"destination"`
+	Amount          xrpl.Amount  `json:"Amount" bson:"amount"`
+	SignatureReward xrpl.Amount  `json:"SignatureReward" bson:"signature_reward"`
+}
+
+// XChainAttestation covers both XChainAddClaimAttestation and XChainAddAccountCreateAttestation
+type XChainAttestation struct {
+	XChainBridge             XChainBridge `json:"XChainBridge" bson:"xchain_bridge"`
+	Amount                   xrpl.Amount  `json:"Amount" bson:"amount"`
+	AttestationRewardAccount string       `json:"AttestationRewardAccount" bson:"attestation_reward_account"`
+	AttestationSignerAccount string       `json:"AttestationSignerAccount" bson:"attestation_signer_account"`
+	Destination              string       `json:"Destination,omitempty" bson:"destination,omitempty"`
+	OtherChainSource         string       `json:"OtherChainSource" bson:"other_chain_source"`
+	PublicKey                string       `json:"PublicKey" bson:"public_key"`
+	Signature                string       `json:"Signature" bson:"signature"`
+	WasLockingChainSend      uint8        `json:"WasLockingChainSend" bson:"was_locking_chain_send"`
+	XChainClaimID            string       `json:"XChainClaimID,omitempty" bson:"xchain_claim_id,omitempty"`
+	XChainAccountCreateCount string       `json:"XChainAccountCreateCount,omitempty" bson:"xchain_account_create_count,omitempty"`
+	SignatureReward          *xrpl.Amount `json:"SignatureReward,omitempty" bson:"signature_reward,omitempty"`
+}
+
+// ---------- Multi-purpose tokens ----------
+
+type MPTokenIssuanceCreate struct {
+	AssetScale      uint8  `json:"AssetScale,omitempty" bson:"asset_scale,omitempty"`
+	MaximumAmount   string `json:"MaximumAmount,omitempty" bson:"maximum_amount,omitempty"`
+	TransferFee     uint16 `json:"TransferFee,omitempty" bson:"transfer_fee,omitempty"`
+	MPTokenMetadata string `json:"MPTokenMetadata,omitempty" bson:"mptoken_metadata,omitempty"`
+}
+
+type MPTokenIssuanceDestroy struct {
+	MPTokenIssuanceID string `json:"MPTokenIssuanceID" bson:"mptoken_issuance_id"`
+}
+
+type MPTokenIssuanceSet struct {
+	MPTokenIssuanceID string `json:"MPTokenIssuanceID" bson:"mptoken_issuance_id"`
+	Holder            string `json:"Holder,omitempty" bson:"holder,omitempty"`
+}
+
+type MPTokenAuthorize struct {
+	MPTokenIssuanceID string `json:"MPTokenIssuanceID" bson:"mptoken_issuance_id"`
+	Holder            string `json:"Holder,omitempty" bson:"holder,omitempty"`
+}
+
+// ---------- Credentials and permissioned domains ----------
+
+type CredentialCreate struct {
+	Subject        string `json:"Subject" bson:"subject"`
+	CredentialType string `json:"CredentialType" bson:"credential_type"`
+	Expiration     uint32 `json:"Expiration,omitempty" bson:"expiration,omitempty"`
+	URI            string `json:"URI,omitempty" bson:"uri,omitempty"`
+}
+
+type CredentialAccept struct {
+	Issuer         string `json:"Issuer" bson:"issuer"`
+	CredentialType string `json:"CredentialType" bson:"credential_type"`
+}
+
+type CredentialDelete struct {
+	Subject        string `json:"Subject,omitempty" bson:"subject,omitempty"`
+	Issuer         string `json:"Issuer,omitempty" bson:"issuer,omitempty"`
+	CredentialType string `json:"CredentialType" bson:"credential_type"`
+}
+
+type PermissionedDomainSet struct {
+	DomainID            string `json:"DomainID,omitempty" bson:"domain_id,omitempty"`
+	AcceptedCredentials []struct {
+		Credential struct {
+			Issuer         string `json:"Issuer" bson:"issuer"`
+			CredentialType string `json:"CredentialType" bson:"credential_type"`
+		} `json:"Credential" bson:"credential"`
+	} `json:"AcceptedCredentials" bson:"accepted_credentials"`
+}
+
+type PermissionedDomainDelete struct {
+	DomainID string `json:"DomainID" bson:"domain_id"`
+}
+
+// ---------- Pseudo-transactions ----------
+
+type EnableAmendment struct {
+	Amendment      string `json:"Amendment" bson:"amendment"`
+	LedgerSequence uint32 `json:"LedgerSequence" bson:"ledger_sequence"`
+}
+
+type SetFee struct {
+	BaseFee               string `json:"BaseFee,omitempty" bson:"base_fee,omitempty"`
+	ReferenceFeeUnits     uint32 `json:"ReferenceFeeUnits,omitempty" bson:"reference_fee_units,omitempty"`
+	ReserveBase           uint32 `json:"ReserveBase,omitempty" bson:"reserve_base,omitempty"`
+	ReserveIncrement      uint32 `json:"ReserveIncrement,omitempty" bson:"reserve_increment,omitempty"`
+	BaseFeeDrops          string `json:"BaseFeeDrops,omitempty" bson:"base_fee_drops,omitempty"`
+	ReserveBaseDrops      string `json:"ReserveBaseDrops,omitempty" bson:"reserve_base_drops,omitempty"`
+	ReserveIncrementDrops string `json:"ReserveIncrementDrops,omitempty" bson:"reserve_increment_drops,omitempty"`
+	LedgerSequence        uint32 `json:"LedgerSequence,omitempty" bson:"ledger_sequence,omitempty"`
+}
+
+type UNLModify struct {
+	UNLModifyDisabling uint8  `json:"UNLModifyDisabling" bson:"unl_modify_disabling"`
+	UNLModifyValidator string `json:"UNLModifyValidator" bson:"unl_modify_validator"`
+	LedgerSequence     uint32 `json:"LedgerSequence" bson:"ledger_sequence"`
+}
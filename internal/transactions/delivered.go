@@ -0,0 +1,155 @@
+package transactions
+
+import (
+	"context"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TfPartialPayment is the Payment flag that allows delivering less than Amount
+const TfPartialPayment = 0x00020000
+
+// DeliveredUnavailable is reported as delivered_amount for ledgers before 2014-01-20
+const DeliveredUnavailable = "unavailable"
+
+// How the delivered amount was resolved
+const (
+	DeliveredFromMeta          = "meta"
+	DeliveredFromAmount        = "amount"
+	DeliveredFromBalanceChange = "balance_changes"
+)
+
+// ResolveDeliveredAmount returns the amount actually delivered by a Payment or CheckCash.
+// Ledgers before 2014-01-20 report "unavailable"; then Amount is used for full payments and
+// partial payments are reconstructed from the destination's balance changes
+func ResolveDeliveredAmount(tx *Transaction) (*xrpl.Amount, string) {
+	if tx.Meta == nil || tx.Result != "tesSUCCESS" {
+		return nil, ""
+	}
+
+	if tx.Meta.DeliveredAmount != DeliveredUnavailable {
+		if amount, ok := xrpl.ParseAmount(tx.Meta.DeliveredAmount); ok {
+			return amount, DeliveredFromMeta
+		}
+	}
+	if amount, ok := xrpl.ParseAmount(tx.Meta.LedgerDeliveredAmount); ok {
+		return amount, DeliveredFromMeta
+	}
+
+	// asset is the currency and issuer being delivered: Amount, or DeliverMin when a CheckCash
+	// only sets the minimum
+	var destination string
+	var amount, asset *xrpl.Amount
+	switch body := tx.Body.(type) {
+	case *Payment:
+		destination, amount, asset = body.Destination, &body.Amount, &body.Amount
+		if body.Amount.Value == "" && body.DeliverMin != nil {
+			asset = body.DeliverMin
+		}
+	case *CheckCash:
+		destination, amount, asset = tx.Account, body.Amount, body.Amount
+		if asset == nil {
+			asset = body.DeliverMin
+		}
+	default:
+		return nil, ""
+	}
+
+	if !IsPartialPayment(tx) && amount != nil {
+		return amount, DeliveredFromAmount
+	}
+	if asset == nil {
+		return nil, ""
+	}
+	return reconstructDelivered(tx, destination, *asset)
+}
+
+// reconstructDelivered sums the positive balance changes of the destination in the delivered asset.
+// A token issuer equal to the destination means any issuer the destination trusts, as in rippled
+func reconstructDelivered(tx *Transaction, destination string, asset xrpl.Amount) (*xrpl.Amount, string) {
+	currency := asset.Currency
+	if currency == "" {
+		currency = "XRP"
+	}
+	matchIssuer := currency != "XRP" && asset.Issuer != "" && asset.Issuer != destination
+
+	delivered := &xrpl.Amount{Currency: currency, Value: "0"}
+	found := false
+
+	for _, change := range BalanceChanges(tx.Meta) {
+		if change.Account != destination || change.Currency != currency || xrpl.ParseValue(change.Value).Sign() <= 0 {
+			continue
+		}
+		if matchIssuer && change.Issuer != asset.Issuer {
+			continue
+		}
+		// Self-payments also pay the fee from the same XRP balance
+		value := change.Value
+		if currency == "XRP" && destination == tx.Account {
+			value = xrpl.AddValues(value, tx.Fee)
+		}
+		delivered.Value = xrpl.AddValues(delivered.Value, value)
+		if currency != "XRP" {
+			delivered.Issuer = change.Issuer
+		}
+		found = true
+	}
+
+	if !found {
+		return nil, ""
+	}
+	return delivered, DeliveredFromBalanceChange
+}
+
+// IsPartialPayment reports whether a Payment has the tfPartialPayment flag set
+func IsPartialPayment(tx *Transaction) bool {
+	return tx.TransactionType == "Payment" && tx.Flags&TfPartialPayment != 0
+}
+
+// IncomingPayment is the account-level view of a received payment, always using the delivered amount
+type IncomingPayment struct {
+	Hash            string       `json:"hash" bson:"hash"`
+	From            string       `json:"from" bson:"account"`
+	LedgerIndex     int          `json:"ledger_index" bson:"ledger_index"`
+	Date            time.Time    `json:"date" bson:"date"`
+	DeliveredAmount *xrpl.Amount `json:"delivered_amount" bson:"delivered_amount"`
+	PartialPayment  bool         `json:"partial_payment" bson:"partial_payment"`
+	Body            struct {
+		Destination    string  `json:"destination" bson:"destination"`
+		DestinationTag *uint32 `json:"destination_tag,omitempty" bson:"destination_tag,omitempty"`
+	} `json:"body" bson:"body"`
+}
+
+// GetIncomingPayments returns successful payments received by an account, newest first
+func GetIncomingPayments(account string, limit int64) ([]IncomingPayment, error) {
+	filter := bson.M{
+		"transaction_type": "Payment",
+		"body.destination": account,
+		"result":           "tesSUCCESS",
+		"validated":        true,
+		"delivered_amount": bson.M{"$exists": true},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetTransactionCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := []IncomingPayment{}
+	if err := cursor.All(ctx, &payments); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
@@ -0,0 +1,99 @@
+package transactions
+
+import (
+	"testing"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+func TestResolveDeliveredAmount(t *testing.T) {
+	unavailable := `, "delivered_amount": "unavailable"`
+	usd := xrpl.Amount{Currency: "USD", Issuer: "rGateway", Value: "10"}
+
+	tests := []struct {
+		name       string
+		tx         *Transaction
+		meta       *TransactionMeta
+		want       *xrpl.Amount
+		wantSource string
+	}{
+		{
+			name:       "delivered_amount from metadata",
+			tx:         &Transaction{TransactionType: "Payment", Body: &Payment{Destination: "rDest", Amount: usd}},
+			meta:       metaOf(t, "tesSUCCESS", `, "delivered_amount": `+iou("USD", "rGateway", "9.5")),
+			want:       &xrpl.Amount{Currency: "USD", Issuer: "rGateway", Value: "9.5"},
+			wantSource: DeliveredFromMeta,
+		},
+		{
+			name:       "failed transactions deliver nothing",
+			tx:         &Transaction{TransactionType: "Payment", Body: &Payment{Destination: "rDest", Amount: usd}},
+			meta:       metaOf(t, "tecPATH_DRY", ""),
+			wantSource: "",
+		},
+		{
+			name:       "full payment before delivered_amount uses Amount",
+			tx:         &Transaction{TransactionType: "Payment", Body: &Payment{Destination: "rDest", Amount: usd}},
+			meta:       metaOf(t, "tesSUCCESS", unavailable),
+			want:       &usd,
+			wantSource: DeliveredFromAmount,
+		},
+		{
+			name: "partial payment only counts the requested issuer",
+			tx:   &Transaction{TransactionType: "Payment", Flags: TfPartialPayment, Body: &Payment{Destination: "rDest", Amount: usd}},
+			meta: metaOf(t, "tesSUCCESS", unavailable,
+				rippleStateNode("rDest", "rGateway", "USD", "1", "5"),
+				rippleStateNode("rDest", "rOtherGateway", "USD", "0", "3")),
+			want:       &xrpl.Amount{Currency: "USD", Issuer: "rGateway", Value: "4"},
+			wantSource: DeliveredFromBalanceChange,
+		},
+		{
+			name: "partial payment with the destination as issuer accepts any issuer",
+			tx: &Transaction{TransactionType: "Payment", Flags: TfPartialPayment,
+				Body: &Payment{Destination: "rDest", Amount: xrpl.Amount{Currency: "USD", Issuer: "rDest", Value: "10"}}},
+			meta: metaOf(t, "tesSUCCESS", unavailable,
+				rippleStateNode("rDest", "rGateway", "USD", "1", "5"),
+				rippleStateNode("rDest", "rOtherGateway", "USD", "0", "3")),
+			want:       &xrpl.Amount{Currency: "USD", Issuer: "rOtherGateway", Value: "7"},
+			wantSource: DeliveredFromBalanceChange,
+		},
+		{
+			name: "XRP self payment adds back the fee",
+			tx: &Transaction{TransactionType: "Payment", Account: "rSelf", Fee: "12", Flags: TfPartialPayment,
+				Body: &Payment{Destination: "rSelf", Amount: xrpl.Amount{Currency: "XRP", Value: "1000000"}}},
+			meta:       metaOf(t, "tesSUCCESS", unavailable, accountRootNode("rSelf", "5000000", "5500000")),
+			want:       &xrpl.Amount{Currency: "XRP", Value: "500012"},
+			wantSource: DeliveredFromBalanceChange,
+		},
+		{
+			name: "CheckCash with DeliverMin uses its asset",
+			tx: &Transaction{TransactionType: "CheckCash", Account: "rCasher",
+				Body: &CheckCash{CheckID: "C1", DeliverMin: &xrpl.Amount{Currency: "EUR", Issuer: "rGateway", Value: "2"}}},
+			meta: metaOf(t, "tesSUCCESS", unavailable,
+				accountRootNode("rCasher", "5000000", "4999988"),
+				rippleStateNode("rCasher", "rGateway", "EUR", "0", "2.5")),
+			want:       &xrpl.Amount{Currency: "EUR", Issuer: "rGateway", Value: "2.5"},
+			wantSource: DeliveredFromBalanceChange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.tx.Meta = tt.meta
+			tt.tx.Result = tt.meta.TransactionResult
+
+			got, source := ResolveDeliveredAmount(tt.tx)
+			if source != tt.wantSource {
+				t.Fatalf("source = %q, want %q", source, tt.wantSource)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("delivered = %+v, want none", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.want {
+				t.Fatalf("delivered = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
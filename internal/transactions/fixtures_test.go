@@ -0,0 +1,49 @@
+package transactions
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Metadata fixtures: each helper renders one affected node as rippled returns it
+
+// accountRootNode is a modified AccountRoot whose XRP balance moved from previous to final drops
+func accountRootNode(account, previous, final string) string {
+	return `{"ModifiedNode": {"LedgerEntryType": "AccountRoot", "LedgerIndex": "AR` + account + `",
+		"FinalFields": {"Account": "` + account + `", "Balance": "` + final + `", "OwnerCount": 0},
+		"PreviousFields": {"Balance": "` + previous + `"}}}`
+}
+
+// rippleStateNode is a modified trust line; balances are from the low account's point of view
+func rippleStateNode(low, high, currency, previous, final string) string {
+	return `{"ModifiedNode": {"LedgerEntryType": "RippleState", "LedgerIndex": "RS` + low + high + currency + `",
+		"FinalFields": {"Balance": {"currency": "` + currency + `", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "` + final + `"},
+			"Flags": 0,
+			"LowLimit": {"currency": "` + currency + `", "issuer": "` + low + `", "value": "1000"},
+			"HighLimit": {"currency": "` + currency + `", "issuer": "` + high + `", "value": "0"}},
+		"PreviousFields": {"Balance": {"currency": "` + currency + `", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "` + previous + `"}}}}`
+}
+
+// metaOf assembles transaction metadata from affected nodes
+func metaOf(t *testing.T, result string, extra string, nodes ...string) *TransactionMeta {
+	t.Helper()
+
+	raw := `{"TransactionResult": "` + result + `", "TransactionIndex": 0` + extra + `, "AffectedNodes": [` + strings.Join(nodes, ",") + `]}`
+	var meta TransactionMeta
+	if err := json.Unmarshal([]byte(raw), &meta); err != nil {
+		t.Fatalf("metadata fixture: %v\n%s", err, raw)
+	}
+	return &meta
+}
+
+func iou(currency, issuer, value string) string {
+	return `{"currency": "` + currency + `", "issuer": "` + issuer + `", "value": "` + value + `"}`
+}
+
+// offerNode is a partially filled offer; gets and pays are rendered amounts (a drops string or iou)
+func offerNode(owner, previousGets, finalGets, previousPays, finalPays string) string {
+	return `{"ModifiedNode": {"LedgerEntryType": "Offer", "LedgerIndex": "OF` + owner + `",
+		"FinalFields": {"Account": "` + owner + `", "TakerGets": ` + finalGets + `, "TakerPays": ` + finalPays + `},
+		"PreviousFields": {"TakerGets": ` + previousGets + `, "TakerPays": ` + previousPays + `}}}`
+}
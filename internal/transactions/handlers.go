@@ -0,0 +1,43 @@
+package transactions
+
+import "sync"
+
+// Handler is called for every transaction stored through Ingest
+type Handler func(tx *Transaction)
+
+var (
+	handlers      []Handler
+	stateHandlers []Handler
+	handlersMu    sync.RWMutex
+)
+
+// RegisterHandler adds a handler that runs after each ingested transaction is saved. It reacts to
+// live transactions only: history stored through Backfill does not reach it
+func RegisterHandler(handler Handler) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	handlers = append(handlers, handler)
+}
+
+// RegisterStateHandler adds a handler that builds state from transactions, such as balances or
+// settings. It runs for ingested transactions and for history stored through Backfill
+func RegisterStateHandler(handler Handler) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	stateHandlers = append(stateHandlers, handler)
+}
+
+func runHandlers(tx *Transaction, live bool) {
+	handlersMu.RLock()
+	defer handlersMu.RUnlock()
+
+	for _, handler := range stateHandlers {
+		handler(tx)
+	}
+	if !live {
+		return
+	}
+	for _, handler := range handlers {
+		handler(tx)
+	}
+}
@@ -0,0 +1,20 @@
+package transactions
+
+import "testing"
+
+func TestRunHandlers(t *testing.T) {
+	var state, live int
+	RegisterStateHandler(func(tx *Transaction) { state++ })
+	RegisterHandler(func(tx *Transaction) { live++ })
+
+	// History only builds state
+	runHandlers(&Transaction{Hash: "H1"}, false)
+	if state != 1 || live != 0 {
+		t.Fatalf("backfill ran state=%d live=%d, want 1 and 0", state, live)
+	}
+
+	runHandlers(&Transaction{Hash: "H2"}, true)
+	if state != 2 || live != 1 {
+		t.Fatalf("ingest ran state=%d live=%d, want 2 and 1", state, live)
+	}
+}
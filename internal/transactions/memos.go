@@ -0,0 +1,121 @@
+package transactions
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kinds of decoded memo content
+const (
+	MemoKindText   = "text"
+	MemoKindJSON   = "json"
+	MemoKindURL    = "url"
+	MemoKindBinary = "binary"
+)
+
+// DecodedMemo holds the readable form of a memo's hex fields
+type DecodedMemo struct {
+	Type   string `json:"type,omitempty" bson:"type,omitempty"`
+	Format string `json:"format,omitempty" bson:"format,omitempty"`
+	// Data is the UTF-8 text of MemoData, or the original hex when it is not valid UTF-8
+	Data string   `json:"data,omitempty" bson:"data,omitempty"`
+	Kind string   `json:"kind,omitempty" bson:"kind,omitempty"`
+	JSON MemoJSON `json:"json,omitempty" bson:"json,omitempty"`
+}
+
+// MemoJSON is the compacted JSON document of a memo. It is stored as a string, since memo keys
+// such as "$set" or "a.b" are not valid MongoDB field names, and rendered as JSON in responses
+type MemoJSON string
+
+func (m MemoJSON) MarshalJSON() ([]byte, error) {
+	if m == "" {
+		return []byte("null"), nil
+	}
+	return []byte(m), nil
+}
+
+func (m *MemoJSON) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*m = ""
+		return nil
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err != nil {
+		return err
+	}
+	*m = MemoJSON(compacted.String())
+	return nil
+}
+
+// DecodeMemo decodes the hex fields of a memo and detects the kind of its data
+func DecodeMemo(memo Memo) *DecodedMemo {
+	decoded := &DecodedMemo{
+		Type:   decodeHexText(memo.MemoType),
+		Format: decodeHexText(memo.MemoFormat),
+	}
+	if memo.MemoData == "" {
+		return decoded
+	}
+
+	text, ok := hexToText(memo.MemoData)
+	if !ok {
+		decoded.Data = memo.MemoData
+		decoded.Kind = MemoKindBinary
+		return decoded
+	}
+	decoded.Data = text
+	decoded.Kind = detectMemoKind(text, decoded.Format)
+
+	if decoded.Kind == MemoKindJSON {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, []byte(text)); err == nil {
+			decoded.JSON = MemoJSON(compacted.String())
+		}
+	}
+	return decoded
+}
+
+func detectMemoKind(text string, format string) string {
+	trimmed := strings.TrimSpace(text)
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		return MemoKindJSON
+	}
+	if strings.Contains(strings.ToLower(format), "json") && json.Valid([]byte(trimmed)) {
+		return MemoKindJSON
+	}
+	if strings.HasPrefix(trimmed, "http://") || strings.HasPrefix(trimmed, "https://") || strings.HasPrefix(trimmed, "ipfs://") {
+		return MemoKindURL
+	}
+	return MemoKindText
+}
+
+// decodeHexText returns the UTF-8 text of a hex field, or the field itself when it is not text
+func decodeHexText(value string) string {
+	if text, ok := hexToText(value); ok {
+		return text
+	}
+	return value
+}
+
+// hexToText decodes hex into printable UTF-8 text
+func hexToText(value string) (string, bool) {
+	if value == "" {
+		return "", false
+	}
+
+	data, err := hex.DecodeString(value)
+	if err != nil || !utf8.Valid(data) {
+		return "", false
+	}
+
+	text := string(data)
+	for _, r := range text {
+		if r < 0x20 && r != '\n' && r != '\r' && r != '\t' {
+			return "", false
+		}
+	}
+	return text, true
+}
@@ -0,0 +1,108 @@
+package transactions
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func hexOf(text string) string {
+	return hex.EncodeToString([]byte(text))
+}
+
+func TestDecodeMemo(t *testing.T) {
+	tests := []struct {
+		name     string
+		memo     Memo
+		wantType string
+		wantData string
+		wantKind string
+		wantJSON MemoJSON
+	}{
+		{
+			name:     "text",
+			memo:     Memo{MemoType: hexOf("note"), MemoData: hexOf("hello world")},
+			wantType: "note",
+			wantData: "hello world",
+			wantKind: MemoKindText,
+		},
+		{
+			name:     "json object with operator and dotted keys",
+			memo:     Memo{MemoData: hexOf(`{"$set": {"a.b": 1},  "id": "x"}`)},
+			wantData: `{"$set": {"a.b": 1},  "id": "x"}`,
+			wantKind: MemoKindJSON,
+			wantJSON: `{"$set":{"a.b":1},"id":"x"}`,
+		},
+		{
+			name:     "json detected by format",
+			memo:     Memo{MemoFormat: hexOf("application/json"), MemoData: hexOf(`"quoted"`)},
+			wantData: `"quoted"`,
+			wantKind: MemoKindJSON,
+			wantJSON: `"quoted"`,
+		},
+		{
+			name:     "url",
+			memo:     Memo{MemoData: hexOf("https://example.com/invoice/1")},
+			wantData: "https://example.com/invoice/1",
+			wantKind: MemoKindURL,
+		},
+		{
+			name:     "binary keeps the hex",
+			memo:     Memo{MemoData: "00FF10"},
+			wantData: "00FF10",
+			wantKind: MemoKindBinary,
+		},
+		{
+			name:     "type that is not hex is kept as is",
+			memo:     Memo{MemoType: "not-hex"},
+			wantType: "not-hex",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded := DecodeMemo(tt.memo)
+			if decoded.Type != tt.wantType || decoded.Data != tt.wantData || decoded.Kind != tt.wantKind || decoded.JSON != tt.wantJSON {
+				t.Fatalf("DecodeMemo = %+v, want type=%q data=%q kind=%q json=%q", decoded, tt.wantType, tt.wantData, tt.wantKind, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestMemoJSONEncoding(t *testing.T) {
+	decoded := DecodeMemo(Memo{MemoData: hexOf(`{"$where": "x", "a.b": [1, 2]}`)})
+
+	// the document keys never reach MongoDB as field names
+	document, err := bson.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("bson.Marshal: %v", err)
+	}
+	var stored bson.M
+	if err := bson.Unmarshal(document, &stored); err != nil {
+		t.Fatalf("bson.Unmarshal: %v", err)
+	}
+	if _, ok := stored["json"].(string); !ok {
+		t.Fatalf("json stored as %T, want string", stored["json"])
+	}
+
+	body, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	var response struct {
+		JSON map[string]interface{} `json:"json"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if response.JSON["$where"] != "x" {
+		t.Fatalf("json rendered as %s", body)
+	}
+
+	var roundTrip DecodedMemo
+	if err := json.Unmarshal(body, &roundTrip); err != nil || roundTrip.JSON != decoded.JSON {
+		t.Fatalf("round trip = %q, %v", roundTrip.JSON, err)
+	}
+}
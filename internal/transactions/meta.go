@@ -0,0 +1,198 @@
+package transactions
+
+import (
+	"sort"
+)
+
+// Node types of an AffectedNodes entry
+const (
+	NodeCreated  = "created"
+	NodeModified = "modified"
+	NodeDeleted  = "deleted"
+)
+
+// TransactionMeta defines the metadata attached to a validated transaction
+type TransactionMeta struct {
+	AffectedNodes     []AffectedNode `json:"AffectedNodes"`
+	TransactionIndex  int            `json:"TransactionIndex"`
+	TransactionResult string         `json:"TransactionResult"`
+	DeliveredAmount   interface{}    `json:"delivered_amount,omitempty"`
+
+	// DeliveredAmount as stored in the ledger; only present for partial payments
+	LedgerDeliveredAmount interface{} `json:"DeliveredAmount,omitempty"`
+}
+
+// AffectedNode holds exactly one of CreatedNode, ModifiedNode or DeletedNode
+type AffectedNode struct {
+	CreatedNode  *LedgerNode `json:"CreatedNode,omitempty"`
+	ModifiedNode *LedgerNode `json:"ModifiedNode,omitempty"`
+	DeletedNode  *LedgerNode `json:"DeletedNode,omitempty"`
+}
+
+// LedgerNode describes a ledger object touched by a transaction
+type LedgerNode struct {
+	LedgerEntryType   string                 `json:"LedgerEntryType"`
+	LedgerIndex       string                 `json:"LedgerIndex"`
+	NewFields         map[string]interface{} `json:"NewFields,omitempty"`
+	FinalFields       map[string]interface{} `json:"FinalFields,omitempty"`
+	PreviousFields    map[string]interface{} `json:"PreviousFields,omitempty"`
+	PreviousTxnID     string                 `json:"PreviousTxnID,omitempty"`
+	PreviousTxnLgrSeq int                    `json:"PreviousTxnLgrSeq,omitempty"`
+}
+
+// Node returns the node type and the node itself
+func (a AffectedNode) Node() (string, *LedgerNode) {
+	switch {
+	case a.CreatedNode != nil:
+		return NodeCreated, a.CreatedNode
+	case a.ModifiedNode != nil:
+		return NodeModified, a.ModifiedNode
+	case a.DeletedNode != nil:
+		return NodeDeleted, a.DeletedNode
+	}
+	return "", nil
+}
+
+// Fields returns the latest known state of the object (NewFields for created nodes, FinalFields otherwise)
+func (n *LedgerNode) Fields() map[string]interface{} {
+	if n.NewFields != nil {
+		return n.NewFields
+	}
+	if n.FinalFields != nil {
+		return n.FinalFields
+	}
+	return map[string]interface{}{}
+}
+
+// ParseStateChanges turns a transaction's metadata into normalized state-change records
+func ParseStateChanges(txHash string, txType string, ledgerIndex int, meta *TransactionMeta) []StateChangeSchema {
+	changes := make([]StateChangeSchema, 0, len(meta.AffectedNodes))
+
+	for _, affected := range meta.AffectedNodes {
+		nodeType, node := affected.Node()
+		if node == nil {
+			continue
+		}
+
+		fields := node.Fields()
+		changes = append(changes, StateChangeSchema{
+			TxHash:           txHash,
+			TransactionType:  txType,
+			LedgerIndex:      ledgerIndex,
+			TransactionIndex: meta.TransactionIndex,
+			NodeType:         nodeType,
+			ObjectType:       node.LedgerEntryType,
+			ObjectKey:        node.LedgerIndex,
+			Owner:            objectOwner(node.LedgerEntryType, fields),
+			Accounts:         objectAccounts(fields),
+			Fields:           fieldChanges(nodeType, node),
+		})
+	}
+	return changes
+}
+
+// AffectedAccounts returns the accounts whose balances or offers a transaction touched: the owners of
+// the AccountRoot, Offer and RippleState (both sides) entries of its metadata
+func AffectedAccounts(meta *TransactionMeta) []string {
+	seen := map[string]bool{}
+	accounts := []string{}
+	if meta == nil {
+		return accounts
+	}
+
+	add := func(account string) {
+		if account != "" && !seen[account] {
+			seen[account] = true
+			accounts = append(accounts, account)
+		}
+	}
+
+	for _, affected := range meta.AffectedNodes {
+		_, node := affected.Node()
+		if node == nil {
+			continue
+		}
+		fields := node.Fields()
+		switch node.LedgerEntryType {
+		case "AccountRoot", "Offer":
+			account, _ := fields["Account"].(string)
+			add(account)
+		case "RippleState":
+			add(amountIssuer(fields["LowLimit"]))
+			add(amountIssuer(fields["HighLimit"]))
+		}
+	}
+	return accounts
+}
+
+// fieldChanges computes field-level before/after values for a node
+func fieldChanges(nodeType string, node *LedgerNode) []FieldChange {
+	changes := []FieldChange{}
+
+	switch nodeType {
+	case NodeCreated:
+		for field, value := range node.NewFields {
+			changes = append(changes, FieldChange{Field: field, After: value})
+		}
+	case NodeModified:
+		for field, before := range node.PreviousFields {
+			changes = append(changes, FieldChange{Field: field, Before: before, After: node.FinalFields[field]})
+		}
+	case NodeDeleted:
+		for field, value := range node.FinalFields {
+			before := value
+			if previous, ok := node.PreviousFields[field]; ok {
+				before = previous
+			}
+			changes = append(changes, FieldChange{Field: field, Before: before})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// objectOwner returns the account that owns a ledger object
+func objectOwner(entryType string, fields map[string]interface{}) string {
+	if entryType == "RippleState" {
+		return amountIssuer(fields["LowLimit"])
+	}
+
+	for _, key := range []string{"Account", "Owner", "Subject", "Issuer"} {
+		if account, ok := fields[key].(string); ok {
+			return account
+		}
+	}
+	return ""
+}
+
+// objectAccounts returns every account referenced by a ledger object
+func objectAccounts(fields map[string]interface{}) []string {
+	seen := map[string]bool{}
+	accounts := []string{}
+
+	add := func(account string) {
+		if account != "" && !seen[account] {
+			seen[account] = true
+			accounts = append(accounts, account)
+		}
+	}
+
+	for _, key := range []string{"Account", "Owner", "Destination", "Issuer", "Subject", "RegularKey"} {
+		if account, ok := fields[key].(string); ok {
+			add(account)
+		}
+	}
+	add(amountIssuer(fields["LowLimit"]))
+	add(amountIssuer(fields["HighLimit"]))
+	return accounts
+}
+
+func amountIssuer(value interface{}) string {
+	if amount, ok := value.(map[string]interface{}); ok {
+		if issuer, ok := amount["issuer"].(string); ok {
+			return issuer
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package transactions
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseStateChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		node       string
+		nodeType   string
+		objectType string
+		owner      string
+		accounts   []string
+		fields     []string // field=before->after
+	}{
+		{
+			name:       "modified account root",
+			node:       accountRootNode("rAlice", "1000", "988"),
+			nodeType:   NodeModified,
+			objectType: "AccountRoot",
+			owner:      "rAlice",
+			accounts:   []string{"rAlice"},
+			fields:     []string{"Balance=1000->988"},
+		},
+		{
+			name:       "trust line is owned by the low account",
+			node:       rippleStateNode("rAlice", "rGateway", "USD", "1", "5"),
+			nodeType:   NodeModified,
+			objectType: "RippleState",
+			owner:      "rAlice",
+			accounts:   []string{"rAlice", "rGateway"},
+			fields: []string{"Balance=map[currency:USD issuer:rrrrrrrrrrrrrrrrrrrrBZbvji value:1]->" +
+				"map[currency:USD issuer:rrrrrrrrrrrrrrrrrrrrBZbvji value:5]"},
+		},
+		{
+			name: "created escrow",
+			node: `{"CreatedNode": {"LedgerEntryType": "Escrow", "LedgerIndex": "E1",
+				"NewFields": {"Account": "rAlice", "Destination": "rBob", "Amount": "500"}}}`,
+			nodeType:   NodeCreated,
+			objectType: "Escrow",
+			owner:      "rAlice",
+			accounts:   []string{"rAlice", "rBob"},
+			fields:     []string{"Account=<nil>->rAlice", "Amount=<nil>->500", "Destination=<nil>->rBob"},
+		},
+		{
+			name: "deleted offer keeps the values before the last change",
+			node: `{"DeletedNode": {"LedgerEntryType": "Offer", "LedgerIndex": "O1",
+				"FinalFields": {"Account": "rMaker", "TakerGets": "0"},
+				"PreviousFields": {"TakerGets": "100"}}}`,
+			nodeType:   NodeDeleted,
+			objectType: "Offer",
+			owner:      "rMaker",
+			accounts:   []string{"rMaker"},
+			fields:     []string{"Account=rMaker-><nil>", "TakerGets=100-><nil>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := metaOf(t, "tesSUCCESS", "", tt.node)
+			meta.TransactionIndex = 3
+
+			changes := ParseStateChanges("H", "Payment", 100, meta)
+			if len(changes) != 1 {
+				t.Fatalf("got %d changes, want 1", len(changes))
+			}
+			change := changes[0]
+			if change.TxHash != "H" || change.LedgerIndex != 100 || change.TransactionIndex != 3 {
+				t.Errorf("envelope = %s %d %d", change.TxHash, change.LedgerIndex, change.TransactionIndex)
+			}
+			if change.NodeType != tt.nodeType || change.ObjectType != tt.objectType || change.Owner != tt.owner {
+				t.Errorf("node = %s %s owner %s, want %s %s owner %s",
+					change.NodeType, change.ObjectType, change.Owner, tt.nodeType, tt.objectType, tt.owner)
+			}
+			if fmt.Sprint(change.Accounts) != fmt.Sprint(tt.accounts) {
+				t.Errorf("accounts = %v, want %v", change.Accounts, tt.accounts)
+			}
+
+			fields := []string{}
+			for _, field := range change.Fields {
+				fields = append(fields, fmt.Sprintf("%s=%v->%v", field.Field, field.Before, field.After))
+			}
+			if fmt.Sprint(fields) != fmt.Sprint(tt.fields) {
+				t.Errorf("fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package transactions
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+// Transaction is the normalized transaction: a common envelope plus a typed body.
+// Unknown transaction types keep the raw transaction document as body
+type Transaction struct {
+	Hash               string      `bson:"hash" json:"hash"`
+	TransactionType    string      `bson:"transaction_type" json:"transaction_type"`
+	Account            string      `bson:"account" json:"account"`
+	Accounts           []string    `bson:"accounts" json:"accounts"`
+	Sequence           uint32      `bson:"sequence" json:"sequence"`
+	TicketSequence     uint32      `bson:"ticket_sequence,omitempty" json:"ticket_sequence,omitempty"`
+	Fee                string      `bson:"fee" json:"fee"`
+	Flags              uint32      `bson:"flags" json:"flags"`
+	LastLedgerSequence uint32      `bson:"last_ledger_sequence,omitempty" json:"last_ledger_sequence,omitempty"`
+	SourceTag          *uint32     `bson:"source_tag,omitempty" json:"source_tag,omitempty"`
+	Result             string      `bson:"result" json:"result"`
+	ResultCategory     string      `bson:"result_category" json:"result_category"`
+	LedgerIndex        int         `bson:"ledger_index" json:"ledger_index"`
+	LedgerHash         string      `bson:"ledger_hash,omitempty" json:"ledger_hash,omitempty"`
+	Date               time.Time   `bson:"date" json:"date"`
+	Memos              []Memo      `bson:"memos,omitempty" json:"memos,omitempty"`
+	Validated          bool        `bson:"validated" json:"validated"`
+	Body               interface{} `bson:"body" json:"body"`
+
+	// Amount actually delivered (Payment/CheckCash); never use Body.Amount for crediting
+	DeliveredAmount       *xrpl.Amount `bson:"delivered_amount,omitempty" json:"delivered_amount,omitempty"`
+	DeliveredAmountSource string       `bson:"delivered_amount_source,omitempty" json:"delivered_amount_source,omitempty"`
+	PartialPayment        bool         `bson:"partial_payment" json:"partial_payment"`
+
+	// Route of cross-currency payments reconstructed from metadata
+	PathAnalysis *PathAnalysis `bson:"path_analysis,omitempty" json:"path_analysis,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+
+	// Meta is kept for pipeline consumers and is not persisted
+	Meta *TransactionMeta `bson:"-" json:"-"`
+}
+
+// Memo defines a transaction memo as sent on the ledger (hex encoded) plus its decoded form
+type Memo struct {
+	MemoType   string       `json:"MemoType,omitempty" bson:"memo_type,omitempty"`
+	MemoData   string       `json:"MemoData,omitempty" bson:"memo_data,omitempty"`
+	MemoFormat string       `json:"MemoFormat,omitempty" bson:"memo_format,omitempty"`
+	Decoded    *DecodedMemo `json:"decoded,omitempty" bson:"decoded,omitempty"`
+}
+
+// RawTransaction pairs a raw transaction JSON with its metadata and ledger context
+type RawTransaction struct {
+	TxJSON       json.RawMessage
+	Meta         *TransactionMeta
+	Hash         string
+	LedgerIndex  int
+	LedgerHash   string
+	Date         int64
+	CloseTimeISO string
+	EngineResult string
+	Validated    bool
+}
+
+// envelope holds the common fields shared by every transaction type
+type envelope struct {
+	TransactionType    string  `json:"TransactionType"`
+	Account            string  `json:"Account"`
+	Sequence           uint32  `json:"Sequence"`
+	TicketSequence     uint32  `json:"TicketSequence"`
+	Fee                string  `json:"Fee"`
+	Flags              uint32  `json:"Flags"`
+	LastLedgerSequence uint32  `json:"LastLedgerSequence"`
+	SourceTag          *uint32 `json:"SourceTag"`
+	Memos              []struct {
+		Memo Memo `json:"Memo"`
+	} `json:"Memos"`
+	Hash        string `json:"hash"`
+	Date        int64  `json:"date"`
+	LedgerIndex int    `json:"ledger_index"`
+	InLedger    int    `json:"inLedger"`
+
+	// Counterparty fields used to index every account involved
+	Destination string `json:"Destination"`
+	Owner       string `json:"Owner"`
+	Holder      string `json:"Holder"`
+	Subject     string `json:"Subject"`
+}
+
+// StreamTransactionMessage represents a "transaction" message from the subscription streams (API v1 and v2)
+type StreamTransactionMessage struct {
+	Type               string           `json:"type"`
+	Transaction        json.RawMessage  `json:"transaction,omitempty"`
+	TxJSON             json.RawMessage  `json:"tx_json,omitempty"`
+	Meta               *TransactionMeta `json:"meta,omitempty"`
+	Hash               string           `json:"hash,omitempty"`
+	EngineResult       string           `json:"engine_result"`
+	LedgerIndex        int              `json:"ledger_index"`
+	LedgerHash         string           `json:"ledger_hash"`
+	LedgerCurrentIndex int              `json:"ledger_current_index,omitempty"`
+	CloseTimeISO       string           `json:"close_time_iso,omitempty"`
+	Validated          bool             `json:"validated"`
+}
+
+// Raw converts a stream message into a RawTransaction
+func (m *StreamTransactionMessage) Raw() *RawTransaction {
+	txJSON := m.Transaction
+	if len(txJSON) == 0 {
+		txJSON = m.TxJSON
+	}
+
+	return &RawTransaction{
+		TxJSON:       txJSON,
+		Meta:         m.Meta,
+		Hash:         m.Hash,
+		LedgerIndex:  m.LedgerIndex,
+		LedgerHash:   m.LedgerHash,
+		CloseTimeISO: m.CloseTimeISO,
+		EngineResult: m.EngineResult,
+		Validated:    m.Validated,
+	}
+}
+
+// Normalize builds the typed transaction model from a raw transaction
+func Normalize(raw *RawTransaction) (*Transaction, error) {
+	if len(raw.TxJSON) == 0 {
+		return nil, errors.New("empty transaction")
+	}
+
+	var env envelope
+	if err := json.Unmarshal(raw.TxJSON, &env); err != nil {
+		return nil, err
+	}
+	if env.TransactionType == "" {
+		return nil, errors.New("missing TransactionType")
+	}
+
+	tx := &Transaction{
+		Hash:               firstString(raw.Hash, env.Hash),
+		TransactionType:    env.TransactionType,
+		Account:            env.Account,
+		Sequence:           env.Sequence,
+		TicketSequence:     env.TicketSequence,
+		Fee:                env.Fee,
+		Flags:              env.Flags,
+		LastLedgerSequence: env.LastLedgerSequence,
+		SourceTag:          env.SourceTag,
+		Result:             raw.EngineResult,
+		LedgerIndex:        raw.LedgerIndex,
+		LedgerHash:         raw.LedgerHash,
+		Validated:          raw.Validated,
+		Meta:               raw.Meta,
+		CreatedAt:          time.Now(),
+	}
+
+	if tx.LedgerIndex == 0 {
+		tx.LedgerIndex = env.LedgerIndex
+	}
+	if tx.LedgerIndex == 0 {
+		tx.LedgerIndex = env.InLedger
+	}
+
+	switch {
+	case raw.Date != 0:
+		tx.Date = xrpl.RippleTimeToTime(raw.Date)
+	case env.Date != 0:
+		tx.Date = xrpl.RippleTimeToTime(env.Date)
+	case raw.CloseTimeISO != "":
+		if closeTime, err := time.Parse(time.RFC3339, raw.CloseTimeISO); err == nil {
+			tx.Date = closeTime
+		}
+	}
+
+	if raw.Meta != nil && raw.Meta.TransactionResult != "" {
+		tx.Result = raw.Meta.TransactionResult
+	}
+	tx.ResultCategory = ResultCategory(tx.Result)
+
+	for _, memo := range env.Memos {
+		memo.Memo.Decoded = DecodeMemo(memo.Memo)
+		tx.Memos = append(tx.Memos, memo.Memo)
+	}
+
+	tx.Accounts = uniqueAccounts(env.Account, env.Destination, env.Owner, env.Holder, env.Subject)
+
+	if factory, ok := LookupType(env.TransactionType); ok {
+		body := factory()
+		if err := json.Unmarshal(raw.TxJSON, body); err != nil {
+			return nil, err
+		}
+		if payment, ok := body.(*Payment); ok && payment.Amount.Value == "" && payment.DeliverMax != nil {
+			payment.Amount = *payment.DeliverMax
+		}
+		tx.Body = body
+	} else {
+		var document map[string]interface{}
+		if err := json.Unmarshal(raw.TxJSON, &document); err != nil {
+			return nil, err
+		}
+		tx.Body = document
+	}
+
+	tx.PartialPayment = IsPartialPayment(tx)
+	tx.DeliveredAmount, tx.DeliveredAmountSource = ResolveDeliveredAmount(tx)
+	tx.PathAnalysis = AnalyzePaths(tx)
+
+	return tx, nil
+}
+
+func firstString(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func uniqueAccounts(accounts ...string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, account := range accounts {
+		if account != "" && !seen[account] {
+			seen[account] = true
+			result = append(result, account)
+		}
+	}
+	return result
+}
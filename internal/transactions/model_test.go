@@ -0,0 +1,105 @@
+package transactions
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		wantErr    bool
+		wantType   string
+		wantAmount string
+		wantDate   time.Time
+		accounts   []string
+	}{
+		{
+			name: "API v1 payment",
+			message: `{"type": "transaction", "validated": true, "ledger_index": 100, "engine_result": "tesSUCCESS",
+				"transaction": {"TransactionType": "Payment", "Account": "rSender", "Destination": "rReceiver",
+					"Amount": "1000000", "Fee": "12", "Sequence": 5, "hash": "H1", "date": 0},
+				"meta": {"TransactionResult": "tesSUCCESS", "delivered_amount": "1000000", "AffectedNodes": []}}`,
+			wantType:   "Payment",
+			wantAmount: "1000000",
+			accounts:   []string{"rSender", "rReceiver"},
+		},
+		{
+			name: "API v2 payment uses DeliverMax",
+			message: `{"type": "transaction", "validated": true, "ledger_index": 101, "hash": "H2",
+				"close_time_iso": "2024-01-02T03:04:05Z",
+				"tx_json": {"TransactionType": "Payment", "Account": "rSender", "Destination": "rReceiver",
+					"DeliverMax": {"currency": "USD", "issuer": "rIssuer", "value": "25"}, "Fee": "12", "Sequence": 6},
+				"meta": {"TransactionResult": "tesSUCCESS", "delivered_amount": {"currency": "USD", "issuer": "rIssuer", "value": "25"}, "AffectedNodes": []}}`,
+			wantType:   "Payment",
+			wantAmount: "25",
+			wantDate:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			accounts:   []string{"rSender", "rReceiver"},
+		},
+		{
+			name: "unknown type keeps the raw document",
+			message: `{"type": "transaction", "validated": true, "ledger_index": 102,
+				"transaction": {"TransactionType": "FutureType", "Account": "rSender", "hash": "H3"}}`,
+			wantType: "FutureType",
+			accounts: []string{"rSender"},
+		},
+		{
+			name:    "missing TransactionType",
+			message: `{"type": "transaction", "transaction": {"Account": "rSender"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var message StreamTransactionMessage
+			if err := json.Unmarshal([]byte(tt.message), &message); err != nil {
+				t.Fatalf("fixture: %v", err)
+			}
+
+			tx, err := Normalize(message.Raw())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Normalize: %v", err)
+			}
+
+			if tx.TransactionType != tt.wantType || tx.Hash == "" || tx.LedgerIndex != message.LedgerIndex {
+				t.Fatalf("envelope = %s %s %d", tx.TransactionType, tx.Hash, tx.LedgerIndex)
+			}
+			if !tt.wantDate.IsZero() && !tx.Date.Equal(tt.wantDate) {
+				t.Errorf("date = %v, want %v", tx.Date, tt.wantDate)
+			}
+			if len(tx.Accounts) != len(tt.accounts) {
+				t.Fatalf("accounts = %v, want %v", tx.Accounts, tt.accounts)
+			}
+			for i, account := range tt.accounts {
+				if tx.Accounts[i] != account {
+					t.Errorf("accounts = %v, want %v", tx.Accounts, tt.accounts)
+				}
+			}
+
+			switch body := tx.Body.(type) {
+			case *Payment:
+				if body.Amount.Value != tt.wantAmount {
+					t.Errorf("Amount = %q, want %q", body.Amount.Value, tt.wantAmount)
+				}
+				if tx.DeliveredAmount == nil || tx.DeliveredAmount.Value != tt.wantAmount {
+					t.Errorf("DeliveredAmount = %+v, want %s", tx.DeliveredAmount, tt.wantAmount)
+				}
+			case map[string]interface{}:
+				if tt.wantAmount != "" {
+					t.Errorf("unexpected raw body for %s", tt.wantType)
+				}
+			default:
+				t.Errorf("unexpected body %T", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package transactions
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Kinds of hops in a payment route
+const (
+	HopOffer  = "offer"
+	HopAMM    = "amm"
+	HopRipple = "ripple"
+)
+
+// PathAnalysis is the route a cross-currency payment actually took, reconstructed from its metadata
+type PathAnalysis struct {
+	SourceAmount    xrpl.Amount `bson:"source_amount" json:"source_amount"`
+	DeliveredAmount xrpl.Amount `bson:"delivered_amount" json:"delivered_amount"`
+	// Rate is delivered per unit spent, with XRP in XRP (not drops)
+	Rate           float64   `bson:"rate" json:"rate"`
+	SourceValue    float64   `bson:"source_value" json:"source_value"`
+	DeliveredValue float64   `bson:"delivered_value" json:"delivered_value"`
+	Corridor       string    `bson:"corridor" json:"corridor"`
+	Assets         []string  `bson:"assets" json:"assets"`
+	Hops           []PathHop `bson:"hops" json:"hops"`
+	OffersConsumed int       `bson:"offers_consumed" json:"offers_consumed"`
+	AMMPools       []string  `bson:"amm_pools,omitempty" json:"amm_pools,omitempty"`
+}
+
+// PathHop is one liquidity source used by the payment: an offer, an AMM pool or an account rippling
+// a currency. In converts to Out from the payment's point of view
+type PathHop struct {
+	Kind    string      `bson:"kind" json:"kind"`
+	Account string      `bson:"account" json:"account"`
+	Key     string      `bson:"key,omitempty" json:"key,omitempty"`
+	In      xrpl.Amount `bson:"in" json:"in"`
+	Out     xrpl.Amount `bson:"out" json:"out"`
+}
+
+// AnalyzePaths reconstructs the route of a successful Payment that uses SendMax or Paths.
+// It returns nil for direct payments
+func AnalyzePaths(tx *Transaction) *PathAnalysis {
+	payment, ok := tx.Body.(*Payment)
+	if !ok || tx.Meta == nil || tx.Result != "tesSUCCESS" || tx.DeliveredAmount == nil {
+		return nil
+	}
+	if payment.SendMax == nil && len(payment.Paths) == 0 {
+		return nil
+	}
+
+	sourceAsset := payment.Amount
+	if payment.SendMax != nil {
+		sourceAsset = *payment.SendMax
+	}
+
+	changes := BalanceChanges(tx.Meta)
+	ammAccounts := AMMAccounts(tx.Meta)
+
+	analysis := &PathAnalysis{
+		SourceAmount:    spentAmount(tx, sourceAsset, changes),
+		DeliveredAmount: *tx.DeliveredAmount,
+		Hops:            []PathHop{},
+	}
+
+	// Offers consumed and AMM pools swapped are hops; their owners are not rippling accounts
+	participants := map[string]bool{tx.Account: true, payment.Destination: true}
+	for _, hop := range ConsumedOffers(tx.Meta) {
+		participants[hop.Account] = true
+		analysis.OffersConsumed++
+		analysis.Hops = append(analysis.Hops, hop)
+	}
+
+	for account := range ammAccounts {
+		participants[account] = true
+	}
+	for _, hop := range AMMSwaps(ammAccounts, changes) {
+		analysis.AMMPools = append(analysis.AMMPools, hop.Account)
+		analysis.Hops = append(analysis.Hops, hop)
+	}
+
+	// Rippling: other accounts whose trust lines were credited and debited in the same currency
+	analysis.Hops = append(analysis.Hops, rippleHops(changes, participants)...)
+
+	analysis.SourceValue = analysis.SourceAmount.Float()
+	analysis.DeliveredValue = analysis.DeliveredAmount.Float()
+	if analysis.SourceValue > 0 {
+		analysis.Rate = analysis.DeliveredValue / analysis.SourceValue
+	}
+	analysis.Assets = corridorAssets(analysis.SourceAmount, analysis.DeliveredAmount, analysis.Hops)
+	analysis.Corridor = strings.Join(analysis.Assets, " -> ")
+	return analysis
+}
+
+// ConsumedOffers returns the offers taken by a transaction: the owner gave Out (TakerGets) and received In (TakerPays)
+func ConsumedOffers(meta *TransactionMeta) []PathHop {
+	hops := []PathHop{}
+	for _, affected := range meta.AffectedNodes {
+		nodeType, node := affected.Node()
+		if node == nil || node.LedgerEntryType != "Offer" || nodeType == NodeCreated || node.PreviousFields == nil {
+			continue
+		}
+
+		fields := node.Fields()
+		gets := consumed(node.PreviousFields["TakerGets"], fields["TakerGets"])
+		pays := consumed(node.PreviousFields["TakerPays"], fields["TakerPays"])
+		if gets == nil || pays == nil {
+			continue
+		}
+
+		owner, _ := fields["Account"].(string)
+		hops = append(hops, PathHop{Kind: HopOffer, Account: owner, Key: node.LedgerIndex, In: *pays, Out: *gets})
+	}
+	return hops
+}
+
+// AMMSwaps returns the AMM pools that received one asset (In) and paid out another (Out)
+func AMMSwaps(ammAccounts map[string]bool, changes []BalanceChange) []PathHop {
+	hops := []PathHop{}
+	for account := range ammAccounts {
+		var in, out *xrpl.Amount
+		for _, change := range changes {
+			if change.Account != account {
+				continue
+			}
+			amount := xrpl.Amount{Currency: change.Currency, Issuer: change.Issuer, Value: change.Value}
+			if xrpl.ParseValue(change.Value).Sign() > 0 {
+				in = &amount
+			} else {
+				amount.Value = xrpl.NegateValue(change.Value)
+				out = &amount
+			}
+		}
+		if in != nil && out != nil {
+			hops = append(hops, PathHop{Kind: HopAMM, Account: account, In: *in, Out: *out})
+		}
+	}
+	return hops
+}
+
+// spentAmount sums what the sender paid in the source asset; the fee is excluded for XRP
+func spentAmount(tx *Transaction, source xrpl.Amount, changes []BalanceChange) xrpl.Amount {
+	spent := xrpl.Amount{Currency: source.Currency, Issuer: source.Issuer, Value: "0"}
+	for _, change := range changes {
+		if change.Account != tx.Account || change.Currency != source.Currency || xrpl.ParseValue(change.Value).Sign() >= 0 {
+			continue
+		}
+		if source.Currency != "XRP" && source.Issuer != "" && source.Issuer != tx.Account && change.Issuer != source.Issuer {
+			continue
+		}
+		spent.Value = xrpl.AddValues(spent.Value, xrpl.NegateValue(change.Value))
+	}
+
+	if source.Currency == "XRP" && spent.Value != "0" {
+		spent.Value = xrpl.SubtractValues(spent.Value, tx.Fee)
+	}
+	return spent
+}
+
+// consumed returns previous - final for an offer amount, or nil when nothing was taken
+func consumed(previous, final interface{}) *xrpl.Amount {
+	before, ok := xrpl.ParseAmount(previous)
+	if !ok {
+		return nil
+	}
+
+	after := "0"
+	if amount, ok := xrpl.ParseAmount(final); ok {
+		after = amount.Value
+	}
+
+	taken := xrpl.SubtractValues(before.Value, after)
+	if xrpl.ParseValue(taken).Sign() <= 0 {
+		return nil
+	}
+	before.Value = taken
+	return before
+}
+
+// AMMAccounts returns the AMM accounts touched by the transaction (AccountRoot with AMMID)
+func AMMAccounts(meta *TransactionMeta) map[string]bool {
+	accounts := map[string]bool{}
+	for _, affected := range meta.AffectedNodes {
+		_, node := affected.Node()
+		if node == nil || node.LedgerEntryType != "AccountRoot" {
+			continue
+		}
+		fields := node.Fields()
+		if _, ok := fields["AMMID"]; ok {
+			if account, ok := fields["Account"].(string); ok {
+				accounts[account] = true
+			}
+		}
+	}
+	return accounts
+}
+
+func rippleHops(changes []BalanceChange, participants map[string]bool) []PathHop {
+	type flows struct{ in, out *xrpl.Amount }
+	byAccount := map[string]map[string]*flows{}
+
+	for _, change := range changes {
+		if participants[change.Account] || change.Currency == "XRP" {
+			continue
+		}
+		if byAccount[change.Account] == nil {
+			byAccount[change.Account] = map[string]*flows{}
+		}
+		flow := byAccount[change.Account][change.Currency]
+		if flow == nil {
+			flow = &flows{}
+			byAccount[change.Account][change.Currency] = flow
+		}
+
+		// The counterparty of the line is the issuer of the IOU the account holds or owes
+		amount := xrpl.Amount{Currency: change.Currency, Issuer: change.Issuer, Value: change.Value}
+		if xrpl.ParseValue(change.Value).Sign() > 0 {
+			flow.in = &amount
+		} else {
+			amount.Value = xrpl.NegateValue(change.Value)
+			flow.out = &amount
+		}
+	}
+
+	hops := []PathHop{}
+	for account, currencies := range byAccount {
+		for _, flow := range currencies {
+			if flow.in != nil && flow.out != nil {
+				// From the payment's point of view the IOU is re-issued by the rippling account
+				in, out := *flow.in, *flow.out
+				in.Issuer, out.Issuer = account, account
+				hops = append(hops, PathHop{Kind: HopRipple, Account: account, In: in, Out: out})
+			}
+		}
+	}
+	return hops
+}
+
+// corridorAssets orders the assets of the route by chaining conversions from the source asset
+func corridorAssets(source, delivered xrpl.Amount, hops []PathHop) []string {
+	conversions := map[string]map[string]bool{}
+	for _, hop := range hops {
+		if hop.Kind == HopRipple {
+			continue
+		}
+		in, out := hop.In.Key(), hop.Out.Key()
+		if in == out {
+			continue
+		}
+		if conversions[in] == nil {
+			conversions[in] = map[string]bool{}
+		}
+		conversions[in][out] = true
+	}
+
+	target := delivered.Key()
+	current := source.Key()
+	assets := []string{current}
+	visited := map[string]bool{current: true}
+
+	for current != target {
+		if conversions[current][target] {
+			current = target
+		} else {
+			next := ""
+			for candidate := range conversions[current] {
+				if !visited[candidate] && (next == "" || candidate < next) {
+					next = candidate
+				}
+			}
+			if next == "" {
+				current = target
+			} else {
+				current = next
+			}
+		}
+		visited[current] = true
+		assets = append(assets, current)
+	}
+	return assets
+}
+
+// CorridorSummary aggregates the cross-currency payments of one corridor
+type CorridorSummary struct {
+	Corridor       string  `bson:"_id" json:"corridor"`
+	Payments       int     `bson:"payments" json:"payments"`
+	SourceTotal    float64 `bson:"source_total" json:"source_total"`
+	DeliveredTotal float64 `bson:"delivered_total" json:"delivered_total"`
+	AverageRate    float64 `bson:"average_rate" json:"average_rate"`
+	MinRate        float64 `bson:"min_rate" json:"min_rate"`
+	MaxRate        float64 `bson:"max_rate" json:"max_rate"`
+	OffersConsumed int     `bson:"offers_consumed" json:"offers_consumed"`
+	AMMPayments    int     `bson:"amm_payments" json:"amm_payments"`
+	LastLedger     int     `bson:"last_ledger" json:"last_ledger"`
+}
+
+// GetCorridors summarizes stored cross-currency payments by corridor within a time window, busiest first.
+// AverageRate is the volume-weighted rate (delivered total / source total)
+func GetCorridors(from, to time.Time, asset string, limit int64) ([]CorridorSummary, error) {
+	match := bson.M{
+		"path_analysis": bson.M{"$exists": true},
+		"date":          bson.M{"$gte": from, "$lt": to},
+	}
+	if asset != "" {
+		match["path_analysis.assets"] = asset
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$group": bson.M{
+			"_id":             "$path_analysis.corridor",
+			"payments":        bson.M{"$sum": 1},
+			"source_total":    bson.M{"$sum": "$path_analysis.source_value"},
+			"delivered_total": bson.M{"$sum": "$path_analysis.delivered_value"},
+			"min_rate":        bson.M{"$min": "$path_analysis.rate"},
+			"max_rate":        bson.M{"$max": "$path_analysis.rate"},
+			"offers_consumed": bson.M{"$sum": "$path_analysis.offers_consumed"},
+			"amm_payments":    bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{bson.M{"$size": bson.M{"$ifNull": bson.A{"$path_analysis.amm_pools", bson.A{}}}}, 0}}, 1, 0}}},
+			"last_ledger":     bson.M{"$max": "$ledger_index"},
+		}},
+		{"$sort": bson.D{{Key: "payments", Value: -1}}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": limit})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := database.GetTransactionCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	corridors := []CorridorSummary{}
+	if err := cursor.All(ctx, &corridors); err != nil {
+		return nil, err
+	}
+	for i := range corridors {
+		if corridors[i].SourceTotal > 0 {
+			corridors[i].AverageRate = corridors[i].DeliveredTotal / corridors[i].SourceTotal
+		}
+	}
+	return corridors, nil
+}
@@ -0,0 +1,122 @@
+package transactions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+)
+
+func TestAnalyzePaths(t *testing.T) {
+	usd := xrpl.Amount{Currency: "USD", Issuer: "rGateway", Value: "10"}
+
+	tests := []struct {
+		name     string
+		payment  *Payment
+		nodes    []string
+		want     bool
+		source   xrpl.Amount
+		rate     float64
+		corridor string
+		offers   int
+	}{
+		{
+			name:    "direct payment has no route",
+			payment: &Payment{Destination: "rDest", Amount: usd},
+			nodes:   []string{rippleStateNode("rDest", "rGateway", "USD", "0", "10")},
+		},
+		{
+			name:    "XRP to USD through one offer",
+			payment: &Payment{Destination: "rDest", Amount: usd, SendMax: &xrpl.Amount{Currency: "XRP", Value: "6000000"}},
+			nodes: []string{
+				accountRootNode("rSender", "100000000", "94999988"),
+				offerNode("rMaker", iou("USD", "rGateway", "30"), iou("USD", "rGateway", "20"), `"15000000"`, `"10000000"`),
+				rippleStateNode("rDest", "rGateway", "USD", "0", "10"),
+			},
+			want:     true,
+			source:   xrpl.Amount{Currency: "XRP", Value: "5000000"},
+			rate:     2,
+			corridor: "XRP -> USD.rGateway",
+			offers:   1,
+		},
+		{
+			name:    "EUR to USD bridged through XRP",
+			payment: &Payment{Destination: "rDest", Amount: usd, SendMax: &xrpl.Amount{Currency: "EUR", Issuer: "rGateway", Value: "8"}},
+			nodes: []string{
+				rippleStateNode("rSender", "rGateway", "EUR", "10", "4"),
+				offerNode("rMakerA", `"10000000"`, `"5000000"`, iou("EUR", "rGateway", "12"), iou("EUR", "rGateway", "6")),
+				offerNode("rMakerB", iou("USD", "rGateway", "30"), iou("USD", "rGateway", "20"), `"15000000"`, `"10000000"`),
+				rippleStateNode("rDest", "rGateway", "USD", "0", "10"),
+			},
+			want:     true,
+			source:   xrpl.Amount{Currency: "EUR", Issuer: "rGateway", Value: "6"},
+			rate:     10.0 / 6,
+			corridor: "EUR.rGateway -> XRP -> USD.rGateway",
+			offers:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &Transaction{TransactionType: "Payment", Account: "rSender", Fee: "12", Result: "tesSUCCESS",
+				Body: tt.payment, DeliveredAmount: &usd, Meta: metaOf(t, "tesSUCCESS", "", tt.nodes...)}
+
+			analysis := AnalyzePaths(tx)
+			if !tt.want {
+				if analysis != nil {
+					t.Fatalf("analysis = %+v, want none", analysis)
+				}
+				return
+			}
+			if analysis == nil {
+				t.Fatal("no analysis")
+			}
+			if analysis.SourceAmount != tt.source {
+				t.Errorf("source = %+v, want %+v", analysis.SourceAmount, tt.source)
+			}
+			if diff := analysis.Rate - tt.rate; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("rate = %v, want %v", analysis.Rate, tt.rate)
+			}
+			if analysis.Corridor != tt.corridor {
+				t.Errorf("corridor = %q, want %q", analysis.Corridor, tt.corridor)
+			}
+			if analysis.OffersConsumed != tt.offers || len(analysis.Hops) != tt.offers {
+				t.Errorf("offers = %d, hops = %+v, want %d offers", analysis.OffersConsumed, analysis.Hops, tt.offers)
+			}
+		})
+	}
+}
+
+func TestCorridorAssets(t *testing.T) {
+	xrp := xrpl.Amount{Currency: "XRP"}
+	eur := xrpl.Amount{Currency: "EUR", Issuer: "rGateway"}
+	usd := xrpl.Amount{Currency: "USD", Issuer: "rGateway"}
+	usdOther := xrpl.Amount{Currency: "USD", Issuer: "rOther"}
+
+	hop := func(kind string, in, out xrpl.Amount) PathHop {
+		return PathHop{Kind: kind, In: in, Out: out}
+	}
+
+	tests := []struct {
+		name   string
+		source xrpl.Amount
+		target xrpl.Amount
+		hops   []PathHop
+		want   string
+	}{
+		{"same asset", usd, usd, nil, "USD.rGateway"},
+		{"direct conversion", xrp, usd, []PathHop{hop(HopAMM, xrp, usd)}, "XRP -> USD.rGateway"},
+		{"hops listed out of order", eur, usd, []PathHop{hop(HopOffer, xrp, usd), hop(HopOffer, eur, xrp)}, "EUR.rGateway -> XRP -> USD.rGateway"},
+		{"rippling does not convert", usd, usdOther, []PathHop{hop(HopRipple, usd, usdOther)}, "USD.rGateway -> USD.rOther"},
+		{"broken chain ends at the delivered asset", eur, usd, []PathHop{hop(HopOffer, eur, xrp)}, "EUR.rGateway -> XRP -> USD.rGateway"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(corridorAssets(tt.source, tt.target, tt.hops), " -> ")
+			if got != tt.want {
+				t.Errorf("corridor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
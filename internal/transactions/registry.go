@@ -0,0 +1,104 @@
+package transactions
+
+import "sync"
+
+// BodyFactory returns a new, empty typed body for a transaction type
+type BodyFactory func() interface{}
+
+var (
+	registry   = make(map[string]BodyFactory)
+	registryMu sync.RWMutex
+)
+
+// RegisterType registers the typed body for a transaction type, replacing any previous registration
+func RegisterType(txType string, factory BodyFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[txType] = factory
+}
+
+// LookupType returns the body factory registered for a transaction type
+func LookupType(txType string) (BodyFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	factory, ok := registry[txType]
+	return factory, ok
+}
+
+// RegisteredTypes returns the transaction types with a typed body
+func RegisteredTypes() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]string, 0, len(registry))
+	for txType := range registry {
+		types = append(types, txType)
+	}
+	return types
+}
+
+func init() {
+	builtin := map[string]BodyFactory{
+		"Payment":                           func() interface{} { return &Payment{} },
+		"OfferCreate":                       func() interface{} { return &OfferCreate{} },
+		"OfferCancel":                       func() interface{} { return &OfferCancel{} },
+		"TrustSet":                          func() interface{} { return &TrustSet{} },
+		"Clawback":                          func() interface{} { return &Clawback{} },
+		"AMMCreate":                         func() interface{} { return &AMMCreate{} },
+		"AMMDeposit":                        func() interface{} { return &AMMDeposit{} },
+		"AMMWithdraw":                       func() interface{} { return &AMMWithdraw{} },
+		"AMMVote":                           func() interface{} { return &AMMVote{} },
+		"AMMBid":                            func() interface{} { return &AMMBid{} },
+		"AMMDelete":                         func() interface{} { return &AMMDelete{} },
+		"AMMClawback":                       func() interface{} { return &AMMClawback{} },
+		"NFTokenMint":                       func() interface{} { return &NFTokenMint{} },
+		"NFTokenBurn":                       func() interface{} { return &NFTokenBurn{} },
+		"NFTokenCreateOffer":                func() interface{} { return &NFTokenCreateOffer{} },
+		"NFTokenCancelOffer":                func() interface{} { return &NFTokenCancelOffer{} },
+		"NFTokenAcceptOffer":                func() interface{} { return &NFTokenAcceptOffer{} },
+		"NFTokenModify":                     func() interface{} { return &NFTokenModify{} },
+		"EscrowCreate":                      func() interface{} { return &EscrowCreate{} },
+		"EscrowFinish":                      func() interface{} { return &EscrowFinish{} },
+		"EscrowCancel":                      func() interface{} { return &EscrowCancel{} },
+		"PaymentChannelCreate":              func() interface{} { return &PaymentChannelCreate{} },
+		"PaymentChannelFund":                func() interface{} { return &PaymentChannelFund{} },
+		"PaymentChannelClaim":               func() interface{} { return &PaymentChannelClaim{} },
+		"CheckCreate":                       func() interface{} { return &CheckCreate{} },
+		"CheckCash":                         func() interface{} { return &CheckCash{} },
+		"CheckCancel":                       func() interface{} { return &CheckCancel{} },
+		"AccountSet":                        func() interface{} { return &AccountSet{} },
+		"AccountDelete":                     func() interface{} { return &AccountDelete{} },
+		"SetRegularKey":                     func() interface{} { return &SetRegularKey{} },
+		"SignerListSet":                     func() interface{} { return &SignerListSet{} },
+		"TicketCreate":                      func() interface{} { return &TicketCreate{} },
+		"DepositPreauth":                    func() interface{} { return &DepositPreauth{} },
+		"DIDSet":                            func() interface{} { return &DIDSet{} },
+		"DIDDelete":                         func() interface{} { return &DIDDelete{} },
+		"OracleSet":                         func() interface{} { return &OracleSet{} },
+		"OracleDelete":                      func() interface{} { return &OracleDelete{} },
+		"XChainCreateBridge":                func() interface{} { return &XChainCreateBridge{} },
+		"XChainModifyBridge":                func() interface{} { return &XChainModifyBridge{} },
+		"XChainCreateClaimID":               func() interface{} { return &XChainCreateClaimID{} },
+		"XChainCommit":                      func() interface{} { return &XChainCommit{} },
+		"XChainClaim":                       func() interface{} { return &XChainClaim{} },
+		"XChainAccountCreateCommit":         func() interface{} { return &XChainAccountCreateCommit{} },
+		"XChainAddClaimAttestation":         func() interface{} { return &XChainAttestation{} },
+		"XChainAddAccountCreateAttestation": func() interface{} { return &XChainAttestation{} },
+		"MPTokenIssuanceCreate":             func() interface{} { return &MPTokenIssuanceCreate{} },
+		"MPTokenIssuanceDestroy":            func() interface{} { return &MPTokenIssuanceDestroy{} },
+		"MPTokenIssuanceSet":                func() interface{} { return &MPTokenIssuanceSet{} },
+		"MPTokenAuthorize":                  func() interface{} { return &MPTokenAuthorize{} },
+		"CredentialCreate":                  func() interface{} { return &CredentialCreate{} },
+		"CredentialAccept":                  func() interface{} { return &CredentialAccept{} },
+		"CredentialDelete":                  func() interface{} { return &CredentialDelete{} },
+		"PermissionedDomainSet":             func() interface{} { return &PermissionedDomainSet{} },
+		"PermissionedDomainDelete":          func() interface{} { return &PermissionedDomainDelete{} },
+		"EnableAmendment":                   func() interface{} { return &EnableAmendment{} },
+		"SetFee":                            func() interface{} { return &SetFee{} },
+		"UNLModify":                         func() interface{} { return &UNLModify{} },
+	}
+
+	for txType, factory := range builtin {
+		RegisterType(txType, factory)
+	}
+}
@@ -0,0 +1,165 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Transaction result categories, named after the result code prefix
+const (
+	ResultSuccess   = "tes" // applied successfully
+	ResultClaimed   = "tec" // failed, fee claimed and included in a ledger
+	ResultFailure   = "tef" // failed locally, not applied
+	ResultLocal     = "tel" // local error, not relayed
+	ResultMalformed = "tem" // malformed transaction
+	ResultRetry     = "ter" // retryable, not applied yet
+)
+
+// Grouping keys accepted by GetResultStats
+const (
+	GroupByType    = "transaction_type"
+	GroupByAccount = "account"
+	GroupByResult  = "result"
+	GroupByHour    = "hour"
+	GroupByDay     = "day"
+)
+
+// ResultCategory returns the category of a result code, or "" when it is unknown
+func ResultCategory(result string) string {
+	if len(result) < 3 {
+		return ""
+	}
+	switch category := strings.ToLower(result[:3]); category {
+	case ResultSuccess, ResultClaimed, ResultFailure, ResultLocal, ResultMalformed, ResultRetry:
+		return category
+	}
+	return ""
+}
+
+// ResultStatsFilter selects the transactions aggregated by GetResultStats
+type ResultStatsFilter struct {
+	Account         string
+	TransactionType string
+	From            time.Time
+	To              time.Time
+	GroupBy         string
+}
+
+// ResultStats is the result breakdown of one group
+type ResultStats struct {
+	Group       string         `json:"group"`
+	Total       int            `json:"total"`
+	Success     int            `json:"success"`
+	Failed      int            `json:"failed"`
+	FailureRate float64        `json:"failure_rate"`
+	Categories  map[string]int `json:"categories"`
+	Results     map[string]int `json:"results"`
+}
+
+// GetResultStats aggregates stored transactions by result code within a group and time window,
+// ordered by number of failures
+func GetResultStats(filter ResultStatsFilter) ([]ResultStats, error) {
+	groupKey, err := resultGroupKey(filter.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	match := bson.M{"date": bson.M{"$gte": filter.From, "$lt": filter.To}}
+	// Results are attributed to the sending account
+	if filter.Account != "" {
+		match["account"] = filter.Account
+	}
+	if filter.TransactionType != "" {
+		match["transaction_type"] = filter.TransactionType
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$group": bson.M{
+			"_id":   bson.M{"group": groupKey, "result": "$result"},
+			"count": bson.M{"$sum": 1},
+		}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := database.GetTransactionCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		ID struct {
+			Group  interface{} `bson:"group"`
+			Result string      `bson:"result"`
+		} `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	groups := map[string]*ResultStats{}
+	for _, row := range rows {
+		group, _ := row.ID.Group.(string)
+		stats, ok := groups[group]
+		if !ok {
+			stats = &ResultStats{Group: group, Categories: map[string]int{}, Results: map[string]int{}}
+			groups[group] = stats
+		}
+
+		category := ResultCategory(row.ID.Result)
+		if category == "" {
+			category = "unknown"
+		}
+		stats.Total += row.Count
+		stats.Results[row.ID.Result] += row.Count
+		stats.Categories[category] += row.Count
+		if category == ResultSuccess {
+			stats.Success += row.Count
+		} else {
+			stats.Failed += row.Count
+		}
+	}
+
+	result := make([]ResultStats, 0, len(groups))
+	for _, stats := range groups {
+		if stats.Total > 0 {
+			stats.FailureRate = float64(stats.Failed) / float64(stats.Total)
+		}
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if filter.GroupBy == GroupByHour || filter.GroupBy == GroupByDay {
+			return result[i].Group < result[j].Group
+		}
+		if result[i].Failed != result[j].Failed {
+			return result[i].Failed > result[j].Failed
+		}
+		return result[i].Group < result[j].Group
+	})
+	return result, nil
+}
+
+func resultGroupKey(groupBy string) (interface{}, error) {
+	switch groupBy {
+	case "", GroupByType:
+		return "$transaction_type", nil
+	case GroupByAccount:
+		return "$account", nil
+	case GroupByResult:
+		return "$result", nil
+	case GroupByHour:
+		return bson.M{"$dateToString": bson.M{"format": "%Y-%m-%dT%H:00:00Z", "date": "$date"}}, nil
+	case GroupByDay:
+		return bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$date"}}, nil
+	}
+	return nil, errors.New("group_by must be transaction_type, account, result, hour or day")
+}
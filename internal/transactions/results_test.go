@@ -0,0 +1,29 @@
+package transactions
+
+import "testing"
+
+func TestResultCategory(t *testing.T) {
+	tests := []struct {
+		result string
+		want   string
+	}{
+		{"tesSUCCESS", ResultSuccess},
+		{"tecPATH_DRY", ResultClaimed},
+		{"tefPAST_SEQ", ResultFailure},
+		{"telINSUF_FEE_P", ResultLocal},
+		{"temBAD_AMOUNT", ResultMalformed},
+		{"terQUEUED", ResultRetry},
+		{"TESSUCCESS", ResultSuccess},
+		{"tuvUNKNOWN", ""},
+		{"te", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.result, func(t *testing.T) {
+			if got := ResultCategory(tt.result); got != tt.want {
+				t.Errorf("ResultCategory(%q) = %q, want %q", tt.result, got, tt.want)
+			}
+		})
+	}
+}
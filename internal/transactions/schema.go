@@ -0,0 +1,25 @@
+package transactions
+
+import "time"
+
+// StateChangeSchema define uma mudança de estado de um objeto do ledger salva no MongoDB
+type StateChangeSchema struct {
+	TxHash           string        `bson:"tx_hash" json:"tx_hash"`
+	TransactionType  string        `bson:"transaction_type" json:"transaction_type"`
+	LedgerIndex      int           `bson:"ledger_index" json:"ledger_index"`
+	TransactionIndex int           `bson:"transaction_index" json:"transaction_index"`
+	NodeType         string        `bson:"node_type" json:"node_type"`
+	ObjectType       string        `bson:"object_type" json:"object_type"`
+	ObjectKey        string        `bson:"object_key" json:"object_key"`
+	Owner            string        `bson:"owner,omitempty" json:"owner,omitempty"`
+	Accounts         []string      `bson:"accounts" json:"accounts"`
+	Fields           []FieldChange `bson:"fields" json:"fields"`
+	CreatedAt        time.Time     `bson:"created_at" json:"created_at"`
+}
+
+// FieldChange define o valor anterior e posterior de um campo
+type FieldChange struct {
+	Field  string      `bson:"field" json:"field"`
+	Before interface{} `bson:"before,omitempty" json:"before,omitempty"`
+	After  interface{} `bson:"after,omitempty" json:"after,omitempty"`
+}
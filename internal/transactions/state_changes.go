@@ -0,0 +1,123 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/ledger"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LedgerTransaction defines a transaction as returned by the ledger method with expand enabled
+type LedgerTransaction struct {
+	Hash            string          `json:"hash"`
+	TransactionType string          `json:"TransactionType"`
+	Account         string          `json:"Account"`
+	Meta            TransactionMeta `json:"metaData"`
+}
+
+// StartStateChanges extracts the state changes of every validated ledger from the ledger stream.
+// Each ledger is fetched in full over HTTP, so it only runs when enabled in the config
+func StartStateChanges(wsClient *xrpl.WebSocketClient, httpClient *xrpl.HTTPClient) error {
+	_, err := ledger.SubscribeValidatedLedgers(wsClient, func(closed *ledger.LedgerSubscribeClosedResponse) {
+		go func(ledgerIndex int) {
+			if err := IngestLedgerStateChanges(httpClient, ledgerIndex); err != nil {
+				log.Printf("❌ Erro ao extrair mudanças de estado do ledger %d: %v", ledgerIndex, err)
+			}
+		}(closed.LedgerIndex)
+	})
+	return err
+}
+
+// IngestLedgerStateChanges fetches a validated ledger and persists the state changes of all its transactions
+func IngestLedgerStateChanges(client *xrpl.HTTPClient, ledgerIndex int) error {
+	response, err := ledger.FetchLedgerTransactions(client, fmt.Sprintf("%d", ledgerIndex))
+	if err != nil {
+		return err
+	}
+
+	changes := []StateChangeSchema{}
+	for _, raw := range response.Result.Ledger.Transactions {
+		var tx LedgerTransaction
+		if err := json.Unmarshal(raw, &tx); err != nil {
+			log.Printf("⚠️ Erro ao interpretar transação do ledger %d: %v", ledgerIndex, err)
+			continue
+		}
+		changes = append(changes, ParseStateChanges(tx.Hash, tx.TransactionType, ledgerIndex, &tx.Meta)...)
+	}
+
+	return SaveStateChanges(changes)
+}
+
+// SaveStateChanges salva mudanças de estado no banco de dados, ignorando duplicadas
+func SaveStateChanges(changes []StateChangeSchema) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(changes))
+	now := time.Now()
+	for i := range changes {
+		changes[i].CreatedAt = now
+		documents[i] = changes[i]
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := database.GetStateChangeCollection().InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		log.Printf("❌ Erro ao salvar mudanças de estado: %v", err)
+		return err
+	}
+
+	log.Printf("✅ %d mudanças de estado salvas (ledger %d)", len(changes), changes[0].LedgerIndex)
+	return nil
+}
+
+// GetObjectHistory returns the state changes of a ledger object, newest first
+func GetObjectHistory(objectKey string, limit int64) ([]StateChangeSchema, error) {
+	return findStateChanges(bson.M{"object_key": objectKey}, limit)
+}
+
+// GetLedgerStateChanges returns all state changes recorded for a ledger
+func GetLedgerStateChanges(ledgerIndex int) ([]StateChangeSchema, error) {
+	return findStateChanges(bson.M{"ledger_index": ledgerIndex}, 0)
+}
+
+// GetAccountStateChanges returns the state changes of objects referencing an account, newest first
+func GetAccountStateChanges(account string, objectType string, limit int64) ([]StateChangeSchema, error) {
+	filter := bson.M{"accounts": account}
+	if objectType != "" {
+		filter["object_type"] = objectType
+	}
+	return findStateChanges(filter, limit)
+}
+
+func findStateChanges(filter bson.M, limit int64) ([]StateChangeSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: -1}, {Key: "transaction_index", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetStateChangeCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []StateChangeSchema{}
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,230 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Ingest normalizes a raw transaction, saves it in the transactions collection and runs the registered handlers
+func Ingest(raw *RawTransaction) (*Transaction, error) {
+	tx, err := Normalize(raw)
+	if err != nil {
+		return nil, err
+	}
+	return tx, Store(tx)
+}
+
+// Store saves a normalized transaction and runs the registered handlers
+func Store(tx *Transaction) error {
+	if err := SaveTransaction(tx); err != nil {
+		return err
+	}
+	runHandlers(tx, true)
+	return nil
+}
+
+// Backfill normalizes and saves a historical transaction, running only the state handlers so
+// that alerts, invoices and deposits do not react to it as if it had just happened
+func Backfill(raw *RawTransaction) (*Transaction, error) {
+	tx, err := Normalize(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := SaveTransaction(tx); err != nil {
+		return tx, err
+	}
+	runHandlers(tx, false)
+	return tx, nil
+}
+
+// SaveTransaction salva ou atualiza uma transação normalizada, usando o hash como chave.
+// Uma transação validada nunca é sobrescrita por uma versão não validada
+func SaveTransaction(tx *Transaction) error {
+	if tx.Hash == "" || tx.Account == "" {
+		log.Printf("⚠️ Dados incompletos ou inválidos: %+v", tx)
+		return nil
+	}
+
+	document, err := toDocument(tx)
+	if err != nil {
+		return err
+	}
+	createdAt := document["created_at"]
+	delete(document, "created_at")
+
+	filter := bson.M{"hash": tx.Hash}
+	if !tx.Validated {
+		filter["validated"] = bson.M{"$ne": true}
+	}
+	update := bson.M{"$set": document, "$setOnInsert": bson.M{"created_at": createdAt}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = database.GetTransactionCollection().UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		log.Printf("❌ Erro ao salvar transação no MongoDB: %v", err)
+		return err
+	}
+	return nil
+}
+
+func toDocument(tx *Transaction) (bson.M, error) {
+	data, err := bson.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var document bson.M
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// GetTransaction returns a stored transaction by hash
+func GetTransaction(hash string) (bson.M, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var document bson.M
+	err := database.GetTransactionCollection().FindOne(ctx, bson.M{"hash": hash}).Decode(&document)
+	if err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// GetAccountTransactions returns stored transactions involving an account, newest first
+func GetAccountTransactions(account string, txType string, limit int64) ([]bson.M, error) {
+	filter := bson.M{"accounts": account}
+	if txType != "" {
+		filter["transaction_type"] = txType
+	}
+	return FindTransactions(filter, limit)
+}
+
+// FindTransactions returns stored transactions matching a filter, newest first
+func FindTransactions(filter bson.M, limit int64) ([]bson.M, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "ledger_index", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetTransactionCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := []bson.M{}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// FetchNormalizedTransaction fetches a transaction by hash via HTTP and returns its normalized model
+func FetchNormalizedTransaction(client *xrpl.HTTPClient, txHash string) (*Transaction, error) {
+	response, err := FetchTransaction(client, txHash, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded TransactionRawResponse
+	if err := json.Unmarshal(response, &decoded); err != nil {
+		return nil, err
+	}
+	if decoded.Result.Error != "" {
+		return nil, &RPCError{Code: decoded.Result.Error, Message: decoded.Result.ErrorMessage}
+	}
+
+	return Normalize(decoded.Result.Raw())
+}
+
+// RPCError represents an error returned by rippled in the result object
+type RPCError struct {
+	Code    string
+	Message string
+}
+
+func (e *RPCError) Error() string {
+	if e.Message != "" {
+		return e.Code + ": " + e.Message
+	}
+	return e.Code
+}
+
+// SearchMemos returns stored transactions whose decoded memos contain a phrase, best matches first
+func SearchMemos(phrase string, account string, limit int64) ([]bson.M, error) {
+	filter := bson.M{"$text": bson.M{"$search": "\"" + strings.ReplaceAll(phrase, "\"", "") + "\""}}
+	if account != "" {
+		filter["accounts"] = account
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "ledger_index", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.GetTransactionCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := []bson.M{}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// BackfillMemos decodes the memos of stored transactions saved before memo decoding existed
+func BackfillMemos(limit int64) (int, error) {
+	filter := bson.M{"memos": bson.M{"$elemMatch": bson.M{"memo_data": bson.M{"$exists": true}, "decoded": bson.M{"$exists": false}}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	cursor, err := database.GetTransactionCollection().Find(ctx, filter, options.Find().SetLimit(limit).SetProjection(bson.M{"hash": 1, "memos": 1}))
+	if err != nil {
+		return 0, err
+	}
+
+	var documents []struct {
+		Hash  string `bson:"hash"`
+		Memos []Memo `bson:"memos"`
+	}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, document := range documents {
+		for i := range document.Memos {
+			document.Memos[i].Decoded = DecodeMemo(document.Memos[i])
+		}
+		_, err := database.GetTransactionCollection().UpdateOne(ctx, bson.M{"hash": document.Hash}, bson.M{"$set": bson.M{"memos": document.Memos}})
+		if err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
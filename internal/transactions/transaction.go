@@ -37,7 +37,7 @@ func FetchTransaction(client *xrpl.HTTPClient, txHash string, binary bool) ([]by
 }
 
 // StreamTransactionEntry fetches transaction entry data in real-time via WebSocket
-func StreamTransactionEntry(wsClient *xrpl.WebSocketClient, txHash string, ledgerIndex string, callback func(*TransactionEntryWSResponse)) error {
+func StreamTransactionEntry(wsClient *xrpl.WebSocketClient, txHash string, ledgerIndex string, callback func(*TransactionEntryWSResponse)) (func(), error) {
 	request := TransactionEntryWSRequest{
 		ID:          5,
 		Command:     "transaction_entry",
@@ -45,22 +45,17 @@ func StreamTransactionEntry(wsClient *xrpl.WebSocketClient, txHash string, ledge
 		LedgerIndex: ledgerIndex,
 	}
 
-	err := wsClient.Subscribe(request)
-	if err != nil {
-		return err
-	}
-
-	wsClient.ReadMessages(func(msg []byte) {
+	// Send the command and read its response
+	return wsClient.Request(request, func(msg []byte) {
 		var response TransactionEntryWSResponse
 		if err := json.Unmarshal(msg, &response); err == nil {
 			callback(&response)
 		}
 	})
-	return nil
 }
 
 // StreamTransaction fetches transaction data in real-time via WebSocket
-func StreamTransaction(wsClient *xrpl.WebSocketClient, txHash string, binary bool, callback func(*TransactionWSResponse)) error {
+func StreamTransaction(wsClient *xrpl.WebSocketClient, txHash string, binary bool, callback func(*TransactionWSResponse)) (func(), error) {
 	request := TransactionWSRequest{
 		ID:          6,
 		Command:     "tx",
@@ -69,16 +64,11 @@ func StreamTransaction(wsClient *xrpl.WebSocketClient, txHash string, binary boo
 		APIVersion:  2,
 	}
 
-	err := wsClient.Subscribe(request)
-	if err != nil {
-		return err
-	}
-
-	wsClient.ReadMessages(func(msg []byte) {
+	// Send the command and read its response
+	return wsClient.Request(request, func(msg []byte) {
 		var response TransactionWSResponse
 		if err := json.Unmarshal(msg, &response); err == nil {
 			callback(&response)
 		}
 	})
-	return nil
 }
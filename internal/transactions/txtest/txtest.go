@@ -0,0 +1,64 @@
+// Package txtest builds transaction metadata fixtures for the tests of the packages that
+// consume transactions. Each node helper renders one affected node as rippled returns it
+package txtest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/transactions"
+)
+
+// IOU renders a token amount
+func IOU(currency, issuer, value string) string {
+	return `{"currency": "` + currency + `", "issuer": "` + issuer + `", "value": "` + value + `"}`
+}
+
+// CreatedAccountNode is an AccountRoot created with an initial balance in drops
+func CreatedAccountNode(account, balance string) string {
+	return `{"CreatedNode": {"LedgerEntryType": "AccountRoot", "LedgerIndex": "AR` + account + `",
+		"NewFields": {"Account": "` + account + `", "Balance": "` + balance + `", "Sequence": 1}}}`
+}
+
+// AccountRootNode is a modified AccountRoot whose balance moved from previous to final drops
+func AccountRootNode(account, previous, final string) string {
+	return `{"ModifiedNode": {"LedgerEntryType": "AccountRoot", "LedgerIndex": "AR` + account + `",
+		"FinalFields": {"Account": "` + account + `", "Balance": "` + final + `"},
+		"PreviousFields": {"Balance": "` + previous + `"}}}`
+}
+
+// AMMNode is the AccountRoot of an AMM pool whose XRP balance moved from previous to final drops
+func AMMNode(account, previous, final string) string {
+	return `{"ModifiedNode": {"LedgerEntryType": "AccountRoot", "LedgerIndex": "AR` + account + `",
+		"FinalFields": {"Account": "` + account + `", "AMMID": "POOL", "Balance": "` + final + `", "OwnerCount": 1},
+		"PreviousFields": {"Balance": "` + previous + `"}}}`
+}
+
+// RippleStateNode is a modified trust line; balances are from the low account's point of view
+func RippleStateNode(low, high, currency, previous, final string) string {
+	return `{"ModifiedNode": {"LedgerEntryType": "RippleState", "LedgerIndex": "RS` + low + high + currency + `",
+		"FinalFields": {"Balance": {"currency": "` + currency + `", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "` + final + `"},
+			"LowLimit": {"currency": "` + currency + `", "issuer": "` + low + `", "value": "0"},
+			"HighLimit": {"currency": "` + currency + `", "issuer": "` + high + `", "value": "0"}},
+		"PreviousFields": {"Balance": {"currency": "` + currency + `", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "` + previous + `"}}}}`
+}
+
+// OfferNode is a partially filled offer; gets and pays are rendered amounts (a drops string or IOU)
+func OfferNode(owner, previousGets, finalGets, previousPays, finalPays string) string {
+	return `{"ModifiedNode": {"LedgerEntryType": "Offer", "LedgerIndex": "OF` + owner + `",
+		"FinalFields": {"Account": "` + owner + `", "TakerGets": ` + finalGets + `, "TakerPays": ` + finalPays + `},
+		"PreviousFields": {"TakerGets": ` + previousGets + `, "TakerPays": ` + previousPays + `}}}`
+}
+
+// Meta assembles the metadata of a successful transaction from affected nodes
+func Meta(t *testing.T, nodes ...string) *transactions.TransactionMeta {
+	t.Helper()
+
+	var meta transactions.TransactionMeta
+	raw := `{"TransactionResult": "tesSUCCESS", "AffectedNodes": [` + strings.Join(nodes, ",") + `]}`
+	if err := json.Unmarshal([]byte(raw), &meta); err != nil {
+		t.Fatalf("metadata fixture: %v\n%s", err, raw)
+	}
+	return &meta
+}
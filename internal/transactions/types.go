@@ -1,5 +1,7 @@
 package transactions
 
+import "encoding/json"
+
 // ---------- HTTP Types ----------
 
 // TransactionEntryRequest defines the structure for HTTP /transaction_entry
@@ -41,6 +43,36 @@ type TransactionResponse struct {
 	} `json:"result"`
 }
 
+// TransactionRawResponse defines the HTTP response for /tx (API v2) keeping tx_json raw for normalization
+type TransactionRawResponse struct {
+	Result TransactionResult `json:"result"`
+}
+
+type TransactionResult struct {
+	TxJSON       json.RawMessage  `json:"tx_json"`
+	Meta         *TransactionMeta `json:"meta,omitempty"`
+	Hash         string           `json:"hash"`
+	LedgerIndex  int              `json:"ledger_index"`
+	LedgerHash   string           `json:"ledger_hash"`
+	CloseTimeISO string           `json:"close_time_iso"`
+	Validated    bool             `json:"validated"`
+	Error        string           `json:"error,omitempty"`
+	ErrorMessage string           `json:"error_message,omitempty"`
+}
+
+// Raw converts a tx result into a RawTransaction
+func (r *TransactionResult) Raw() *RawTransaction {
+	return &RawTransaction{
+		TxJSON:       r.TxJSON,
+		Meta:         r.Meta,
+		Hash:         r.Hash,
+		LedgerIndex:  r.LedgerIndex,
+		LedgerHash:   r.LedgerHash,
+		CloseTimeISO: r.CloseTimeISO,
+		Validated:    r.Validated,
+	}
+}
+
 // ---------- WebSocket Types ----------
 
 // TransactionEntryWSRequest defines the WebSocket request for transaction_entry
@@ -0,0 +1,198 @@
+package validators
+
+import (
+	"sort"
+	"sync"
+)
+
+// RevokedSeq is the manifest sequence a validator publishes to revoke its master key
+const RevokedSeq = 0xFFFFFFFF
+
+// MaxTrackedLedgers bounds how many ledgers of validations are kept in memory
+const MaxTrackedLedgers = 4096
+
+// ledgerVotes holds the hashes signed by each validator (master key) for one ledger index
+type ledgerVotes struct {
+	hashes        map[string]string
+	validatedHash string
+}
+
+// Collector keeps recent validations and manifests in memory to compute agreement reports
+type Collector struct {
+	mu        sync.RWMutex
+	ledgers   map[int]*ledgerVotes
+	manifests map[string]string // signing key -> master key
+	signing   map[string]string // master key -> current signing key
+	seqs      map[string]int    // master key -> sequence of the applied manifest
+	latest    int
+}
+
+// DefaultCollector is the collector fed by the validations stream and the ledger stream
+var DefaultCollector = NewCollector()
+
+func NewCollector() *Collector {
+	return &Collector{
+		ledgers:   make(map[int]*ledgerVotes),
+		manifests: make(map[string]string),
+		signing:   make(map[string]string),
+		seqs:      make(map[string]int),
+	}
+}
+
+// AddManifest maps an ephemeral signing key to its validator master key. Manifests with a
+// sequence not above the applied one are ignored; a revocation drops the signing key for good.
+// It reports whether the manifest was applied
+func (c *Collector) AddManifest(masterKey, signingKey string, seq int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if applied, ok := c.seqs[masterKey]; ok && seq <= applied {
+		return false
+	}
+	c.seqs[masterKey] = seq
+
+	if previous, ok := c.signing[masterKey]; ok && (previous != signingKey || seq == RevokedSeq) {
+		delete(c.manifests, previous)
+	}
+	if seq == RevokedSeq {
+		delete(c.signing, masterKey)
+		return true
+	}
+	c.manifests[signingKey] = masterKey
+	c.signing[masterKey] = signingKey
+	return true
+}
+
+// MasterKey resolves a validation public key to the validator master key, if known
+func (c *Collector) MasterKey(publicKey string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if master, ok := c.manifests[publicKey]; ok {
+		return master
+	}
+	return publicKey
+}
+
+// AddValidation records that a validator signed a ledger hash
+func (c *Collector) AddValidation(ledgerIndex int, ledgerHash, masterKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	votes := c.votes(ledgerIndex)
+	votes.hashes[masterKey] = ledgerHash
+}
+
+// RecordValidatedLedger sets the hash the network validated for a ledger index
+func (c *Collector) RecordValidatedLedger(ledgerIndex int, ledgerHash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	votes := c.votes(ledgerIndex)
+	votes.validatedHash = ledgerHash
+	if ledgerIndex > c.latest {
+		c.latest = ledgerIndex
+	}
+	c.prune()
+}
+
+func (c *Collector) votes(ledgerIndex int) *ledgerVotes {
+	votes, ok := c.ledgers[ledgerIndex]
+	if !ok {
+		votes = &ledgerVotes{hashes: make(map[string]string)}
+		c.ledgers[ledgerIndex] = votes
+	}
+	return votes
+}
+
+func (c *Collector) prune() {
+	for index := range c.ledgers {
+		if index <= c.latest-MaxTrackedLedgers {
+			delete(c.ledgers, index)
+		}
+	}
+}
+
+// referenceHash returns the validated hash of a ledger, falling back to the hash most validators signed
+func (v *ledgerVotes) referenceHash() string {
+	if v.validatedHash != "" {
+		return v.validatedHash
+	}
+
+	counts := make(map[string]int)
+	best, bestCount := "", 0
+	for _, hash := range v.hashes {
+		counts[hash]++
+		if counts[hash] > bestCount {
+			best, bestCount = hash, counts[hash]
+		}
+	}
+	return best
+}
+
+// Report computes agreement and missed-validation rates for every validator seen in the last window ledgers
+func (c *Collector) Report(window int) []ValidatorReport {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	latest := c.latest
+	if latest == 0 {
+		for index := range c.ledgers {
+			if index > latest {
+				latest = index
+			}
+		}
+	}
+
+	reports := make(map[string]*ValidatorReport)
+	for index := latest - window + 1; index <= latest; index++ {
+		if votes, ok := c.ledgers[index]; ok {
+			for master := range votes.hashes {
+				if _, ok := reports[master]; !ok {
+					reports[master] = &ValidatorReport{MasterKey: master, SigningKey: c.signing[master], Window: window}
+				}
+			}
+		}
+	}
+
+	for index := latest - window + 1; index <= latest; index++ {
+		votes, ok := c.ledgers[index]
+		if !ok {
+			continue
+		}
+		reference := votes.referenceHash()
+		if reference == "" {
+			continue
+		}
+
+		for master, report := range reports {
+			report.Ledgers++
+			hash, signed := votes.hashes[master]
+			switch {
+			case !signed:
+				report.Missed++
+			case hash == reference:
+				report.Agreed++
+			default:
+				report.Disagreed++
+			}
+		}
+	}
+
+	result := make([]ValidatorReport, 0, len(reports))
+	for _, report := range reports {
+		if report.Ledgers > 0 {
+			report.AgreementRate = float64(report.Agreed) / float64(report.Ledgers)
+			report.MissedRate = float64(report.Missed) / float64(report.Ledgers)
+		}
+		result = append(result, *report)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].AgreementRate != result[j].AgreementRate {
+			return result[i].AgreementRate > result[j].AgreementRate
+		}
+		return result[i].MasterKey < result[j].MasterKey
+	})
+	return result
+}
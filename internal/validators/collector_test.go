@@ -0,0 +1,87 @@
+package validators
+
+import "testing"
+
+func TestCollectorAddManifest(t *testing.T) {
+	tests := []struct {
+		name        string
+		manifests   []ManifestSchema
+		wantSigning string
+		lookups     map[string]string // validation public key -> expected master key
+	}{
+		{
+			name:        "newer manifest rotates the signing key",
+			manifests:   []ManifestSchema{{MasterKey: "nM", SigningKey: "n1", Seq: 1}, {MasterKey: "nM", SigningKey: "n2", Seq: 2}},
+			wantSigning: "n2",
+			lookups:     map[string]string{"n1": "n1", "n2": "nM"},
+		},
+		{
+			name:        "replayed older manifest is ignored",
+			manifests:   []ManifestSchema{{MasterKey: "nM", SigningKey: "n2", Seq: 2}, {MasterKey: "nM", SigningKey: "n1", Seq: 1}},
+			wantSigning: "n2",
+			lookups:     map[string]string{"n1": "n1", "n2": "nM"},
+		},
+		{
+			name:        "same sequence is ignored",
+			manifests:   []ManifestSchema{{MasterKey: "nM", SigningKey: "n2", Seq: 2}, {MasterKey: "nM", SigningKey: "n3", Seq: 2}},
+			wantSigning: "n2",
+			lookups:     map[string]string{"n2": "nM", "n3": "n3"},
+		},
+		{
+			name:        "revocation drops the signing key and blocks later manifests",
+			manifests:   []ManifestSchema{{MasterKey: "nM", SigningKey: "n2", Seq: 2}, {MasterKey: "nM", Seq: RevokedSeq}, {MasterKey: "nM", SigningKey: "n4", Seq: 4}},
+			wantSigning: "",
+			lookups:     map[string]string{"n2": "n2", "n4": "n4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := NewCollector()
+			for _, manifest := range tt.manifests {
+				collector.AddManifest(manifest.MasterKey, manifest.SigningKey, manifest.Seq)
+			}
+			if got := collector.signing["nM"]; got != tt.wantSigning {
+				t.Fatalf("signing key = %q, want %q", got, tt.wantSigning)
+			}
+			for publicKey, want := range tt.lookups {
+				if got := collector.MasterKey(publicKey); got != want {
+					t.Errorf("MasterKey(%s) = %s, want %s", publicKey, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectorReport(t *testing.T) {
+	collector := NewCollector()
+	// ledger 10: validated hash A, V3 disagrees; ledger 11: no validated hash, majority B, V2 missed
+	collector.AddValidation(10, "A", "V1")
+	collector.AddValidation(10, "A", "V2")
+	collector.AddValidation(10, "X", "V3")
+	collector.RecordValidatedLedger(10, "A")
+	collector.AddValidation(11, "B", "V1")
+	collector.AddValidation(11, "B", "V3")
+	collector.RecordValidatedLedger(11, "")
+
+	want := map[string]ValidatorReport{
+		"V1": {Ledgers: 2, Agreed: 2},
+		"V2": {Ledgers: 2, Agreed: 1, Missed: 1},
+		"V3": {Ledgers: 2, Agreed: 1, Disagreed: 1},
+	}
+
+	reports := collector.Report(2)
+	if len(reports) != len(want) {
+		t.Fatalf("got %d reports, want %d", len(reports), len(want))
+	}
+	if reports[0].MasterKey != "V1" {
+		t.Errorf("first report = %s, want V1 (highest agreement)", reports[0].MasterKey)
+	}
+	for _, report := range reports {
+		expected := want[report.MasterKey]
+		if report.Ledgers != expected.Ledgers || report.Agreed != expected.Agreed ||
+			report.Missed != expected.Missed || report.Disagreed != expected.Disagreed {
+			t.Errorf("%s = %+v, want %+v", report.MasterKey, report, expected)
+		}
+	}
+}
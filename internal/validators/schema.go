@@ -0,0 +1,22 @@
+package validators
+
+import "time"
+
+// ValidationSchema define os campos de uma validação salva no MongoDB
+type ValidationSchema struct {
+	LedgerIndex         int       `bson:"ledger_index" json:"ledger_index"`
+	LedgerHash          string    `bson:"ledger_hash" json:"ledger_hash"`
+	ValidationPublicKey string    `bson:"validation_public_key" json:"validation_public_key"`
+	MasterKey           string    `bson:"master_key" json:"master_key"`
+	SigningTime         time.Time `bson:"signing_time" json:"signing_time"`
+	Full                bool      `bson:"full" json:"full"`
+	CreatedAt           time.Time `bson:"created_at" json:"created_at"`
+}
+
+// ManifestSchema define o mapeamento de chave efêmera para chave mestra salvo no MongoDB
+type ManifestSchema struct {
+	MasterKey  string    `bson:"master_key" json:"master_key"`
+	SigningKey string    `bson:"signing_key" json:"signing_key"`
+	Seq        int       `bson:"seq" json:"seq"`
+	UpdatedAt  time.Time `bson:"updated_at" json:"updated_at"`
+}
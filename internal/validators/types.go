@@ -0,0 +1,45 @@
+package validators
+
+// ---------- WebSocket Types ----------
+
+// SubscribeValidatorsRequest subscribes to the validations and manifests streams
+type SubscribeValidatorsRequest struct {
+	ID      string   `json:"id"`
+	Command string   `json:"command"`
+	Streams []string `json:"streams"`
+}
+
+// ValidationMessage represents a message from the "validations" stream
+type ValidationMessage struct {
+	Type                string `json:"type"`
+	LedgerHash          string `json:"ledger_hash"`
+	LedgerIndex         string `json:"ledger_index"`
+	ValidationPublicKey string `json:"validation_public_key"`
+	MasterKey           string `json:"master_key,omitempty"`
+	SigningTime         int64  `json:"signing_time"`
+	Full                bool   `json:"full"`
+	Flags               int64  `json:"flags"`
+}
+
+// ManifestMessage represents a message from the "manifests" stream
+type ManifestMessage struct {
+	Type            string `json:"type"`
+	MasterKey       string `json:"master_key"`
+	MasterSignature string `json:"master_signature"`
+	Seq             int    `json:"seq"`
+	Signature       string `json:"signature"`
+	SigningKey      string `json:"signing_key"`
+}
+
+// ValidatorReport summarizes a validator's behaviour over a rolling window of validated ledgers
+type ValidatorReport struct {
+	MasterKey     string  `json:"master_key"`
+	SigningKey    string  `json:"signing_key,omitempty"`
+	Window        int     `json:"window"`
+	Ledgers       int     `json:"ledgers"`
+	Agreed        int     `json:"agreed"`
+	Disagreed     int     `json:"disagreed"`
+	Missed        int     `json:"missed"`
+	AgreementRate float64 `json:"agreement_rate"`
+	MissedRate    float64 `json:"missed_rate"`
+}
@@ -0,0 +1,177 @@
+package validators
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/database"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/ledger"
+	"github.com/Panorama-Block/xrpl-data-extraction/internal/xrpl"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Start records the hash of every validated ledger in the default collector, so agreement
+// reports do not depend on a ledger viewer being open
+func Start(wsClient *xrpl.WebSocketClient) error {
+	_, err := ledger.SubscribeValidatedLedgers(wsClient, func(closed *ledger.LedgerSubscribeClosedResponse) {
+		DefaultCollector.RecordValidatedLedger(closed.LedgerIndex, closed.LedgerHash)
+	})
+	return err
+}
+
+// StreamValidators subscribes to the validations and manifests streams and feeds the default collector
+func StreamValidators(wsClient *xrpl.WebSocketClient, stopChan chan struct{}) error {
+	request := SubscribeValidatorsRequest{
+		ID:      "subscribe_validators",
+		Command: "subscribe",
+		Streams: []string{"validations", "manifests"},
+	}
+
+	if err := wsClient.Subscribe(request); err != nil {
+		log.Printf("❌ Erro ao enviar o comando subscribe de validações: %v", err)
+		return err
+	}
+
+	remove := wsClient.AddHandler(HandleMessage)
+	go func() {
+		<-stopChan
+		log.Println("⛔ Encerrando o streaming de validações.")
+		remove()
+
+		// The client only unsubscribes upstream when no other consumer holds the streams
+		request.ID = "unsubscribe_validators"
+		request.Command = "unsubscribe"
+		if err := wsClient.Subscribe(request); err != nil {
+			log.Printf("⚠️ Erro ao cancelar a inscrição de validações: %v", err)
+		}
+	}()
+	return nil
+}
+
+// HandleMessage processes a raw validationReceived or manifestReceived message
+func HandleMessage(msg []byte) {
+	var envelope struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(msg, &envelope); err != nil {
+		return
+	}
+
+	switch envelope.Type {
+	case "validationReceived":
+		var validation ValidationMessage
+		if err := json.Unmarshal(msg, &validation); err != nil {
+			log.Printf("⚠️ Erro ao interpretar validação: %v", err)
+			return
+		}
+		handleValidation(&validation)
+	case "manifestReceived":
+		var manifest ManifestMessage
+		if err := json.Unmarshal(msg, &manifest); err != nil {
+			log.Printf("⚠️ Erro ao interpretar manifest: %v", err)
+			return
+		}
+		if !DefaultCollector.AddManifest(manifest.MasterKey, manifest.SigningKey, manifest.Seq) {
+			return
+		}
+		if err := SaveManifest(&manifest); err != nil {
+			log.Printf("❌ Erro ao salvar manifest: %v", err)
+		}
+	}
+}
+
+func handleValidation(validation *ValidationMessage) {
+	ledgerIndex, err := strconv.Atoi(validation.LedgerIndex)
+	if err != nil {
+		log.Printf("⚠️ ledger_index inválido na validação: %s", validation.LedgerIndex)
+		return
+	}
+
+	// Newer servers include master_key; older ones require the manifest mapping
+	masterKey := validation.MasterKey
+	if masterKey == "" {
+		masterKey = DefaultCollector.MasterKey(validation.ValidationPublicKey)
+	}
+
+	DefaultCollector.AddValidation(ledgerIndex, validation.LedgerHash, masterKey)
+
+	record := ValidationSchema{
+		LedgerIndex:         ledgerIndex,
+		LedgerHash:          validation.LedgerHash,
+		ValidationPublicKey: validation.ValidationPublicKey,
+		MasterKey:           masterKey,
+		SigningTime:         xrpl.RippleTimeToTime(validation.SigningTime),
+		Full:                validation.Full,
+		CreatedAt:           time.Now(),
+	}
+	if err := SaveValidation(&record); err != nil {
+		log.Printf("❌ Erro ao salvar validação: %v", err)
+	}
+}
+
+// SaveValidation salva uma validação no banco de dados
+func SaveValidation(record *ValidationSchema) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := database.GetValidationCollection().InsertOne(ctx, record)
+	return err
+}
+
+// SaveManifest salva ou atualiza o mapeamento de chaves de um validador
+func SaveManifest(manifest *ManifestMessage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	record := ManifestSchema{
+		MasterKey:  manifest.MasterKey,
+		SigningKey: manifest.SigningKey,
+		Seq:        manifest.Seq,
+		UpdatedAt:  time.Now(),
+	}
+
+	// Only replace the stored manifest when the sequence is newer
+	filter := bson.M{"master_key": manifest.MasterKey, "seq": bson.M{"$lt": manifest.Seq}}
+	_, err := database.GetManifestCollection().ReplaceOne(ctx, filter, record, options.Replace().SetUpsert(true))
+	// A stale manifest (lower seq) hits the unique master_key index on upsert; that is expected
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	return nil
+}
+
+// LoadManifests restores the signing key mapping from the database into the default collector
+func LoadManifests() error {
+	manifests, err := GetManifests()
+	if err != nil {
+		return err
+	}
+
+	for _, manifest := range manifests {
+		DefaultCollector.AddManifest(manifest.MasterKey, manifest.SigningKey, manifest.Seq)
+	}
+	log.Printf("✅ %d manifests carregados", len(manifests))
+	return nil
+}
+
+// GetManifests returns all known validator manifests
+func GetManifests() ([]ManifestSchema, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := database.GetManifestCollection().Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := []ManifestSchema{}
+	if err := cursor.All(ctx, &manifests); err != nil {
+		return nil, err
+	}
+	return manifests, nil
+}
@@ -0,0 +1,100 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// DropsPerXRP is the number of drops in one XRP
+const DropsPerXRP = 1000000
+
+// Amount represents an XRPL amount: XRP (value in drops), an issued currency or an MPT
+type Amount struct {
+	Currency      string `json:"currency" bson:"currency"`
+	Issuer        string `json:"issuer,omitempty" bson:"issuer,omitempty"`
+	Value         string `json:"value" bson:"value"`
+	MPTIssuanceID string `json:"mpt_issuance_id,omitempty" bson:"mpt_issuance_id,omitempty"`
+}
+
+// Issue identifies an asset without an amount (e.g. AMM pool assets)
+type Issue struct {
+	Currency      string `json:"currency,omitempty" bson:"currency,omitempty"`
+	Issuer        string `json:"issuer,omitempty" bson:"issuer,omitempty"`
+	MPTIssuanceID string `json:"mpt_issuance_id,omitempty" bson:"mpt_issuance_id,omitempty"`
+}
+
+// UnmarshalJSON accepts both the XRP string form ("1000") and the object form
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var drops string
+	if err := json.Unmarshal(data, &drops); err == nil {
+		*a = Amount{Currency: "XRP", Value: drops}
+		return nil
+	}
+
+	type amount Amount
+	var parsed amount
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	*a = Amount(parsed)
+	return nil
+}
+
+// ParseAmount converts a decoded JSON value (string or map) into an Amount
+func ParseAmount(value interface{}) (*Amount, bool) {
+	switch v := value.(type) {
+	case string:
+		return &Amount{Currency: "XRP", Value: v}, true
+	case map[string]interface{}:
+		amount := &Amount{}
+		amount.Currency, _ = v["currency"].(string)
+		amount.Issuer, _ = v["issuer"].(string)
+		amount.Value, _ = v["value"].(string)
+		amount.MPTIssuanceID, _ = v["mpt_issuance_id"].(string)
+		return amount, amount.Value != ""
+	}
+	return nil, false
+}
+
+// IsXRP reports whether the amount is denominated in XRP drops
+func (a Amount) IsXRP() bool {
+	return a.Currency == "XRP" && a.Issuer == ""
+}
+
+// Float returns the amount as a float; XRP amounts are converted from drops to XRP
+func (a Amount) Float() float64 {
+	value, err := strconv.ParseFloat(a.Value, 64)
+	if err != nil {
+		return 0
+	}
+	if a.IsXRP() {
+		return value / DropsPerXRP
+	}
+	return value
+}
+
+// Key identifies the asset of the amount as "XRP", "CUR.issuer" or the MPT issuance ID
+func (a Amount) Key() string {
+	return Issue{Currency: a.Currency, Issuer: a.Issuer, MPTIssuanceID: a.MPTIssuanceID}.Key()
+}
+
+// Key identifies the asset as "XRP", "CUR.issuer" or the MPT issuance ID
+func (i Issue) Key() string {
+	switch {
+	case i.MPTIssuanceID != "":
+		return i.MPTIssuanceID
+	case i.Issuer == "":
+		return "XRP"
+	default:
+		return i.Currency + "." + i.Issuer
+	}
+}
+
+// RPCValue returns the amount in the form rippled expects in requests: a drops string for XRP,
+// an object otherwise
+func (a Amount) RPCValue() interface{} {
+	if a.IsXRP() {
+		return a.Value
+	}
+	return a
+}
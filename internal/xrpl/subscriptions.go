@@ -0,0 +1,135 @@
+package xrpl
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// subscriptionFields are the subscribe/unsubscribe fields counted per consumer
+var subscriptionFields = []string{"streams", "accounts", "accounts_proposed", "books"}
+
+// subscriptions counts the consumers of each stream, account and book subscribed on a connection
+type subscriptions struct {
+	mu sync.Mutex
+	// active maps a field to the key of each entry (its JSON encoding) and its consumers
+	active map[string]map[string]*subscriptionEntry
+}
+
+type subscriptionEntry struct {
+	value interface{}
+	count int
+}
+
+// add counts every entry of a subscribe command
+func (s *subscriptions) add(command map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active == nil {
+		s.active = map[string]map[string]*subscriptionEntry{}
+	}
+	for _, field := range subscriptionFields {
+		values, _ := command[field].([]interface{})
+		for _, value := range values {
+			key := entryKey(value)
+			if s.active[field] == nil {
+				s.active[field] = map[string]*subscriptionEntry{}
+			}
+			entry, ok := s.active[field][key]
+			if !ok {
+				entry = &subscriptionEntry{value: value}
+				s.active[field][key] = entry
+			}
+			entry.count++
+		}
+	}
+}
+
+// release drops one consumer of every entry of an unsubscribe command and keeps in the command only
+// the entries left without consumers. It returns false when nothing is left to unsubscribe upstream
+func (s *subscriptions) release(command map[string]interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	left := false
+	for _, field := range subscriptionFields {
+		values, ok := command[field].([]interface{})
+		if !ok {
+			continue
+		}
+
+		upstream := []interface{}{}
+		for _, value := range values {
+			key := entryKey(value)
+			entry, tracked := s.active[field][key]
+			if !tracked {
+				// Not subscribed through this client: pass it through
+				upstream = append(upstream, value)
+				continue
+			}
+			entry.count--
+			if entry.count <= 0 {
+				delete(s.active[field], key)
+				upstream = append(upstream, value)
+			}
+		}
+
+		if len(upstream) == 0 {
+			delete(command, field)
+			continue
+		}
+		command[field] = upstream
+		left = true
+	}
+	return left
+}
+
+// replay returns a subscribe command for every active entry, or nil when there is none
+func (s *subscriptions) replay() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	command := map[string]interface{}{"id": "resubscribe", "command": "subscribe"}
+	empty := true
+	for _, field := range subscriptionFields {
+		values := []interface{}{}
+		for _, entry := range s.active[field] {
+			values = append(values, entry.value)
+		}
+		if len(values) > 0 {
+			command[field] = values
+			empty = false
+		}
+	}
+	if empty {
+		return nil
+	}
+
+	encoded, err := json.Marshal(command)
+	if err != nil {
+		return nil
+	}
+	return encoded
+}
+
+// decodeCommand decodes a request into a map, keeping numbers as they were written
+func decodeCommand(request []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(request))
+	decoder.UseNumber()
+
+	var command map[string]interface{}
+	if err := decoder.Decode(&command); err != nil {
+		return nil, err
+	}
+	return command, nil
+}
+
+// entryKey identifies a stream or account by its name and a book by its JSON encoding (sorted keys)
+func entryKey(value interface{}) string {
+	if name, ok := value.(string); ok {
+		return name
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
@@ -0,0 +1,16 @@
+package xrpl
+
+import "time"
+
+// RippleEpochOffset is the number of seconds between the Unix epoch and the Ripple epoch (2000-01-01T00:00:00Z)
+const RippleEpochOffset = 946684800
+
+// RippleTimeToTime converts a Ripple epoch timestamp (seconds since 2000-01-01) to time.Time
+func RippleTimeToTime(rippleTime int64) time.Time {
+	return time.Unix(rippleTime+RippleEpochOffset, 0).UTC()
+}
+
+// TimeToRippleTime converts a time.Time to a Ripple epoch timestamp
+func TimeToRippleTime(t time.Time) int64 {
+	return t.Unix() - RippleEpochOffset
+}
@@ -0,0 +1,59 @@
+package xrpl
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ParseValue parses a decimal amount value (drops or token value); invalid values parse as zero
+func ParseValue(value string) *big.Float {
+	parsed, _, err := big.ParseFloat(value, 10, 128, big.ToNearestEven)
+	if err != nil {
+		return new(big.Float).SetPrec(128)
+	}
+	return parsed
+}
+
+// FormatValue renders a value in plain decimal notation, never with an exponent. It keeps 18
+// significant digits, which cover the XRP supply in drops and token precision (16 digits)
+func FormatValue(value *big.Float) string {
+	if value.Sign() == 0 {
+		return "0"
+	}
+
+	// d.ddddddddddddddddde±x, rounded to 18 significant digits
+	text := value.Text('e', 17)
+	sign := ""
+	if text[0] == '-' {
+		sign, text = "-", text[1:]
+	}
+	mantissa, exponent, _ := strings.Cut(text, "e")
+	shift, _ := strconv.Atoi(exponent)
+	digits := strings.TrimRight(strings.Replace(mantissa, ".", "", 1), "0")
+
+	// Position of the decimal point within digits
+	point := shift + 1
+	switch {
+	case point <= 0:
+		return sign + "0." + strings.Repeat("0", -point) + digits
+	case point >= len(digits):
+		return sign + digits + strings.Repeat("0", point-len(digits))
+	}
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// AddValues returns a + b
+func AddValues(a, b string) string {
+	return FormatValue(new(big.Float).SetPrec(128).Add(ParseValue(a), ParseValue(b)))
+}
+
+// SubtractValues returns a - b
+func SubtractValues(a, b string) string {
+	return FormatValue(new(big.Float).SetPrec(128).Sub(ParseValue(a), ParseValue(b)))
+}
+
+// NegateValue returns -a
+func NegateValue(a string) string {
+	return FormatValue(new(big.Float).SetPrec(128).Neg(ParseValue(a)))
+}
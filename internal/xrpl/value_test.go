@@ -0,0 +1,33 @@
+package xrpl
+
+import "testing"
+
+func TestValueArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "add drops", got: AddValues("1000000", "12"), want: "1000012"},
+		{name: "add token values", got: AddValues("0.1", "0.2"), want: "0.3"},
+		{name: "subtract to zero", got: SubtractValues("5.5", "5.5"), want: "0"},
+		{name: "subtract below zero", got: SubtractValues("1", "2.25"), want: "-1.25"},
+		{name: "negate", got: NegateValue("-7.5"), want: "7.5"},
+		{name: "negate zero", got: NegateValue("0"), want: "0"},
+		{name: "invalid parses as zero", got: AddValues("abc", "3"), want: "3"},
+		{name: "XRP supply in drops", got: AddValues("99999999999999999", "1"), want: "100000000000000000"},
+		{name: "token exponent", got: AddValues("1e-15", "1"), want: "1.000000000000001"},
+		{name: "small token value without exponent", got: AddValues("1e-20", "0"), want: "0.00000000000000000001"},
+		{name: "large token value without exponent", got: AddValues("1.5e20", "0"), want: "150000000000000000000"},
+		{name: "negative fraction", got: SubtractValues("0.001", "0.0025"), want: "-0.0015"},
+		{name: "rounded to 18 significant digits", got: AddValues("0.1234567890123456789", "0"), want: "0.123456789012345679"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %s, want %s", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package xrpl
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"log"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -10,6 +14,36 @@ import (
 
 type WebSocketClient struct {
 	Connection *websocket.Conn
+	URL        string
+
+	// gorilla allows one concurrent writer and one concurrent reader: writes go through writeMu and
+	// a single read loop queues every message to the registered handlers
+	writeMu    sync.Mutex
+	handlersMu sync.RWMutex
+	handlers   []*messageHandler
+	nextID     int
+	readerOnce sync.Once
+	requestSeq atomic.Int64
+	closed     atomic.Bool
+
+	subscriptions subscriptions
+}
+
+// HandlerQueueSize is the number of messages buffered per handler before new ones are dropped
+const HandlerQueueSize = 1000
+
+// reconnectDelay is the wait before dialing again after the connection drops
+var reconnectDelay = 5 * time.Second
+
+// requestTimeout bounds how long Request waits for the response of a command
+const requestTimeout = 30 * time.Second
+
+// messageHandler runs its callback on its own goroutine, so a slow consumer never stalls the
+// read loop or the other consumers
+type messageHandler struct {
+	id       int
+	callback func(message []byte)
+	queue    chan []byte
 }
 
 // create a new WebSocket client
@@ -25,36 +59,201 @@ func NewWebSocketClient(url string) (*WebSocketClient, error) {
 	}
 
 	log.Printf("Connected to WebSocket server: %s", url)
-	return &WebSocketClient{Connection: conn}, nil // return a new WebSocket client
+	return &WebSocketClient{Connection: conn, URL: url}, nil // return a new WebSocket client
 }
 
-// send a request to the WebSocket server
+// send a request to the WebSocket server. Subscribe and unsubscribe commands are counted per
+// stream, account and book: an unsubscribe only goes upstream for the entries no other consumer
+// holds, and the active subscriptions are replayed after a reconnect
 func (wsc *WebSocketClient) Subscribe(request interface{}) error {
 	reqJSON, err := json.Marshal(request) // convert the request to JSON
 	if err != nil {
 		return err
 	}
+
+	command, err := decodeCommand(reqJSON)
+	if err == nil {
+		switch command["command"] {
+		case "subscribe":
+			wsc.subscriptions.add(command)
+		case "unsubscribe":
+			if !wsc.subscriptions.release(command) {
+				return nil
+			}
+			if reqJSON, err = json.Marshal(command); err != nil {
+				return err
+			}
+		}
+	}
+
+	return wsc.write(reqJSON)
+}
+
+// Request sends a one-off command and calls callback with its response. The command gets an id
+// unique to the client, so concurrent commands never read each other's responses. The handler is
+// removed once the response arrives, after requestTimeout or when the returned function is called
+func (wsc *WebSocketClient) Request(request interface{}, callback func(message []byte)) (func(), error) {
+	reqJSON, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	command, err := decodeCommand(reqJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the type of the caller's id, since responses are decoded into the caller's structs
+	seq := wsc.requestSeq.Add(1)
+	if original, ok := command["id"].(string); ok {
+		command["id"] = fmt.Sprintf("%s_%d", original, seq)
+	} else {
+		command["id"] = seq
+	}
+	id, err := json.Marshal(command["id"])
+	if err != nil {
+		return nil, err
+	}
+	if reqJSON, err = json.Marshal(command); err != nil {
+		return nil, err
+	}
+
+	var answered sync.Once
+	done := make(chan struct{})
+	remove := wsc.AddHandler(func(message []byte) {
+		var envelope struct {
+			ID   json.RawMessage `json:"id"`
+			Type string          `json:"type"`
+		}
+		if err := json.Unmarshal(message, &envelope); err != nil || envelope.Type != "response" || !bytes.Equal(envelope.ID, id) {
+			return
+		}
+		answered.Do(func() {
+			callback(message)
+			close(done)
+		})
+	})
+
+	if err := wsc.write(reqJSON); err != nil {
+		remove()
+		return nil, err
+	}
+
+	cancel := make(chan struct{})
+	var cancelOnce sync.Once
+	go func() {
+		select {
+		case <-done:
+		case <-cancel:
+		case <-time.After(requestTimeout):
+		}
+		remove()
+	}()
+	return func() { cancelOnce.Do(func() { close(cancel) }) }, nil
+}
+
+func (wsc *WebSocketClient) write(message []byte) error {
+	wsc.writeMu.Lock()
+	defer wsc.writeMu.Unlock()
 	// send the request to the WebSocket server as a text message 
-	return wsc.Connection.WriteMessage(websocket.TextMessage, reqJSON)
+	return wsc.Connection.WriteMessage(websocket.TextMessage, message)
 }
 
-// read messages from the WebSocket server
-func (wsc *WebSocketClient) ReadMessages(callback func(message []byte)) {
-	defer wsc.Connection.Close() // close the connection when the function returns
+// AddHandler registers a callback for every message received on the connection and starts the
+// shared read loop on first use. Each callback has its own queue of HandlerQueueSize messages and
+// runs on its own goroutine. The returned function removes the callback
+func (wsc *WebSocketClient) AddHandler(callback func(message []byte)) func() {
+	wsc.handlersMu.Lock()
+	wsc.nextID++
+	handler := &messageHandler{id: wsc.nextID, callback: callback, queue: make(chan []byte, HandlerQueueSize)}
+	wsc.handlers = append(wsc.handlers, handler)
+	wsc.handlersMu.Unlock()
 
+	go handler.run()
+	wsc.readerOnce.Do(func() {
+		go wsc.readLoop()
+	})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { wsc.removeHandler(handler.id) })
+	}
+}
+
+func (h *messageHandler) run() {
+	for message := range h.queue {
+		h.callback(message)
+	}
+}
+
+// removeHandler unregisters a handler and closes its queue; the read loop only queues under
+// handlersMu, so it never sends on a closed queue
+func (wsc *WebSocketClient) removeHandler(id int) {
+	wsc.handlersMu.Lock()
+	defer wsc.handlersMu.Unlock()
+
+	for i, handler := range wsc.handlers {
+		if handler.id == id {
+			wsc.handlers = append(wsc.handlers[:i:i], wsc.handlers[i+1:]...)
+			close(handler.queue)
+			return
+		}
+	}
+}
+
+// ReadMessages registers callback on the shared read loop and returns the function that removes it
+func (wsc *WebSocketClient) ReadMessages(callback func(message []byte)) func() {
+	return wsc.AddHandler(callback)
+}
+
+// Close closes the connection and stops the read loop instead of reconnecting
+func (wsc *WebSocketClient) Close() error {
+	wsc.closed.Store(true)
+	wsc.writeMu.Lock()
+	defer wsc.writeMu.Unlock()
+	return wsc.Connection.Close()
+}
+
+// readLoop is the only reader of the connection; it reconnects when the connection drops
+func (wsc *WebSocketClient) readLoop() {
 	for {
-    _, msg, err := wsc.Connection.ReadMessage()
-    if err != nil {
-        log.Printf("⚠️ WebSocket desconectado. Tentando reconectar... Erro: %v", err)
-        time.Sleep(5 * time.Second) // Esperar antes de tentar reconectar
-        wsc.Connection, _, err = websocket.DefaultDialer.Dial(wsc.Connection.RemoteAddr().String(), nil)
-        if err != nil {
-            log.Printf("❌ Falha ao reconectar: %v", err)
-            continue
-        }
-        log.Println("✅ Reconexão bem-sucedida!")
-        continue
-    }
-    callback(msg)
+		wsc.writeMu.Lock()
+		conn := wsc.Connection
+		wsc.writeMu.Unlock()
+
+		_, msg, err := conn.ReadMessage()
+		if err != nil && wsc.closed.Load() {
+			return
+		}
+		if err != nil {
+			log.Printf("⚠️ WebSocket desconectado. Tentando reconectar... Erro: %v", err)
+			time.Sleep(reconnectDelay) // Esperar antes de tentar reconectar
+			reconnected, _, err := websocket.DefaultDialer.Dial(wsc.URL, nil)
+			if err != nil {
+				log.Printf("❌ Falha ao reconectar: %v", err)
+				continue
+			}
+			wsc.writeMu.Lock()
+			wsc.Connection = reconnected
+			wsc.writeMu.Unlock()
+			log.Println("✅ Reconexão bem-sucedida!")
+
+			// A new connection starts without subscriptions
+			if replay := wsc.subscriptions.replay(); replay != nil {
+				if err := wsc.write(replay); err != nil {
+					log.Printf("❌ Falha ao refazer as inscrições: %v", err)
+				}
+			}
+			continue
+		}
+
+		wsc.handlersMu.RLock()
+		for _, handler := range wsc.handlers {
+			select {
+			case handler.queue <- msg:
+			default:
+				log.Printf("⚠️ Fila do consumidor %d do WebSocket cheia, mensagem descartada", handler.id)
+			}
+		}
+		wsc.handlersMu.RUnlock()
 	}
 }
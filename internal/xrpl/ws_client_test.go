@@ -0,0 +1,236 @@
+package xrpl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// echoServer answers every text message with the same payload
+func echoServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+		for {
+			kind, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(kind, msg); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+func TestAddHandlerFansOutToEveryHandler(t *testing.T) {
+	server := echoServer(t)
+	defer server.Close()
+
+	client, err := NewWebSocketClient("ws" + strings.TrimPrefix(server.URL, "http"))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	first := make(chan string, 4)
+	second := make(chan string, 4)
+	client.AddHandler(func(msg []byte) { first <- string(msg) })
+	removeSecond := client.AddHandler(func(msg []byte) { second <- string(msg) })
+
+	if err := client.Subscribe(map[string]string{"command": "ping"}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	for _, received := range []chan string{first, second} {
+		select {
+		case msg := <-received:
+			if msg != `{"command":"ping"}` {
+				t.Fatalf("unexpected message %s", msg)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("handler did not receive the message")
+		}
+	}
+
+	removeSecond()
+	if err := client.Subscribe(map[string]string{"command": "pong"}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	select {
+	case <-first:
+	case <-time.After(2 * time.Second):
+		t.Fatal("remaining handler did not receive the message")
+	}
+	select {
+	case msg := <-second:
+		t.Fatalf("removed handler received %s", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// fakeRippled answers every command with an empty successful response and reports the commands
+// it receives. The first connection is dropped after dropAfter commands when dropAfter > 0
+func fakeRippled(t *testing.T, dropAfter int) (*httptest.Server, chan map[string]interface{}) {
+	upgrader := websocket.Upgrader{}
+	received := make(chan map[string]interface{}, 100)
+	var connections atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+		first := connections.Add(1) == 1
+
+		for count := 1; ; count++ {
+			var command map[string]interface{}
+			if err := conn.ReadJSON(&command); err != nil {
+				return
+			}
+			received <- command
+			response := map[string]interface{}{"id": command["id"], "type": "response", "status": "success", "result": map[string]interface{}{}}
+			if err := conn.WriteJSON(response); err != nil {
+				return
+			}
+			if first && count == dropAfter {
+				return
+			}
+		}
+	}))
+	return server, received
+}
+
+func dial(t *testing.T, server *httptest.Server) *WebSocketClient {
+	client, err := NewWebSocketClient("ws" + strings.TrimPrefix(server.URL, "http"))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	return client
+}
+
+func next(t *testing.T, received chan map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	select {
+	case command := <-received:
+		return command
+	case <-time.After(2 * time.Second):
+		t.Fatal("no command received")
+		return nil
+	}
+}
+
+func TestRequestReadsOnlyItsOwnResponse(t *testing.T) {
+	server, received := fakeRippled(t, 0)
+	defer server.Close()
+	client := dial(t, server)
+	defer client.Close()
+
+	// Both callers use the same id, as the fixed-id request structs do
+	responses := make(chan string, 4)
+	for i := 0; i < 2; i++ {
+		if _, err := client.Request(map[string]interface{}{"id": "fee", "command": "fee"}, func(message []byte) {
+			responses <- string(message)
+		}); err != nil {
+			t.Fatalf("request: %v", err)
+		}
+	}
+
+	ids := map[interface{}]bool{next(t, received)["id"]: true, next(t, received)["id"]: true}
+	if len(ids) != 2 {
+		t.Fatalf("requests share the id %v", ids)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-responses:
+		case <-time.After(2 * time.Second):
+			t.Fatal("response not delivered")
+		}
+	}
+	select {
+	case message := <-responses:
+		t.Fatalf("response delivered twice: %s", message)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Answered requests remove their handler
+	deadline := time.Now().Add(time.Second)
+	for {
+		client.handlersMu.RLock()
+		left := len(client.handlers)
+		client.handlersMu.RUnlock()
+		if left == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d handlers left after the responses", left)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestUnsubscribeOnlyReleasesUnsharedStreams(t *testing.T) {
+	server, received := fakeRippled(t, 0)
+	defer server.Close()
+	client := dial(t, server)
+	defer client.Close()
+
+	subscribe := map[string]interface{}{"command": "subscribe", "streams": []string{"ledger", "transactions_proposed"}}
+	unsubscribe := map[string]interface{}{"command": "unsubscribe", "streams": []string{"transactions_proposed"}}
+
+	client.Subscribe(subscribe)
+	client.Subscribe(map[string]interface{}{"command": "subscribe", "streams": []string{"transactions_proposed"}})
+	next(t, received)
+	next(t, received)
+
+	// Another consumer still holds transactions_proposed
+	client.Subscribe(unsubscribe)
+	client.Subscribe(map[string]interface{}{"command": "ping"})
+	if command := next(t, received); command["command"] != "ping" {
+		t.Fatalf("unsubscribe sent while the stream is shared: %v", command)
+	}
+
+	client.Subscribe(unsubscribe)
+	command := next(t, received)
+	streams, _ := command["streams"].([]interface{})
+	if command["command"] != "unsubscribe" || len(streams) != 1 || streams[0] != "transactions_proposed" {
+		t.Fatalf("unexpected command %v", command)
+	}
+}
+
+func TestReconnectReplaysSubscriptions(t *testing.T) {
+	defer func(delay time.Duration) { reconnectDelay = delay }(reconnectDelay)
+	reconnectDelay = 10 * time.Millisecond
+
+	server, received := fakeRippled(t, 2)
+	defer server.Close()
+	client := dial(t, server)
+	defer client.Close()
+
+	client.AddHandler(func([]byte) {})
+	client.Subscribe(map[string]interface{}{"command": "subscribe", "streams": []string{"ledger"}, "accounts": []string{"rAlice"}})
+	client.Subscribe(map[string]interface{}{"command": "subscribe", "accounts": []string{"rBob"}})
+	next(t, received)
+	next(t, received)
+
+	command := next(t, received)
+	if command["command"] != "subscribe" || command["id"] != "resubscribe" {
+		t.Fatalf("unexpected command after reconnect %v", command)
+	}
+	accounts, _ := command["accounts"].([]interface{})
+	streams, _ := command["streams"].([]interface{})
+	if len(accounts) != 2 || len(streams) != 1 || streams[0] != "ledger" {
+		t.Fatalf("replayed %v", command)
+	}
+}